@@ -17,15 +17,28 @@ import (
 	"github.com/purushothdl/ecommerce-api/internal/admin"
 	"github.com/purushothdl/ecommerce-api/internal/auth"
 	"github.com/purushothdl/ecommerce-api/internal/cart"
+	"github.com/purushothdl/ecommerce-api/internal/catalog"
 	"github.com/purushothdl/ecommerce-api/internal/category"
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
 	"github.com/purushothdl/ecommerce-api/internal/database"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/inventory"
 	"github.com/purushothdl/ecommerce-api/internal/order"
 	"github.com/purushothdl/ecommerce-api/internal/payment"
+	"github.com/purushothdl/ecommerce-api/internal/pricing"
 	"github.com/purushothdl/ecommerce-api/internal/product"
+	"github.com/purushothdl/ecommerce-api/internal/recommendation"
+	"github.com/purushothdl/ecommerce-api/internal/reminder"
+	"github.com/purushothdl/ecommerce-api/internal/review"
 	"github.com/purushothdl/ecommerce-api/internal/server"
 	"github.com/purushothdl/ecommerce-api/internal/shared/tasks"
+	"github.com/purushothdl/ecommerce-api/internal/storage"
 	"github.com/purushothdl/ecommerce-api/internal/user"
+	"github.com/purushothdl/ecommerce-api/internal/warehouse"
+	"github.com/purushothdl/ecommerce-api/internal/wishlist"
+	"github.com/purushothdl/ecommerce-api/pkg/cache"
+	"github.com/purushothdl/ecommerce-api/pkg/money"
+	"github.com/purushothdl/ecommerce-api/pkg/web"
 )
 
 type application struct {
@@ -41,6 +54,16 @@ type application struct {
 	addressService  domain.AddressService
 	orderService    domain.OrderService
 	paymentService  domain.PaymentService
+	reviewService   domain.ReviewService
+	catalogService  domain.CatalogService
+	inventoryService domain.InventoryService
+	warehouseService domain.WarehouseService
+	pricingService  domain.PricingService
+	recommendationService domain.RecommendationService
+	wishlistService domain.WishlistService
+	couponService   domain.CouponService
+	cartReminderService domain.CartReminderService
+	catalogCache    *cache.Cache
 }
 
 func main() {
@@ -89,6 +112,12 @@ func run() error {
 		"max_idle_conns", cfg.DB.MaxIdleConns,
 	)
 
+	// Initialize blob storage for uploaded files
+	blobStore, err := storage.NewLocalBlobStore(cfg.Storage.LocalDir, cfg.Storage.PublicURL)
+	if err != nil {
+		return fmt.Errorf("failed to initialize blob storage: %w", err)
+	}
+
 	// Initialize store for transactions
 	store := database.NewStore(db)
 
@@ -97,19 +126,45 @@ func run() error {
 	authRepo := auth.NewAuthRepository(db)
 	categoryRepo := category.NewCategoryRepository(db)
 	productRepo := product.NewProductRepository(db)
+	variantRepo := product.NewVariantRepository(db)
+	attributeRepo := category.NewAttributeRepository(db)
 	cartRepo := cart.NewCartRepository(db)
 	addressRepo := address.NewAddressRepository(db)
+	reviewRepo := review.NewReviewRepository(db)
+	movementRepo := inventory.NewMovementRepository(db)
+	stockSubscriptionRepo := inventory.NewStockSubscriptionRepository(db)
+	warehouseRepo := warehouse.NewWarehouseRepository(db)
+	warehouseStockRepo := warehouse.NewWarehouseStockRepository(db)
+	priceScheduleRepo := pricing.NewPriceScheduleRepository(db)
+	priceHistoryRepo := pricing.NewPriceHistoryRepository(db)
+	recommendationRepo := recommendation.NewRecommendationRepository(db)
+	wishlistRepo := wishlist.NewWishlistRepository(db)
+	couponRepo := coupon.NewCouponRepository(db)
+	cartReminderRepo := reminder.NewCartReminderRepository(db)
 
 	// Setup services (implement domain interfaces)
 	paymentService := payment.NewStripeService(cfg.Stripe) 
-	cartService := cart.NewCartService(cartRepo, productRepo, store, logger)
-	authService := auth.NewAuthService(authRepo, userRepo, cartService, cfg.JWT.Secret, logger)
-	userService := user.NewUserService(userRepo, authService, cartService, logger)	
+	cartService := cart.NewCartService(cartRepo, productRepo, couponRepo, store, logger)
+	wishlistService := wishlist.NewWishlistService(wishlistRepo, cartService, store, logger)
+	authService := auth.NewAuthService(authRepo, userRepo, cartService, wishlistService, cfg.JWT.Secret, logger)
+	userService := user.NewUserService(userRepo, authService, cartService, wishlistService, logger)	
 	adminService := admin.NewAdminService(userRepo, logger)
-	categoryService := category.NewCategoryService(categoryRepo, logger)
-	productService := product.NewProductService(productRepo, logger)
+	// Catalog reads are cached; both services share one cache so any write clears both.
+	catalogCache := cache.New(cfg.Cache.TTL, cfg.Cache.MaxEntries)
+	categoryService := category.NewCachedCategoryService(category.NewCategoryService(categoryRepo, attributeRepo, logger), catalogCache)
+	productService := product.NewCachedProductService(
+		product.NewProductService(productRepo, variantRepo, attributeRepo, store, blobStore, cfg.Storage.ThumbnailSize, cfg.Storage.MaxImagePixels, logger), catalogCache)
 	addressService := address.NewAddressService(addressRepo, store, logger)
-	orderService := order.NewOrderService(store, paymentService, taskCreator, logger, cfg.OrderFinancials)
+	orderService := order.NewOrderService(store, paymentService, taskCreator, logger, cfg.OrderFinancials, cfg.Inventory, money.NewRates(cfg.Currency.Base, cfg.Currency.Rates), catalogCache)
+	reviewService := review.NewReviewService(reviewRepo, productRepo, logger)
+	catalogService := catalog.NewCatalogService(productRepo, variantRepo, categoryService, store, logger)
+	inventoryService := inventory.NewInventoryService(productRepo, movementRepo, stockSubscriptionRepo, store, taskCreator, cfg.Currency.Base, logger)
+	warehouseService := warehouse.NewWarehouseService(warehouseRepo, warehouseStockRepo, productRepo, store, logger)
+	pricingService := pricing.NewPricingService(priceScheduleRepo, priceHistoryRepo, productRepo, store, logger)
+	recommendationService := recommendation.NewRecommendationService(recommendationRepo, productRepo, store, logger)
+	couponService := coupon.NewCouponService(couponRepo, cartService, logger)
+	// Reminders are sent by the mega-worker; the API only handles unsubscribes and stats.
+	cartReminderService := reminder.NewCartReminderService(cartReminderRepo, store, nil, web.NewUnsubscribeTokens(cfg.Cart.UnsubscribeSecret), "", cfg.Currency.Base, logger)
 
 	app := &application{
 		config:          cfg,
@@ -124,6 +179,16 @@ func run() error {
 		addressService:  addressService,
 		orderService:    orderService,
 		paymentService:  paymentService,
+		reviewService:   reviewService,
+		catalogService:  catalogService,
+		inventoryService: inventoryService,
+		warehouseService: warehouseService,
+		pricingService:  pricingService,
+		recommendationService: recommendationService,
+		wishlistService: wishlistService,
+		couponService:   couponService,
+		cartReminderService: cartReminderService,
+		catalogCache:    catalogCache,
 	}
 
 	// Start server
@@ -137,6 +202,8 @@ func (app *application) startServer() error {
 			app.config, app.logger, app.userService, app.authService,
 			app.adminService, app.productService, app.categoryService,
 			app.cartService, app.store, app.addressService, app.orderService, app.paymentService,
+			app.reviewService, app.catalogService, app.inventoryService, app.warehouseService,
+			app.pricingService, app.recommendationService, app.wishlistService, app.couponService, app.cartReminderService, app.catalogCache,
 		).Router(),
 		ReadTimeout:  app.config.Server.ReadTimeout,
 		WriteTimeout: app.config.Server.WriteTimeout,
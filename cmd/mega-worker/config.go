@@ -4,6 +4,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -38,6 +40,14 @@ type WorkerConfig struct {
 	PendingOrderCleanupThreshold time.Duration
 	AnonymousCartCleanupThreshold time.Duration
 
+	// Abandoned cart reminders
+	AbandonedCartThreshold    time.Duration // Idle time before a cart is reminded, and between reminders
+	AbandonedCartMaxReminders int           // Reminders per cart between orders; zero turns them off
+	UnsubscribeTokenSecret    string        // Signs unsubscribe links; must match the API's
+
+	// Currency catalog prices are in, for stock emails
+	BaseCurrency string
+
 	// Database Configuration
 	DB DBConfig
 }
@@ -61,6 +71,11 @@ func LoadWorkerConfig(path string) (*WorkerConfig, error) {
 		port = "8081"
 	}
 
+	baseCurrency := strings.ToUpper(os.Getenv("BASE_CURRENCY"))
+	if baseCurrency == "" {
+		baseCurrency = "INR"
+	}
+
 	return &WorkerConfig{
 		Port:                 port,
 		Env:                  os.Getenv("ENV"),
@@ -78,6 +93,10 @@ func LoadWorkerConfig(path string) (*WorkerConfig, error) {
 		DeliveryProcessingTime:  getEnvAsDuration("DELIVERY_PROCESSING_TIME", 20*time.Second),
 		PendingOrderCleanupThreshold: getEnvAsDuration("PENDING_ORDER_CLEANUP_THRESHOLD", 2*time.Hour),
 		AnonymousCartCleanupThreshold: getEnvAsDuration("ANONYMOUS_CART_CLEANUP_THRESHOLD", 24*time.Hour),
+		AbandonedCartThreshold:    getEnvAsDuration("ABANDONED_CART_THRESHOLD", 24*time.Hour),
+		AbandonedCartMaxReminders: getEnvAsInt("ABANDONED_CART_MAX_REMINDERS", 2),
+		UnsubscribeTokenSecret:    os.Getenv("UNSUBSCRIBE_TOKEN_SECRET"),
+		BaseCurrency:         baseCurrency,
 		DB: DBConfig{
 			DSN:             os.Getenv("DB_DSN"),
 			MaxOpenConns:    25,
@@ -96,3 +115,12 @@ func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
 	}
 	return fallback
 }
+
+func getEnvAsInt(key string, fallback int) int {
+	if valueStr, exists := os.LookupEnv(key); exists {
+		if value, err := strconv.Atoi(valueStr); err == nil {
+			return value
+		}
+	}
+	return fallback
+}
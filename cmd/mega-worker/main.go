@@ -7,16 +7,22 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/purushothdl/ecommerce-api/configs"
 	"github.com/purushothdl/ecommerce-api/internal/cart"
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
 	"github.com/purushothdl/ecommerce-api/internal/database"
+	"github.com/purushothdl/ecommerce-api/internal/inventory"
 	"github.com/purushothdl/ecommerce-api/internal/order"
 	"github.com/purushothdl/ecommerce-api/internal/product"
+	"github.com/purushothdl/ecommerce-api/internal/recommendation"
+	"github.com/purushothdl/ecommerce-api/internal/reminder"
 	"github.com/purushothdl/ecommerce-api/internal/shared/tasks"
 	apiclient "github.com/purushothdl/ecommerce-api/pkg/api-client"
+	"github.com/purushothdl/ecommerce-api/pkg/web"
 	"github.com/purushothdl/ecommerce-api/workers/cleanup"
 	"github.com/purushothdl/ecommerce-api/workers/delivery"
 	"github.com/purushothdl/ecommerce-api/workers/notification"
@@ -38,6 +44,9 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if cfg.AbandonedCartMaxReminders > 0 && cfg.UnsubscribeTokenSecret == "" {
+		return fmt.Errorf("UNSUBSCRIBE_TOKEN_SECRET is required to send abandoned cart reminders; set ABANDONED_CART_MAX_REMINDERS=0 to turn them off")
+	}
 
 	// The worker now needs its own DB connection to run cleanup logic.
 	db, err := database.NewPostgres(configs.DBConfig{DSN: cfg.DB.DSN})
@@ -73,6 +82,11 @@ func run() error {
 	// Initialize Services for the Worker ---
     productRepo := product.NewProductRepository(db)
     cartRepo := cart.NewCartRepository(db)
+    movementRepo := inventory.NewMovementRepository(db)
+    stockSubscriptionRepo := inventory.NewStockSubscriptionRepository(db)
+    recommendationRepo := recommendation.NewRecommendationRepository(db)
+    couponRepo := coupon.NewCouponRepository(db)
+    cartReminderRepo := reminder.NewCartReminderRepository(db)
 
     // Initialize Template Service
     templateService, err := notification.NewTemplateService()
@@ -80,15 +94,19 @@ func run() error {
 		return fmt.Errorf("failed to create template service: %w", err)
 	}
 	emailService := notification.NewEmailService(cfg.ResendAPIKey, cfg.ResendFromEmail, cfg.ResendFromName, logger)
-	cartService := cart.NewCartService(cartRepo, productRepo, store, logger)
-	orderService := order.NewOrderService(store, nil, nil, logger, nil)
+	cartService := cart.NewCartService(cartRepo, productRepo, couponRepo, store, logger)
+	orderService := order.NewOrderService(store, nil, nil, logger, nil, configs.InventoryConfig{}, nil, nil)
+	inventoryService := inventory.NewInventoryService(productRepo, movementRepo, stockSubscriptionRepo, store, taskCreator, cfg.BaseCurrency, logger)
+	recommendationService := recommendation.NewRecommendationService(recommendationRepo, productRepo, store, logger)
+	unsubscribeURL := strings.TrimRight(cfg.ApiURL, "/") + "/api/v1/notifications/unsubscribe"
+	cartReminderService := reminder.NewCartReminderService(cartReminderRepo, store, taskCreator, web.NewUnsubscribeTokens(cfg.UnsubscribeTokenSecret), unsubscribeURL, cfg.BaseCurrency, logger)
 	
 	// Initialize handlers
 	wh := warehouse.NewWarehouseHandler(logger, taskCreator, apiClient, cfg.WarehouseProcessingTime)
 	sh := shipping.NewShippingHandler(logger, taskCreator, apiClient, cfg.ShippingProcessingTime)
 	dh := delivery.NewDeliveryHandler(logger, taskCreator, apiClient, cfg.DeliveryProcessingTime)
 	nh := notification.NewNotificationHandler(logger, emailService, templateService)
-	cleanH := cleanup.NewCleanupHandler(logger, orderService, cartService, cfg.PendingOrderCleanupThreshold, cfg.AnonymousCartCleanupThreshold) 
+	cleanH := cleanup.NewCleanupHandler(logger, orderService, cartService, inventoryService, recommendationService, cartReminderService, cfg.PendingOrderCleanupThreshold, cfg.AnonymousCartCleanupThreshold, cfg.AbandonedCartThreshold, cfg.AbandonedCartMaxReminders) 
 
 	// Setup router
 	r := chi.NewRouter()
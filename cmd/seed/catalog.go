@@ -0,0 +1,106 @@
+// cmd/seed/catalog.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/purushothdl/ecommerce-api/internal/catalog"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+)
+
+// runImportCommand implements `seed import [-dry-run] [-format csv|json] <file>`.
+func runImportCommand(ctx context.Context, svc domain.CatalogService, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "validate the file and report changes without writing them")
+	formatFlag := fs.String("format", "", "file format: csv or json (default: from the file extension)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: seed import [-dry-run] [-format csv|json] <file>")
+	}
+	path := fs.Arg(0)
+
+	if *formatFlag == "" {
+		*formatFlag = filepath.Ext(path)
+	}
+	format, err := catalog.ParseFormat(*formatFlag)
+	if err != nil {
+		return fmt.Errorf("cannot import %s: %w", path, err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := catalog.Parse(file, format)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	report, err := svc.ImportProducts(ctx, nil, rows, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to print import report: %w", err)
+	}
+
+	log.Printf("Import of %d rows: %d to create, %d to update, %d invalid, applied=%t",
+		report.Total, report.Created, report.Updated, report.Failed, report.Applied)
+	if report.Failed > 0 {
+		return fmt.Errorf("%d rows failed validation, nothing was imported", report.Failed)
+	}
+	return nil
+}
+
+// runExportCommand implements `seed export [-format csv|json] [-o file]`.
+func runExportCommand(ctx context.Context, svc domain.CatalogService, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	formatFlag := fs.String("format", "", "file format: csv or json (default: from -o, else json)")
+	output := fs.String("o", "", "output file (default: stdout)")
+	fs.Parse(args)
+
+	if *formatFlag == "" {
+		*formatFlag = "json"
+		if *output != "" {
+			*formatFlag = filepath.Ext(*output)
+		}
+	}
+	format, err := catalog.ParseFormat(*formatFlag)
+	if err != nil {
+		return fmt.Errorf("cannot export: %w", err)
+	}
+
+	rows, err := svc.ExportProducts(ctx)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := catalog.Write(w, format, rows); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	log.Printf("Exported %d products", len(rows))
+	return nil
+}
@@ -4,14 +4,22 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
+	"os"
 
 	"github.com/purushothdl/ecommerce-api/configs"
+	"github.com/purushothdl/ecommerce-api/internal/catalog"
 	"github.com/purushothdl/ecommerce-api/internal/category"
 	"github.com/purushothdl/ecommerce-api/internal/database"
 	"github.com/purushothdl/ecommerce-api/internal/product"
 	"github.com/purushothdl/ecommerce-api/internal/user"
 )
 
+// Usage:
+//
+//	seed                                              run the seeder tasks
+//	seed import [-dry-run] [-format csv|json] <file>  upsert products from a catalog file
+//	seed export [-format csv|json] [-o file]          write the full catalog
 func main() {
 	// 1. Load configuration
 	cfg, err := configs.LoadConfig("api.env")
@@ -33,6 +41,29 @@ func main() {
 		ProductRepo:  product.NewProductRepository(db),
 	}
 
+	// Catalog subcommands go through the same service as the admin endpoints.
+	if len(os.Args) > 1 {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+		categoryService := category.NewCategoryService(deps.CategoryRepo, category.NewAttributeRepository(db), logger)
+		catalogService := catalog.NewCatalogService(
+			deps.ProductRepo, product.NewVariantRepository(db), categoryService, database.NewStore(db), logger,
+		)
+
+		ctx := context.Background()
+		switch os.Args[1] {
+		case "import":
+			err = runImportCommand(ctx, catalogService, os.Args[2:])
+		case "export":
+			err = runExportCommand(ctx, catalogService, os.Args[2:])
+		default:
+			log.Fatalf("unknown command %q (expected import or export)", os.Args[1])
+		}
+		if err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	// 4. Define the list of tasks to run
 	// The order here matters!
 	tasks := []SeederTask{
@@ -42,4 +73,4 @@ func main() {
 
 	// 5. Run the seeder
 	RunSeeders(context.Background(), deps, tasks)
-}
\ No newline at end of file
+}
@@ -25,6 +25,11 @@ type Config struct {
 	ApiURL          string
 	OrderFinancials *OrderFinancialsConfig
 	GCTasks         tasks.TaskCreatorConfig
+	Storage         StorageConfig
+	Inventory       InventoryConfig
+	Cache           CacheConfig
+	Currency        CurrencyConfig
+	Cart            CartConfig
 }
 
 // Database configuration
@@ -58,6 +63,7 @@ type TimeoutConfig struct {
 	UserOps   time.Duration
 	Protected time.Duration
 	Database  time.Duration
+	Import    time.Duration
 }
 
 // CORS configuration for API access control
@@ -74,7 +80,6 @@ type CORSConfig struct {
 type OrderFinancialsConfig struct {
 	OrderShippingCost    float64
 	OrderTaxRate         float64
-	OrderDiscountAmount  float64
 }
 
 // Stripe payment configuration
@@ -83,6 +88,42 @@ type StripeConfig struct {
 	WebhookSecret  string
 }
 
+// Blob storage configuration for uploaded files
+type StorageConfig struct {
+	Backend        string
+	LocalDir       string
+	PublicURL      string
+	MaxUploadBytes int64
+	ThumbnailSize  int
+	MaxImagePixels int // Largest width x height accepted for an uploaded image
+}
+
+// Stock handling configuration
+type InventoryConfig struct {
+	ReservationTTL     time.Duration // How long checkout holds stock for an unpaid order
+	AllocationStrategy string        // How checkout picks the warehouse: "nearest" or "priority"
+}
+
+// Catalog caching configuration
+type CacheConfig struct {
+	TTL        time.Duration // How long catalog reads are kept in memory; zero disables the cache
+	MaxEntries int           // Upper bound on cached catalog responses
+	MaxAge     time.Duration // Cache-Control max-age sent with catalog responses
+}
+
+// Currency configuration for selling in more than one currency
+type CurrencyConfig struct {
+	Base  string             // Currency catalog prices and order financials are configured in
+	Rates map[string]float64 // Units of each other currency per one unit of Base
+}
+
+// Cart cookie and cart reminder configuration
+type CartConfig struct {
+	TokenSecret        string    // Key used to sign cart and wishlist cookies; defaults to the JWT secret
+	LegacyCookiesUntil time.Time // Unsigned cart ID cookies are still accepted before this time
+	UnsubscribeSecret  string    // Key that signs unsubscribe links in reminder emails; must match the worker's
+}
+
 func LoadConfig(path string) (*Config, error) {
 	// Load .env file if it exists (ignore error in production)
 	if err := godotenv.Load(path); err != nil && os.Getenv("ENV") != "production" {
@@ -121,6 +162,7 @@ func LoadConfig(path string) (*Config, error) {
 			UserOps:   getEnvAsDuration("TIMEOUT_USER_OPS", 15*time.Second),
 			Protected: getEnvAsDuration("TIMEOUT_PROTECTED", 8*time.Second),
 			Database:  getEnvAsDuration("TIMEOUT_DATABASE", 5*time.Second),
+			Import:    getEnvAsDuration("TIMEOUT_IMPORT", 2*time.Minute),
 		},
 
 		CORS: CORSConfig{
@@ -142,7 +184,6 @@ func LoadConfig(path string) (*Config, error) {
 		OrderFinancials: &OrderFinancialsConfig{
 			OrderShippingCost:    getEnvAsFloat64("ORDER_SHIPPING_COST", 50.00),
 			OrderTaxRate:         getEnvAsFloat64("ORDER_TAX_RATE", 0.18),
-			OrderDiscountAmount:  getEnvAsFloat64("ORDER_DISCOUNT_AMOUNT", 0.00),
 		},
 
 		GCTasks: tasks.TaskCreatorConfig{
@@ -153,8 +194,46 @@ func LoadConfig(path string) (*Config, error) {
 			ServiceAccount: getEnv("MEGA_WORKER_SA_EMAIL", ""),
 		},
 
+		Storage: StorageConfig{
+			Backend:        getEnv("STORAGE_BACKEND", "local"),
+			LocalDir:       getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+			PublicURL:      getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080/uploads"),
+			MaxUploadBytes: int64(getEnvAsInt("UPLOAD_MAX_BYTES", 5<<20)),
+			ThumbnailSize:  getEnvAsInt("UPLOAD_THUMBNAIL_SIZE", 300),
+			MaxImagePixels: getEnvAsInt("UPLOAD_MAX_IMAGE_PIXELS", 40000000),
+		},
+
+		Inventory: InventoryConfig{
+			ReservationTTL:     getEnvAsDuration("RESERVATION_TTL", 15*time.Minute),
+			AllocationStrategy: getEnv("ALLOCATION_STRATEGY", "nearest"),
+		},
+
+		Cache: CacheConfig{
+			TTL:        getEnvAsDuration("CATALOG_CACHE_TTL", 30*time.Second),
+			MaxEntries: getEnvAsInt("CATALOG_CACHE_MAX_ENTRIES", 1000),
+			MaxAge:     getEnvAsDuration("HTTP_CACHE_MAX_AGE", time.Minute),
+		},
+
+		Currency: CurrencyConfig{
+			Base: strings.ToUpper(getEnv("BASE_CURRENCY", "INR")),
+		},
+
 	}
 
+	rates, err := parseRates(getEnv("CURRENCY_RATES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	cfg.Currency.Rates = rates
+
+	cfg.Cart.TokenSecret = getEnv("CART_TOKEN_SECRET", cfg.JWT.Secret)
+	cfg.Cart.UnsubscribeSecret = getEnv("UNSUBSCRIBE_TOKEN_SECRET", cfg.JWT.Secret)
+	legacyUntil, err := parseDate(getEnv("CART_LEGACY_COOKIES_UNTIL", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: CART_LEGACY_COOKIES_UNTIL: %w", err)
+	}
+	cfg.Cart.LegacyCookiesUntil = legacyUntil
+
 	// Validate critical config
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -177,6 +256,34 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("database DSN is required")
 	}
 
+	if c.Storage.Backend != "local" {
+		return fmt.Errorf("unsupported storage backend: %q", c.Storage.Backend)
+	}
+
+	if c.Storage.MaxUploadBytes <= 0 || c.Storage.ThumbnailSize <= 0 || c.Storage.MaxImagePixels <= 0 {
+		return fmt.Errorf("upload size limit, thumbnail size and image pixel limit must be positive")
+	}
+
+	if c.Inventory.ReservationTTL <= 0 {
+		return fmt.Errorf("stock reservation TTL must be positive")
+	}
+
+	if c.Inventory.AllocationStrategy != "nearest" && c.Inventory.AllocationStrategy != "priority" {
+		return fmt.Errorf("allocation strategy must be nearest or priority, got %q", c.Inventory.AllocationStrategy)
+	}
+
+	if len(c.Currency.Base) != 3 {
+		return fmt.Errorf("base currency must be a three-letter ISO code, got %q", c.Currency.Base)
+	}
+
+	if c.Cart.TokenSecret == "" || c.Cart.UnsubscribeSecret == "" {
+		return fmt.Errorf("cart token and unsubscribe token secrets cannot be empty")
+	}
+
+	if c.Cache.MaxEntries <= 0 || c.Cache.MaxAge < 0 {
+		return fmt.Errorf("catalog cache size must be positive and HTTP cache max-age must not be negative")
+	}
+
 	return nil
 }
 
@@ -223,3 +330,38 @@ func getEnvAsBool(key string, fallback bool) bool {
 	}
 	return fallback
 }
+
+// parseRates reads an exchange rate table written as "USD=0.012,EUR=0.011".
+func parseRates(value string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		code, rateStr, ok := strings.Cut(pair, "=")
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !ok || len(code) != 3 {
+			return nil, fmt.Errorf("invalid exchange rate %q: want CODE=rate", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: must be a positive number", code)
+		}
+		rates[code] = rate
+	}
+	return rates, nil
+}
+
+// parseDate reads an RFC 3339 timestamp or a plain "2006-01-02" date (midnight UTC).
+// An empty value yields the zero time.
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
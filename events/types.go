@@ -13,8 +13,10 @@ type OrderCreatedEvent struct {
 	OrderNumber     string           `json:"order_number"`
 	UserEmail       string           `json:"user_email"`
 	TotalAmount     float64          `json:"total_amount"`
+	Currency        string           `json:"currency"` // Currency of TotalAmount and item prices; empty means INR for older events
 	OrderDate       time.Time        `json:"order_date"`
 	Items           []OrderItemInfo  `json:"items"`
+	Warehouse       *WarehouseInfo   `json:"warehouse,omitempty"` // Location allocated at checkout; nil for older orders
 }
 
 // OrderPackedEvent is triggered by the warehouse.
@@ -46,6 +48,43 @@ type OrderDeliveredEvent struct {
 	DeliveredAt time.Time `json:"delivered_at"`
 }
 
+// LowStockEvent tells an admin that a product's stock has fallen to its threshold.
+type LowStockEvent struct {
+	ProductID     int64  `json:"product_id"`
+	ProductName   string `json:"product_name"`
+	SKU           string `json:"sku"`
+	StockQuantity int    `json:"stock_quantity"`
+	Threshold     int    `json:"threshold"`
+}
+
+// BackInStockEvent tells a subscribed customer that a product is available again.
+type BackInStockEvent struct {
+	ProductID   int64   `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	Thumbnail   string  `json:"thumbnail"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency"` // Currency of Price; empty means INR for older events
+	UserName    string  `json:"user_name"`
+}
+
+// AbandonedCartEvent reminds a customer of the items left in their cart.
+type AbandonedCartEvent struct {
+	UserName       string         `json:"user_name"`
+	CartName       string         `json:"cart_name,omitempty"` // Set for named carts
+	Items          []CartItemInfo `json:"items"`
+	Total          float64        `json:"total"`
+	Currency       string         `json:"currency"`
+	UnsubscribeURL string         `json:"unsubscribe_url"`
+}
+
+// CartItemInfo is one cart line as shown in an abandoned cart email.
+type CartItemInfo struct {
+	ProductName string  `json:"product_name"`
+	Thumbnail   string  `json:"thumbnail"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+}
+
 // NotificationEvent is a generic event for the notification service.
 type NotificationEvent struct {
 	UserEmail string `json:"user_email"`
@@ -68,6 +107,13 @@ type OrderItemInfo struct {
 	UnitPrice   float64 `json:"unit_price"`
 }
 
+// WarehouseInfo identifies the location an order ships from.
+type WarehouseInfo struct {
+	ID   int64  `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
 type OrderAddressInfo struct {
 	Name       string `json:"name"`
 	Street1    string `json:"street1"`
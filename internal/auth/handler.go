@@ -19,29 +19,35 @@ import (
 )
 
 type Handler struct {
-	authService  domain.AuthService
-	store   	 domain.Store
-	cartService  domain.CartService
-	jwtSecret    string
-	isProduction bool
-	logger       *slog.Logger
+	authService    domain.AuthService
+	store          domain.Store
+	cartService    domain.CartService
+	jwtSecret      string
+	cartTokens     *web.AnonymousTokens
+	wishlistTokens *web.AnonymousTokens
+	isProduction   bool
+	logger         *slog.Logger
 }
 
 func NewHandler(
-	authService  domain.AuthService, 
-	store 		 domain.Store,
-	cartService  domain.CartService, 
-	jwtSecret    string, 
-	isProduction bool, 
-	logger       *slog.Logger,
+	authService    domain.AuthService, 
+	store          domain.Store,
+	cartService    domain.CartService, 
+	jwtSecret      string, 
+	cartTokens     *web.AnonymousTokens,
+	wishlistTokens *web.AnonymousTokens,
+	isProduction   bool, 
+	logger         *slog.Logger,
 ) *Handler {
 	return &Handler{
-		authService:  authService,
-		store:        store,
-		cartService:  cartService,
-		jwtSecret:    jwtSecret,
-		isProduction: isProduction,
-		logger:       logger,
+		authService:    authService,
+		store:          store,
+		cartService:    cartService,
+		jwtSecret:      jwtSecret,
+		cartTokens:     cartTokens,
+		wishlistTokens: wishlistTokens,
+		isProduction:   isProduction,
+		logger:         logger,
 	}
 }
 
@@ -68,16 +74,14 @@ func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
         return
     }
 
-    // Parse anonymous cart ID
-    var anonymousCartID *int64
-    if cookie, err := r.Cookie(web.CartIDCookieName); err == nil {
-        if id, parseErr := strconv.ParseInt(cookie.Value, 10, 64); parseErr == nil {
-            anonymousCartID = &id
-        }
-    }
+    // Anonymous cart ID from the signed cart cookie; forged cookies are ignored
+    anonymousCartID := h.cartTokens.IDFromRequest(r)
+
+    // Anonymous wishlist ID from the signed wishlist cookie; forged cookies are ignored
+    anonymousWishlistID := h.wishlistTokens.IDFromRequest(r)
 
     // Single transactional login call
-    user, refreshToken, err := h.authService.LoginWithCartMerge(r.Context(), h.store, input.Email, input.Password, anonymousCartID)
+    user, refreshToken, err := h.authService.LoginWithCartMerge(r.Context(), h.store, input.Email, input.Password, anonymousCartID, anonymousWishlistID)
     if err != nil {
         h.logger.Warn("auth service login failed", "email", input.Email, "error", err)
         response.Error(w, http.StatusUnauthorized, "Invalid email or password")
@@ -88,6 +92,9 @@ func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
     if anonymousCartID != nil && *anonymousCartID != 0 {
         web.ClearCookie(w, web.CartIDCookieName, h.isProduction)
     }
+    if anonymousWishlistID != nil && *anonymousWishlistID != 0 {
+        web.ClearCookie(w, web.WishlistIDCookieName, h.isProduction)
+    }
 
     // Generate access token (outside transaction)
     accessToken, err := GenerateAccessToken(user, h.jwtSecret)
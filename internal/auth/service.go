@@ -20,21 +20,23 @@ type authService struct {
 	userRepo    domain.UserRepository
 	authRepo    domain.AuthRepository
 	cartService domain.CartService
+	wishlistService domain.WishlistService
 	jwtSecret   string
 	logger      *slog.Logger
 }
 
-func NewAuthService(authRepo domain.AuthRepository, userRepo domain.UserRepository, cartService domain.CartService, jwtSecret string, logger *slog.Logger) domain.AuthService {
+func NewAuthService(authRepo domain.AuthRepository, userRepo domain.UserRepository, cartService domain.CartService, wishlistService domain.WishlistService, jwtSecret string, logger *slog.Logger) domain.AuthService {
 	return &authService{
 		authRepo:    authRepo,
 		userRepo:    userRepo,
 		cartService: cartService,
+		wishlistService: wishlistService,
 		jwtSecret:   jwtSecret,
 		logger:      logger,
 	}
 }
 
-func (s *authService) LoginWithCartMerge(ctx context.Context, store domain.Store, email, password string, anonymousCartID *int64) (*models.User, *models.RefreshToken, error) {
+func (s *authService) LoginWithCartMerge(ctx context.Context, store domain.Store, email, password string, anonymousCartID, anonymousWishlistID *int64) (*models.User, *models.RefreshToken, error) {
     // First, authenticate user (outside transaction)
     user, err := s.userRepo.GetByEmail(ctx, email)
     if err != nil {
@@ -99,6 +101,13 @@ func (s *authService) LoginWithCartMerge(ctx context.Context, store domain.Store
             }
         }
 
+        // 4. Handle wishlist merge the same way
+        if anonymousWishlistID != nil && *anonymousWishlistID != 0 {
+            if err := s.wishlistService.HandleLoginWithTransaction(ctx, q, user.ID, *anonymousWishlistID); err != nil {
+                return fmt.Errorf("failed to merge wishlist: %w", err)
+            }
+        }
+
         return nil
     })
 
@@ -9,7 +9,9 @@ import (
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
 	"github.com/purushothdl/ecommerce-api/internal/shared/context"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
 	"github.com/purushothdl/ecommerce-api/pkg/response"
@@ -17,12 +19,13 @@ import (
 )
 
 type Handler struct {
-	cartSvc domain.CartService
-	logger  *slog.Logger
+	cartSvc   domain.CartService
+	couponSvc domain.CouponService
+	logger    *slog.Logger
 }
 
-func NewHandler(cartSvc domain.CartService, logger *slog.Logger) *Handler {
-	return &Handler{cartSvc: cartSvc, logger: logger}
+func NewHandler(cartSvc domain.CartService, couponSvc domain.CouponService, logger *slog.Logger) *Handler {
+	return &Handler{cartSvc: cartSvc, couponSvc: couponSvc, logger: logger}
 }
 
 // HandleGetCart returns the cart with items and totals
@@ -46,6 +49,97 @@ func (h *Handler) HandleGetCart(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, resp)
 }
 
+// HandleFixCart resolves the cart's warnings so it can be checked out, and reports
+// which lines were removed, reduced or repriced.
+func (h *Handler) HandleFixCart(w http.ResponseWriter, r *http.Request) {
+	cartCtx, err := context.GetCart(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "cart unavailable")
+		return
+	}
+
+	fixedCart, changes, err := h.cartSvc.FixCart(r.Context(), cartCtx.ID)
+	if err != nil {
+		// Stock moved between reading the cart and adjusting it; a retry sees the new level.
+		if errors.Is(err, apperrors.ErrInsufficientStock) || errors.Is(err, apperrors.ErrProductUnavailable) {
+			response.Error(w, http.StatusConflict, "stock changed while fixing the cart, please try again")
+			return
+		}
+		h.logger.Error("failed to fix cart", "cart_id", cartCtx.ID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not fix cart")
+		return
+	}
+
+	if changes == nil {
+		changes = []models.CartFix{}
+	}
+	response.JSON(w, http.StatusOK, FixCartResponse{Cart: NewCartResponse(fixedCart, fixedCart.Items), Changes: changes})
+}
+
+// HandleApplyCoupon applies a coupon code to the cart. The coupon must take something
+// off the cart as it is now.
+func (h *Handler) HandleApplyCoupon(w http.ResponseWriter, r *http.Request) {
+	cartCtx, err := context.GetCart(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "cart unavailable")
+		return
+	}
+
+	var input ApplyCouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request format")
+		return
+	}
+
+	v := validator.New()
+	if input.Validate(v); !v.Valid() {
+		response.ValidationError(w, v.Errors)
+		return
+	}
+
+	// Per-customer limits can only be checked for signed-in customers; checkout checks them again.
+	var userID *int64
+	if id, err := context.GetUserID(r.Context()); err == nil {
+		userID = &id
+	}
+
+	updatedCart, err := h.couponSvc.ApplyToCart(r.Context(), cartCtx.ID, userID, input.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "coupon not found")
+		case coupon.IsCouponProblem(err):
+			response.Error(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.logger.Error("failed to apply coupon", "cart_id", cartCtx.ID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not apply coupon")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, NewCartResponse(updatedCart, updatedCart.Items))
+}
+
+// HandleRemoveCoupon takes a coupon off the cart.
+func (h *Handler) HandleRemoveCoupon(w http.ResponseWriter, r *http.Request) {
+	cartCtx, err := context.GetCart(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "cart unavailable")
+		return
+	}
+
+	updatedCart, err := h.couponSvc.RemoveFromCart(r.Context(), cartCtx.ID, chi.URLParam(r, "code"))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "coupon is not applied to the cart")
+			return
+		}
+		h.logger.Error("failed to remove coupon", "cart_id", cartCtx.ID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not remove coupon")
+		return
+	}
+	response.JSON(w, http.StatusOK, NewCartResponse(updatedCart, updatedCart.Items))
+}
+
 // HandleAddItem adds a product to the cart
 func (h *Handler) HandleAddItem(w http.ResponseWriter, r *http.Request) {
 	cartCtx, err := context.GetCart(r.Context())
@@ -68,11 +162,15 @@ func (h *Handler) HandleAddItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Business logic
-	updatedCart, err := h.cartSvc.AddProductToCart(r.Context(), cartCtx.ID, input.ProductID, input.Quantity)
+	updatedCart, err := h.cartSvc.AddProductToCart(r.Context(), cartCtx.ID, input.ProductID, input.VariantID, input.Quantity)
 	if err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrNotFound):
 			response.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, apperrors.ErrVariantRequired):
+			response.Error(w, http.StatusUnprocessableEntity, "this product is sold in variants, please choose one")
+		case errors.Is(err, apperrors.ErrProductUnavailable):
+			response.Error(w, http.StatusUnprocessableEntity, "this product is not available for purchase")
 		case errors.Is(err, apperrors.ErrInsufficientStock):
 			response.Error(w, http.StatusConflict, "insufficient stock")
 		default:
@@ -109,6 +207,7 @@ func (h *Handler) HandleUpdateItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	v := validator.New()
+	variantID := ParseVariantID(r.URL.Query(), v)
 	if input.Validate(v); !v.Valid() {
 		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
 		return
@@ -116,7 +215,11 @@ func (h *Handler) HandleUpdateItem(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Info("request to update item quantity", "cart_id", cart.ID, "product_id", productID, "new_quantity", input.Quantity)
 
-	updatedCart, err := h.cartSvc.UpdateProductInCart(r.Context(), cart.ID, productID, input.Quantity)
+	updatedCart, err := h.cartSvc.UpdateProductInCart(r.Context(), cart.ID, productID, variantID, input.Quantity)
+	if errors.Is(err, apperrors.ErrProductUnavailable) {
+		response.Error(w, http.StatusUnprocessableEntity, "this product is no longer available for purchase, remove it from the cart")
+		return
+	}
 	if err != nil {
 		// The service layer handles the case where quantity is 0 by calling Remove.
 		// We only need to handle generic errors here.
@@ -143,9 +246,16 @@ func (h *Handler) HandleRemoveItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Info("request to remove item from cart", "cart_id", cart.ID, "product_id", productID)
+	v := validator.New()
+	variantID := ParseVariantID(r.URL.Query(), v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	h.logger.Info("request to remove item from cart", "cart_id", cart.ID, "product_id", productID, "variant_id", variantID)
 
-	_, err = h.cartSvc.RemoveProductFromCart(r.Context(), cart.ID, productID)
+	_, err = h.cartSvc.RemoveProductFromCart(r.Context(), cart.ID, productID, variantID)
 	if err != nil {
 		h.logger.Error("unhandled error removing item from cart", "error", err)
 		response.Error(w, http.StatusInternalServerError, "could not remove item from cart")
@@ -155,3 +265,178 @@ func (h *Handler) HandleRemoveItem(w http.ResponseWriter, r *http.Request) {
 	resp := response.MessageResponse{Message: "item removed successfully"}
 	response.JSON(w, http.StatusOK, resp)
 }
+
+// HandleSaveForLater moves a line out of the cart into its saved-for-later list.
+func (h *Handler) HandleSaveForLater(w http.ResponseWriter, r *http.Request) {
+	h.handleSetSavedForLater(w, r, true)
+}
+
+// HandleMoveSavedToCart brings a line saved for later back into the cart.
+func (h *Handler) HandleMoveSavedToCart(w http.ResponseWriter, r *http.Request) {
+	h.handleSetSavedForLater(w, r, false)
+}
+
+func (h *Handler) handleSetSavedForLater(w http.ResponseWriter, r *http.Request, saved bool) {
+	cartCtx, err := context.GetCart(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "cart unavailable")
+		return
+	}
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID in URL")
+		return
+	}
+
+	v := validator.New()
+	variantID := ParseVariantID(r.URL.Query(), v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	var updatedCart *models.Cart
+	if saved {
+		updatedCart, err = h.cartSvc.SaveForLater(r.Context(), cartCtx.ID, productID, variantID)
+	} else {
+		updatedCart, err = h.cartSvc.MoveToCart(r.Context(), cartCtx.ID, productID, variantID)
+	}
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "item not found in cart")
+			return
+		}
+		h.logger.Error("failed to move cart item", "cart_id", cartCtx.ID, "product_id", productID, "saved", saved, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not update cart")
+		return
+	}
+	response.JSON(w, http.StatusOK, NewCartResponse(updatedCart, updatedCart.Items))
+}
+
+// HandleListCarts lists the signed-in user's carts. Any of them can be used with the
+// cart and checkout endpoints by passing its ID as ?cart_id=.
+func (h *Handler) HandleListCarts(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	carts, err := h.cartSvc.ListCarts(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to list carts", "user_id", userID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not list carts")
+		return
+	}
+
+	resp := make([]CartSummaryResponse, len(carts))
+	for i := range carts {
+		resp[i] = NewCartSummaryResponse(&carts[i])
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// HandleCreateCart creates a named cart for the signed-in user.
+func (h *Handler) HandleCreateCart(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var input CartNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request format")
+		return
+	}
+
+	v := validator.New()
+	if input.Validate(v); !v.Valid() {
+		response.ValidationError(w, v.Errors)
+		return
+	}
+
+	cart, err := h.cartSvc.CreateNamedCart(r.Context(), userID, input.Name)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrDuplicateCartName) {
+			response.Error(w, http.StatusConflict, err.Error())
+			return
+		}
+		h.logger.Error("failed to create cart", "user_id", userID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not create cart")
+		return
+	}
+	response.JSON(w, http.StatusCreated, NewCartSummaryResponse(cart))
+}
+
+// HandleRenameCart renames one of the signed-in user's carts.
+func (h *Handler) HandleRenameCart(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	cartID, err := strconv.ParseInt(chi.URLParam(r, "cartId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid cart ID")
+		return
+	}
+
+	var input CartNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request format")
+		return
+	}
+
+	v := validator.New()
+	if input.Validate(v); !v.Valid() {
+		response.ValidationError(w, v.Errors)
+		return
+	}
+
+	cart, err := h.cartSvc.RenameCart(r.Context(), userID, cartID, input.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "cart not found")
+		case errors.Is(err, apperrors.ErrDuplicateCartName):
+			response.Error(w, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("failed to rename cart", "cart_id", cartID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not rename cart")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, NewCartSummaryResponse(cart))
+}
+
+// HandleDeleteCart deletes one of the signed-in user's named carts and its contents.
+func (h *Handler) HandleDeleteCart(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	cartID, err := strconv.ParseInt(chi.URLParam(r, "cartId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid cart ID")
+		return
+	}
+
+	if err := h.cartSvc.DeleteCart(r.Context(), userID, cartID); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "cart not found")
+		case errors.Is(err, apperrors.ErrDefaultCart):
+			response.Error(w, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("failed to delete cart", "cart_id", cartID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not delete cart")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "cart deleted successfully"})
+}
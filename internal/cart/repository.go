@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/models"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
@@ -21,10 +22,17 @@ func NewCartRepository(db domain.DBTX) domain.CartRepository {
 	return &cartRepository{db: db}
 }
 
+const cartColumns = `id, user_id, name, is_default, created_at, updated_at`
+
+func scanCart(row interface{ Scan(dest ...any) error }, cart *models.Cart) error {
+	return row.Scan(&cart.ID, &cart.UserID, &cart.Name, &cart.IsDefault, &cart.CreatedAt, &cart.UpdatedAt)
+}
+
+// GetByUserID returns the user's default cart.
 func (r *cartRepository) GetByUserID(ctx context.Context, userID int64) (*models.Cart, error) {
-	query := `SELECT id, user_id, created_at, updated_at FROM carts WHERE user_id = $1`
+	query := `SELECT ` + cartColumns + ` FROM carts WHERE user_id = $1 AND is_default`
 	var cart models.Cart
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&cart.ID, &cart.UserID, &cart.CreatedAt, &cart.UpdatedAt)
+	err := scanCart(r.db.QueryRowContext(ctx, query, userID), &cart)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, apperrors.ErrNotFound
@@ -35,9 +43,9 @@ func (r *cartRepository) GetByUserID(ctx context.Context, userID int64) (*models
 }
 
 func (r *cartRepository) GetByID(ctx context.Context, cartID int64) (*models.Cart, error) {
-	query := `SELECT id, user_id, created_at, updated_at FROM carts WHERE id = $1`
+	query := `SELECT ` + cartColumns + ` FROM carts WHERE id = $1`
 	var cart models.Cart
-	err := r.db.QueryRowContext(ctx, query, cartID).Scan(&cart.ID, &cart.UserID, &cart.CreatedAt, &cart.UpdatedAt)
+	err := scanCart(r.db.QueryRowContext(ctx, query, cartID), &cart)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, apperrors.ErrNotFound
@@ -47,40 +55,130 @@ func (r *cartRepository) GetByID(ctx context.Context, cartID int64) (*models.Car
 	return &cart, nil
 }
 
+// Create makes an anonymous cart, or the user's default cart. If a concurrent request
+// already made the default cart, that one is returned instead.
 func (r *cartRepository) Create(ctx context.Context, userID *int64) (*models.Cart, error) {
-	query := `INSERT INTO carts (user_id) VALUES ($1) RETURNING id, user_id, created_at, updated_at`
+	query := `
+        INSERT INTO carts (user_id, is_default) VALUES ($1, $1::bigint IS NOT NULL)
+        ON CONFLICT (user_id) WHERE is_default DO UPDATE SET updated_at = carts.updated_at
+        RETURNING ` + cartColumns
 	var cart models.Cart
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&cart.ID, &cart.UserID, &cart.CreatedAt, &cart.UpdatedAt)
+	err := scanCart(r.db.QueryRowContext(ctx, query, userID), &cart)
 	if err != nil {
 		return nil, fmt.Errorf("cart repo: create: %w", err)
 	}
 	return &cart, nil
 }
 
+// ListByUserID returns all of the user's carts, the default one first.
+func (r *cartRepository) ListByUserID(ctx context.Context, userID int64) ([]models.Cart, error) {
+	query := `SELECT ` + cartColumns + ` FROM carts WHERE user_id = $1 ORDER BY is_default DESC, created_at, id`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cart repo: list by user id: %w", err)
+	}
+	defer rows.Close()
+
+	carts := []models.Cart{}
+	for rows.Next() {
+		var cart models.Cart
+		if err := scanCart(rows, &cart); err != nil {
+			return nil, fmt.Errorf("cart repo: scan cart: %w", err)
+		}
+		carts = append(carts, cart)
+	}
+	return carts, rows.Err()
+}
+
+// CreateNamed makes an additional, named cart for the user.
+func (r *cartRepository) CreateNamed(ctx context.Context, userID int64, name string) (*models.Cart, error) {
+	query := `INSERT INTO carts (user_id, name) VALUES ($1, $2) RETURNING ` + cartColumns
+	var cart models.Cart
+	if err := scanCart(r.db.QueryRowContext(ctx, query, userID, name), &cart); err != nil {
+		if isUniqueViolation(err) {
+			return nil, apperrors.ErrDuplicateCartName
+		}
+		return nil, fmt.Errorf("cart repo: create named: %w", err)
+	}
+	return &cart, nil
+}
+
+func (r *cartRepository) Rename(ctx context.Context, cartID int64, name string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE carts SET name = $1, updated_at = NOW() WHERE id = $2`, name, cartID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return apperrors.ErrDuplicateCartName
+		}
+		return fmt.Errorf("cart repo: rename: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cart repo: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
 func (r *cartRepository) Delete(ctx context.Context, cartID int64) error {
     _, err := r.db.ExecContext(ctx, "DELETE FROM carts WHERE id = $1", cartID)
     return err
 }
 
-func (r *cartRepository) AddItem(ctx context.Context, cartID int64, productID int64, quantity int) error {
-	// 1. Get current product stock and current quantity in cart in one go
-	var stockQuantity int
-	var currentCartQuantity sql.NullInt64 
-
-	// This query locks the product row until the transaction is committed,
-	// preventing other users from buying it at the same time.
-	productQuery := `SELECT stock_quantity FROM products WHERE id = $1 FOR UPDATE`
-	err := r.db.QueryRowContext(ctx, productQuery, productID).Scan(&stockQuantity)
+// lockStock locks the row that holds stock for a cart line and returns the quantity
+// available to buy, i.e. on hand minus what unpaid checkouts have reserved, and the
+// unit price it sells at now. For a variant line that is the variant row; otherwise it
+// is the product row, and hasVariants reports whether the product should have been
+// bought as a variant instead.
+func (r *cartRepository) lockStock(ctx context.Context, productID int64, variantID *int64) (stock int, price float64, hasVariants bool, err error) {
+	var status models.ProductStatus
+	if variantID != nil {
+		// Lock the product first, then the variant, the same order checkout uses.
+		variantQuery := `
+            SELECT v.stock_quantity - reserved_stock(p.id, v.id), LEAST(v.price, sale_price_at(p.id, v.id, NOW())), p.status
+            FROM products p
+            JOIN product_variants v ON v.product_id = p.id
+            WHERE p.id = $1 AND v.id = $2
+            FOR UPDATE`
+		err = r.db.QueryRowContext(ctx, variantQuery, productID, *variantID).Scan(&stock, &price, &status)
+	} else {
+		// This query locks the product row until the transaction is committed,
+		// preventing other users from buying it at the same time.
+		productQuery := `
+            SELECT stock_quantity - reserved_stock(id, NULL), LEAST(price, sale_price_at(id, NULL, NOW())), status,
+                   EXISTS (SELECT 1 FROM product_variants WHERE product_id = $1)
+            FROM products WHERE id = $1 FOR UPDATE`
+		err = r.db.QueryRowContext(ctx, productQuery, productID).Scan(&stock, &price, &status, &hasVariants)
+	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return apperrors.ErrNotFound 
+			return 0, 0, false, apperrors.ErrNotFound
 		}
-		return fmt.Errorf("cart repo: failed to get product stock: %w", err)
+		return 0, 0, false, fmt.Errorf("cart repo: failed to get product stock: %w", err)
 	}
+	// Drafts and archived products cannot be bought.
+	if status != models.ProductStatusActive {
+		return 0, 0, false, apperrors.ErrProductUnavailable
+	}
+	return stock, price, hasVariants, nil
+}
 
-	cartItemQuery := `SELECT quantity FROM cart_items WHERE cart_id = $1 AND product_id = $2`
+func (r *cartRepository) AddItem(ctx context.Context, cartID int64, productID int64, variantID *int64, quantity int) error {
+	// 1. Get current stock and current quantity in cart in one go
+	var currentCartQuantity sql.NullInt64 
+
+	stockQuantity, price, hasVariants, err := r.lockStock(ctx, productID, variantID)
+	if err != nil {
+		return err
+	}
+	if hasVariants {
+		return apperrors.ErrVariantRequired
+	}
+
+	cartItemQuery := `SELECT quantity FROM cart_items WHERE cart_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3`
 	// This might return no rows, which is fine.
-	_ = r.db.QueryRowContext(ctx, cartItemQuery, cartID, productID).Scan(&currentCartQuantity)
+	_ = r.db.QueryRowContext(ctx, cartItemQuery, cartID, productID, variantID).Scan(&currentCartQuantity)
 
 	// 2. Check if there is enough stock
 	totalQuantityNeeded := quantity
@@ -92,16 +190,20 @@ func (r *cartRepository) AddItem(ctx context.Context, cartID int64, productID in
 		return apperrors.ErrInsufficientStock
 	}
 
-	// 3. If stock is sufficient, insert or update the cart item
+	// 3. If stock is sufficient, insert or update the cart item. Adding more of a line
+	// means the customer has seen the current price, so it becomes the recorded one.
+	// Adding a product that was saved for later brings the line back into the cart.
 	upsertQuery := `
-        INSERT INTO cart_items (cart_id, product_id, quantity, created_at, updated_at)
-        VALUES ($1, $2, $3, NOW(), NOW())
-        ON CONFLICT (cart_id, product_id)
+        INSERT INTO cart_items (cart_id, product_id, variant_id, quantity, price_at_add, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+        ON CONFLICT (cart_id, product_id, variant_id)
         DO UPDATE SET 
             quantity = cart_items.quantity + EXCLUDED.quantity,
+            price_at_add = EXCLUDED.price_at_add,
+            saved_for_later = false,
             updated_at = NOW()`
 	
-	_, err = r.db.ExecContext(ctx, upsertQuery, cartID, productID, quantity)
+	_, err = r.db.ExecContext(ctx, upsertQuery, cartID, productID, variantID, quantity, price)
 	if err != nil {
 		return fmt.Errorf("cart repo: add item upsert failed: %w", err)
 	}
@@ -112,15 +214,10 @@ func (r *cartRepository) AddItem(ctx context.Context, cartID int64, productID in
 }
 
 
-func (r *cartRepository) UpdateItemQuantity(ctx context.Context, cartID int64, productID int64, newQuantity int) error {
-	var stockQuantity int
-	productQuery := `SELECT stock_quantity FROM products WHERE id = $1 FOR UPDATE`
-	err := r.db.QueryRowContext(ctx, productQuery, productID).Scan(&stockQuantity)
+func (r *cartRepository) UpdateItemQuantity(ctx context.Context, cartID int64, productID int64, variantID *int64, newQuantity int) error {
+	stockQuantity, _, _, err := r.lockStock(ctx, productID, variantID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return apperrors.ErrNotFound
-		}
-		return fmt.Errorf("cart repo: failed to get product stock: %w", err)
+		return err
 	}
 
 	if stockQuantity < newQuantity {
@@ -130,9 +227,9 @@ func (r *cartRepository) UpdateItemQuantity(ctx context.Context, cartID int64, p
 	query := `
         UPDATE cart_items 
         SET quantity = $1, updated_at = NOW() 
-        WHERE cart_id = $2 AND product_id = $3`
+        WHERE cart_id = $2 AND product_id = $3 AND variant_id IS NOT DISTINCT FROM $4`
 	
-	_, err = r.db.ExecContext(ctx, query, newQuantity, cartID, productID)
+	_, err = r.db.ExecContext(ctx, query, newQuantity, cartID, productID, variantID)
 	if err != nil {
 		return fmt.Errorf("cart repo: update item failed: %w", err)
 	}
@@ -141,9 +238,9 @@ func (r *cartRepository) UpdateItemQuantity(ctx context.Context, cartID int64, p
 	return err
 }
 
-func (r *cartRepository) RemoveItem(ctx context.Context, cartID int64, productID int64) error {
-	query := `DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2`
-	_, err := r.db.ExecContext(ctx, query, cartID, productID)
+func (r *cartRepository) RemoveItem(ctx context.Context, cartID int64, productID int64, variantID *int64) error {
+	query := `DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3`
+	_, err := r.db.ExecContext(ctx, query, cartID, productID, variantID)
     if err != nil {
 		return fmt.Errorf("cart repo: remove item: %w", err)
 	}
@@ -152,17 +249,32 @@ func (r *cartRepository) RemoveItem(ctx context.Context, cartID int64, productID
 	return err
 }
 
+// GetItemsByCartID returns the lines that are checked out with the cart, leaving out
+// those saved for later.
 func (r *cartRepository) GetItemsByCartID(ctx context.Context, cartID int64) ([]models.CartItem, error) {
+    return r.listItems(ctx, cartID, false)
+}
+
+// GetSavedItemsByCartID returns the cart's lines that are saved for later.
+func (r *cartRepository) GetSavedItemsByCartID(ctx context.Context, cartID int64) ([]models.CartItem, error) {
+    return r.listItems(ctx, cartID, true)
+}
+
+func (r *cartRepository) listItems(ctx context.Context, cartID int64, saved bool) ([]models.CartItem, error) {
     query := `
         SELECT
-            ci.id, ci.cart_id, ci.quantity, ci.created_at, ci.updated_at,
-            p.id, p.name, p.price, p.thumbnail, p.stock_quantity
+            ci.id, ci.cart_id, ci.quantity, ci.price_at_add, ci.saved_for_later, ci.created_at, ci.updated_at,
+            p.id, p.category_id, p.name, p.status, p.price, p.thumbnail, p.stock_quantity, p.stock_quantity - reserved_stock(p.id, NULL),
+            sale_price_at(p.id, NULL, NOW()), active_price_schedule(p.id, NULL, NOW()),
+            v.id, v.sku, v.price, v.stock_quantity, v.stock_quantity - reserved_stock(p.id, v.id), v.images, v.options,
+            sale_price_at(p.id, v.id, NOW()), active_price_schedule(p.id, v.id, NOW())
         FROM cart_items ci
         JOIN products p ON ci.product_id = p.id
-        WHERE ci.cart_id = $1
+        LEFT JOIN product_variants v ON ci.variant_id = v.id
+        WHERE ci.cart_id = $1 AND ci.saved_for_later = $2
         ORDER BY ci.created_at DESC`  
 
-    rows, err := r.db.QueryContext(ctx, query, cartID)
+    rows, err := r.db.QueryContext(ctx, query, cartID, saved)
     if err != nil {
         return nil, fmt.Errorf("cart repo: get items: %w", err)
     }
@@ -172,25 +284,53 @@ func (r *cartRepository) GetItemsByCartID(ctx context.Context, cartID int64) ([]
     for rows.Next() {
         var item models.CartItem
         var product models.Product
+        var variantID sql.NullInt64
+        var variantSKU sql.NullString
+        var variantPrice sql.NullFloat64
+        var variantStock sql.NullInt64
+        var variantAvailable sql.NullInt64
+        var variant models.ProductVariant
+        var variantOptions []byte
+        var productPrice float64
+        var productSale, variantSale *float64
+        var productScheduleID, variantScheduleID *int64
         if err := rows.Scan(
-            &item.ID, &item.CartID, &item.Quantity, &item.CreatedAt, &item.UpdatedAt,
-            &product.ID, &product.Name, &product.Price, &product.Thumbnail, &product.StockQuantity,
+            &item.ID, &item.CartID, &item.Quantity, &item.PriceAtAdd, &item.SavedForLater, &item.CreatedAt, &item.UpdatedAt,
+            &product.ID, &product.CategoryID, &product.Name, &product.Status, &productPrice, &product.Thumbnail, &product.StockQuantity, &product.AvailableStock,
+            &productSale, &productScheduleID,
+            &variantID, &variantSKU, &variantPrice, &variantStock, &variantAvailable, &variant.Images, &variantOptions,
+            &variantSale, &variantScheduleID,
         ); err != nil {
             return nil, fmt.Errorf("cart repo: scan item: %w", err)
         }
+        product.SetPrice(productPrice, productSale, productScheduleID)
         item.Product = &product
+        if variantID.Valid {
+            variant.ID = variantID.Int64
+            variant.ProductID = product.ID
+            variant.SKU = variantSKU.String
+            variant.SetPrice(variantPrice.Float64, variantSale, variantScheduleID)
+            variant.StockQuantity = int(variantStock.Int64)
+            variant.AvailableStock = int(variantAvailable.Int64)
+            variant.Options = variantOptions
+            item.Variant = &variant
+        }
         items = append(items, item)
     }
     return items, rows.Err()
 }
 
+// MergeCarts moves the lines of one cart into another. A line is only kept as saved for
+// later if it was saved in both carts.
 func (r *cartRepository) MergeCarts(ctx context.Context, fromCartID, toCartID int64) error {
     query := `
-        INSERT INTO cart_items (cart_id, product_id, quantity, created_at, updated_at)
-        SELECT $1, product_id, quantity, created_at, NOW() FROM cart_items WHERE cart_id = $2
-        ON CONFLICT (cart_id, product_id)
+        INSERT INTO cart_items (cart_id, product_id, variant_id, quantity, price_at_add, saved_for_later, created_at, updated_at)
+        SELECT $1, product_id, variant_id, quantity, price_at_add, saved_for_later, created_at, NOW() FROM cart_items WHERE cart_id = $2
+        ON CONFLICT (cart_id, product_id, variant_id)
         DO UPDATE SET 
             quantity = cart_items.quantity + EXCLUDED.quantity,
+            price_at_add = COALESCE(cart_items.price_at_add, EXCLUDED.price_at_add),
+            saved_for_later = cart_items.saved_for_later AND EXCLUDED.saved_for_later,
             updated_at = NOW()`  
     
     _, err := r.db.ExecContext(ctx, query, toCartID, fromCartID)
@@ -218,8 +358,28 @@ func (r *cartRepository) CleanupOldAnonymousCarts(ctx context.Context, olderThan
 	return rowsAffected, nil
 }
 
+// AcceptCurrentPrices records the price every line of the cart sells at now as the
+// price it was added at, which clears price change warnings. Lines saved for later
+// keep theirs until they are moved back.
+func (r *cartRepository) AcceptCurrentPrices(ctx context.Context, cartID int64) error {
+	query := `
+        UPDATE cart_items ci
+        SET price_at_add = CASE
+                WHEN ci.variant_id IS NULL THEN
+                    (SELECT LEAST(p.price, sale_price_at(p.id, NULL, NOW())) FROM products p WHERE p.id = ci.product_id)
+                ELSE
+                    (SELECT LEAST(v.price, sale_price_at(ci.product_id, v.id, NOW())) FROM product_variants v WHERE v.id = ci.variant_id)
+            END
+        WHERE ci.cart_id = $1 AND NOT ci.saved_for_later`
+	if _, err := r.db.ExecContext(ctx, query, cartID); err != nil {
+		return fmt.Errorf("cart repo: failed to accept current prices: %w", err)
+	}
+	return nil
+}
+
+// ClearCart empties the cart after checkout. Lines saved for later are kept.
 func (r *cartRepository) ClearCart(ctx context.Context, cartID int64) error {
-	query := `DELETE FROM cart_items WHERE cart_id = $1`
+	query := `DELETE FROM cart_items WHERE cart_id = $1 AND NOT saved_for_later`
 	_, err := r.db.ExecContext(ctx, query, cartID)
 	if err != nil {
 		return fmt.Errorf("cart repo: failed to clear cart items: %w", err)
@@ -234,3 +394,28 @@ func (r *cartRepository) ClearCart(ctx context.Context, cartID int64) error {
 	return nil
 }
 
+// SetSavedForLater moves a line between the cart and its saved-for-later list.
+func (r *cartRepository) SetSavedForLater(ctx context.Context, cartID int64, productID int64, variantID *int64, saved bool) error {
+	query := `
+        UPDATE cart_items SET saved_for_later = $1, updated_at = NOW()
+        WHERE cart_id = $2 AND product_id = $3 AND variant_id IS NOT DISTINCT FROM $4`
+	result, err := r.db.ExecContext(ctx, query, saved, cartID, productID, variantID)
+	if err != nil {
+		return fmt.Errorf("cart repo: failed to set saved for later: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cart repo: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	_, err = r.db.ExecContext(ctx, "UPDATE carts SET updated_at = NOW() WHERE id = $1", cartID)
+	return err
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -1,16 +1,28 @@
 package cart
 
-import "github.com/purushothdl/ecommerce-api/pkg/validator"
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
 
 // AddItemRequest defines the request body for adding an item to the cart.
+// VariantID is required for products that are sold as variants.
 type AddItemRequest struct {
-	ProductID int64 `json:"product_id"`
-	Quantity  int   `json:"quantity"`
+	ProductID int64  `json:"product_id"`
+	VariantID *int64 `json:"variant_id,omitempty"`
+	Quantity  int    `json:"quantity"`
 }
 
 // Validate checks the AddItemRequest for correctness.
 func (r AddItemRequest) Validate(v *validator.Validator) {
 	v.Check(r.ProductID > 0, "product_id", "must be a positive integer")
+	if r.VariantID != nil {
+		v.Check(*r.VariantID > 0, "variant_id", "must be a positive integer")
+	}
 	v.Check(r.Quantity > 0, "quantity", "must be a positive integer")
 }
 
@@ -23,4 +35,37 @@ type UpdateItemRequest struct {
 func (r UpdateItemRequest) Validate(v *validator.Validator) {
 	// Quantity can be 0, which means "remove the item".
 	v.Check(r.Quantity >= 0, "quantity", "must be a non-negative integer")
-}
\ No newline at end of file
+}
+
+// CartNameRequest defines the request body for creating or renaming a named cart.
+type CartNameRequest struct {
+	Name string `json:"name"`
+}
+
+// Validate checks the CartNameRequest for correctness.
+func (r CartNameRequest) Validate(v *validator.Validator) {
+	v.Check(strings.TrimSpace(r.Name) != "", "name", "must be provided")
+	v.Check(len(r.Name) <= 100, "name", "must not be more than 100 characters long")
+}
+
+// ApplyCouponRequest defines the request body for applying a coupon code to the cart.
+type ApplyCouponRequest struct {
+	Code string `json:"code"`
+}
+
+// Validate checks the ApplyCouponRequest for correctness.
+func (r ApplyCouponRequest) Validate(v *validator.Validator) {
+	coupon.ValidateCode(r.Code, v)
+}
+
+// ParseVariantID reads the optional ?variant_id= query parameter that selects
+// which variant line of a product an update or removal applies to.
+func ParseVariantID(query url.Values, v *validator.Validator) *int64 {
+	raw := query.Get("variant_id")
+	if raw == "" {
+		return nil
+	}
+	variantID, err := strconv.ParseInt(raw, 10, 64)
+	v.Check(err == nil && variantID > 0, "variant_id", "must be a positive integer")
+	return &variantID
+}
@@ -2,7 +2,9 @@
 package cart
 
 import (
+    "encoding/json"
     "time"
+
     "github.com/purushothdl/ecommerce-api/internal/models"
 )
 
@@ -10,9 +12,16 @@ import (
 type CartResponse struct {
     ID        int64            `json:"id"`
     UserID    *int64           `json:"user_id,omitempty"`
+    Name      *string          `json:"name,omitempty"`
+    IsDefault bool             `json:"is_default"`
     Items     []CartItemResponse `json:"items"`
+    SavedItems []CartItemResponse `json:"saved_items"` // Saved for later; not part of Total
     Total     float64          `json:"total"`
+    Coupons   []models.AppliedCoupon `json:"coupons"`
+    Discount  float64          `json:"discount"` // What the coupons take off Total
     ItemCount int              `json:"item_count"`
+    HasWarnings bool           `json:"has_warnings"` // Some line or coupon needs attention before checkout
+
     CreatedAt time.Time        `json:"created_at"`
     UpdatedAt time.Time        `json:"updated_at"`
 }
@@ -21,53 +30,141 @@ type CartResponse struct {
 type CartItemResponse struct {
     ID        int64              `json:"id"`
     Product   CartProductResponse `json:"product"`  // Clean product DTO
+    Variant   *CartVariantResponse `json:"variant,omitempty"`
+    UnitPrice float64            `json:"unit_price"`
+    CompareAtPrice *float64      `json:"compare_at_price,omitempty"` // Regular unit price while on sale
     Quantity  int                `json:"quantity"`
     Subtotal  float64            `json:"subtotal"`
+    PriceAtAdd *float64          `json:"price_at_add,omitempty"` // Unit price when the line was added
+    Warnings  []models.CartWarning `json:"warnings,omitempty"`
     CreatedAt time.Time          `json:"created_at"`
     UpdatedAt time.Time          `json:"updated_at"`
 }
 
 // CartProductResponse represents only the product fields needed in cart
 type CartProductResponse struct {
-    ID            int64   `json:"id"`
-    Name          string  `json:"name"`
-    Price         float64 `json:"price"`
-    Thumbnail     string  `json:"thumbnail"`
-    StockQuantity int     `json:"stock_quantity"`
+    ID             int64   `json:"id"`
+    Name           string  `json:"name"`
+    Price          float64 `json:"price"`
+    CompareAtPrice *float64 `json:"compare_at_price,omitempty"`
+    Thumbnail      string  `json:"thumbnail"`
+    StockQuantity  int     `json:"stock_quantity"`
+    AvailableStock int     `json:"available_stock"`
+}
+
+// CartVariantResponse represents the chosen variant of a cart line
+type CartVariantResponse struct {
+    ID             int64           `json:"id"`
+    SKU            string          `json:"sku"`
+    Price          float64         `json:"price"`
+    CompareAtPrice *float64        `json:"compare_at_price,omitempty"`
+    StockQuantity  int             `json:"stock_quantity"`
+    AvailableStock int             `json:"available_stock"`
+    Options        json.RawMessage `json:"options"`
+}
+
+// CartSummaryResponse describes one of a user's carts without its contents
+type CartSummaryResponse struct {
+    ID        int64     `json:"id"`
+    Name      *string   `json:"name,omitempty"`
+    IsDefault bool      `json:"is_default"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewCartSummaryResponse creates a CartSummaryResponse from a cart model
+func NewCartSummaryResponse(cart *models.Cart) CartSummaryResponse {
+    return CartSummaryResponse{
+        ID:        cart.ID,
+        Name:      cart.Name,
+        IsDefault: cart.IsDefault,
+        CreatedAt: cart.CreatedAt,
+        UpdatedAt: cart.UpdatedAt,
+    }
+}
+
+// FixCartResponse is the cart after fixing it, with the changes that were made
+type FixCartResponse struct {
+    Cart    *CartResponse    `json:"cart"`
+    Changes []models.CartFix `json:"changes"`
 }
 
 // NewCartResponse creates a CartResponse from models
 func NewCartResponse(cart *models.Cart, items []models.CartItem) *CartResponse {
     cartItems := make([]CartItemResponse, len(items))
     total := 0.0
+    hasWarnings := false
     
     for i, item := range items {
-        subtotal := float64(item.Quantity) * item.Product.Price
-        total += subtotal
-        
-        cartItems[i] = CartItemResponse{
-            ID: item.ID,
-            Product: CartProductResponse{
-                ID:            item.Product.ID,
-                Name:          item.Product.Name,
-                Price:         item.Product.Price,
-                Thumbnail:     item.Product.Thumbnail,
-                StockQuantity: item.Product.StockQuantity,
-            },
-            Quantity:  item.Quantity,
-            Subtotal:  subtotal,
-            CreatedAt: item.CreatedAt,
-            UpdatedAt: item.UpdatedAt,
-        }
+        cartItems[i] = newCartItemResponse(item)
+        total += cartItems[i].Subtotal
+        hasWarnings = hasWarnings || len(item.Warnings) > 0
+    }
+
+    // Saved lines show their warnings, but they do not hold up checkout.
+    savedItems := make([]CartItemResponse, len(cart.SavedItems))
+    for i, item := range cart.SavedItems {
+        savedItems[i] = newCartItemResponse(item)
     }
     
+    coupons := cart.Coupons
+    if coupons == nil {
+        coupons = []models.AppliedCoupon{}
+    }
+    for _, applied := range coupons {
+        hasWarnings = hasWarnings || applied.Problem != ""
+    }
+
     return &CartResponse{
         ID:        cart.ID,
         UserID:    cart.UserID,
+        Name:      cart.Name,
+        IsDefault: cart.IsDefault,
         Items:     cartItems,
+        SavedItems: savedItems,
         Total:     total,
+        Coupons:   coupons,
+        Discount:  cart.Discount,
         ItemCount: len(items),
+        HasWarnings: hasWarnings,
         CreatedAt: cart.CreatedAt,
         UpdatedAt: cart.UpdatedAt,
     }
 }
+
+func newCartItemResponse(item models.CartItem) CartItemResponse {
+    var variant *CartVariantResponse
+    if item.Variant != nil {
+        variant = &CartVariantResponse{
+            ID:             item.Variant.ID,
+            SKU:            item.Variant.SKU,
+            Price:          item.Variant.Price,
+            CompareAtPrice: item.Variant.CompareAtPrice,
+            StockQuantity:  item.Variant.StockQuantity,
+            AvailableStock: item.Variant.AvailableStock,
+            Options:        item.Variant.Options,
+        }
+    }
+
+    return CartItemResponse{
+        ID: item.ID,
+        Product: CartProductResponse{
+            ID:             item.Product.ID,
+            Name:           item.Product.Name,
+            Price:          item.Product.Price,
+            CompareAtPrice: item.Product.CompareAtPrice,
+            Thumbnail:      item.Product.Thumbnail,
+            StockQuantity:  item.Product.StockQuantity,
+            AvailableStock: item.Product.AvailableStock,
+        },
+        Variant:   variant,
+        UnitPrice: item.UnitPrice(),
+        CompareAtPrice: item.CompareAtPrice(),
+        Quantity:  item.Quantity,
+        Subtotal:  float64(item.Quantity) * item.UnitPrice(),
+        PriceAtAdd: item.PriceAtAdd,
+        Warnings:  item.Warnings,
+        CreatedAt: item.CreatedAt,
+        UpdatedAt: item.UpdatedAt,
+    }
+}
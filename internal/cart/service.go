@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/models"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
@@ -16,14 +18,16 @@ import (
 type cartService struct {
 	cartRepo    domain.CartRepository
 	productRepo domain.ProductRepository
+	couponRepo  domain.CouponRepository
 	store       domain.Store
 	logger      *slog.Logger
 }
 
-func NewCartService(cartRepo domain.CartRepository, productRepo domain.ProductRepository, store domain.Store, logger *slog.Logger) domain.CartService {
+func NewCartService(cartRepo domain.CartRepository, productRepo domain.ProductRepository, couponRepo domain.CouponRepository, store domain.Store, logger *slog.Logger) domain.CartService {
 	return &cartService{
 		cartRepo:    cartRepo,
 		productRepo: productRepo,
+		couponRepo:  couponRepo,
 		store:       store,
 		logger:      logger,
 	}
@@ -38,14 +42,40 @@ func (s *cartService) getCartWithItems(ctx context.Context, cart *models.Cart) (
 	}
 	cart.Items = items
 
+	saved, err := s.cartRepo.GetSavedItemsByCartID(ctx, cart.ID)
+	if err != nil {
+		s.logger.Error("failed to get saved cart items", "cart_id", cart.ID, "error", err)
+		return nil, fmt.Errorf("could not retrieve saved cart items: %w", err)
+	}
+	for i := range saved {
+		saved[i].Warnings = saved[i].Check()
+	}
+	cart.SavedItems = saved
+
 	var total float64
-	for _, item := range items {
+	for i := range items {
+		item := &items[i]
 		if item.Product != nil {
-			total += item.Product.Price * float64(item.Quantity)
+			total += item.UnitPrice() * float64(item.Quantity)
+			item.Warnings = item.Check()
 		}
 	}
 	cart.Total = total
 
+	// Coupons are priced here as a preview; checkout checks them again under lock.
+	coupons, err := s.couponRepo.ListByCart(ctx, cart.ID)
+	if err != nil {
+		s.logger.Error("failed to get cart coupons", "cart_id", cart.ID, "error", err)
+		return nil, fmt.Errorf("could not retrieve cart coupons: %w", err)
+	}
+	cart.Coupons, err = coupon.Evaluate(ctx, s.couponRepo, coupons, cart.UserID, coupon.CartLines(items), 1, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("could not price cart coupons: %w", err)
+	}
+	for _, applied := range cart.Coupons {
+		cart.Discount += applied.Amount
+	}
+
 	return cart, nil
 }
 
@@ -81,11 +111,68 @@ func (s *cartService) GetOrCreateCart(ctx context.Context, userID *int64, anonym
 	return s.cartRepo.Create(ctx, nil)
 }
 
-func (s *cartService) AddProductToCart(ctx context.Context, cartID int64, productID int64, quantity int) (*models.Cart, error) {
-	s.logger.Info("adding product to cart within transaction", "cart_id", cartID, "product_id", productID, "quantity", quantity)
+// GetUserCart returns one of the user's carts, or ErrNotFound when the cart is not theirs.
+func (s *cartService) GetUserCart(ctx context.Context, userID int64, cartID int64) (*models.Cart, error) {
+	cart, err := s.cartRepo.GetByID(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+	if cart.UserID == nil || *cart.UserID != userID {
+		return nil, apperrors.ErrNotFound
+	}
+	return cart, nil
+}
+
+// ListCarts returns the user's carts, the default one first, without their items.
+func (s *cartService) ListCarts(ctx context.Context, userID int64) ([]models.Cart, error) {
+	carts, err := s.cartRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cart service: could not list carts: %w", err)
+	}
+	return carts, nil
+}
+
+func (s *cartService) CreateNamedCart(ctx context.Context, userID int64, name string) (*models.Cart, error) {
+	cart, err := s.cartRepo.CreateNamed(ctx, userID, strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("cart service: could not create cart: %w", err)
+	}
+	s.logger.Info("created named cart", "user_id", userID, "cart_id", cart.ID)
+	return cart, nil
+}
+
+func (s *cartService) RenameCart(ctx context.Context, userID int64, cartID int64, name string) (*models.Cart, error) {
+	if _, err := s.GetUserCart(ctx, userID, cartID); err != nil {
+		return nil, err
+	}
+	if err := s.cartRepo.Rename(ctx, cartID, strings.TrimSpace(name)); err != nil {
+		return nil, fmt.Errorf("cart service: could not rename cart: %w", err)
+	}
+	return s.cartRepo.GetByID(ctx, cartID)
+}
+
+// DeleteCart deletes one of the user's named carts with everything in it. The default
+// cart cannot be deleted, since it is where anonymous carts are merged on login.
+func (s *cartService) DeleteCart(ctx context.Context, userID int64, cartID int64) error {
+	cart, err := s.GetUserCart(ctx, userID, cartID)
+	if err != nil {
+		return err
+	}
+	if cart.IsDefault {
+		return apperrors.ErrDefaultCart
+	}
+	if err := s.cartRepo.Delete(ctx, cartID); err != nil {
+		return fmt.Errorf("cart service: could not delete cart: %w", err)
+	}
+	s.logger.Info("deleted named cart", "user_id", userID, "cart_id", cartID)
+	return nil
+}
+
+func (s *cartService) AddProductToCart(ctx context.Context, cartID int64, productID int64, variantID *int64, quantity int) (*models.Cart, error) {
+	s.logger.Info("adding product to cart within transaction", "cart_id", cartID, "product_id", productID, "variant_id", variantID, "quantity", quantity)
 
 	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
-		return q.CartRepo.AddItem(ctx, cartID, productID, quantity)
+		return q.CartRepo.AddItem(ctx, cartID, productID, variantID, quantity)
 	})
 
 	if err != nil {
@@ -97,16 +184,16 @@ func (s *cartService) AddProductToCart(ctx context.Context, cartID int64, produc
 	return s.GetCartContents(ctx, cartID)
 }
 
-func (s *cartService) UpdateProductInCart(ctx context.Context, cartID int64, productID int64, quantity int) (*models.Cart, error) {
-	s.logger.Info("updating product in cart within transaction", "cart_id", cartID, "product_id", productID, "new_quantity", quantity)
+func (s *cartService) UpdateProductInCart(ctx context.Context, cartID int64, productID int64, variantID *int64, quantity int) (*models.Cart, error) {
+	s.logger.Info("updating product in cart within transaction", "cart_id", cartID, "product_id", productID, "variant_id", variantID, "new_quantity", quantity)
 
 	if quantity <= 0 {
 		// Removing an item can also be done in a transaction for consistency
-		return s.RemoveProductFromCart(ctx, cartID, productID)
+		return s.RemoveProductFromCart(ctx, cartID, productID, variantID)
 	}
 
 	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
-		return q.CartRepo.UpdateItemQuantity(ctx, cartID, productID, quantity)
+		return q.CartRepo.UpdateItemQuantity(ctx, cartID, productID, variantID, quantity)
 	})
 
 	if err != nil {
@@ -117,11 +204,11 @@ func (s *cartService) UpdateProductInCart(ctx context.Context, cartID int64, pro
 	return s.GetCartContents(ctx, cartID)
 }
 
-func (s *cartService) RemoveProductFromCart(ctx context.Context, cartID int64, productID int64) (*models.Cart, error) {
-	s.logger.Info("removing product from cart within transaction", "cart_id", cartID, "product_id", productID)
+func (s *cartService) RemoveProductFromCart(ctx context.Context, cartID int64, productID int64, variantID *int64) (*models.Cart, error) {
+	s.logger.Info("removing product from cart within transaction", "cart_id", cartID, "product_id", productID, "variant_id", variantID)
 
 	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
-		return q.CartRepo.RemoveItem(ctx, cartID, productID)
+		return q.CartRepo.RemoveItem(ctx, cartID, productID, variantID)
 	})
 
 	if err != nil {
@@ -132,6 +219,23 @@ func (s *cartService) RemoveProductFromCart(ctx context.Context, cartID int64, p
 	return s.GetCartContents(ctx, cartID)
 }
 
+// SaveForLater moves a line out of the cart's total and checkout, keeping it in the cart.
+func (s *cartService) SaveForLater(ctx context.Context, cartID int64, productID int64, variantID *int64) (*models.Cart, error) {
+	if err := s.cartRepo.SetSavedForLater(ctx, cartID, productID, variantID, true); err != nil {
+		return nil, fmt.Errorf("cart service: could not save item for later: %w", err)
+	}
+	return s.GetCartContents(ctx, cartID)
+}
+
+// MoveToCart brings a line saved for later back into the cart. Stock is not checked here;
+// the cart's warnings show any shortfall, as they do for lines left in the cart.
+func (s *cartService) MoveToCart(ctx context.Context, cartID int64, productID int64, variantID *int64) (*models.Cart, error) {
+	if err := s.cartRepo.SetSavedForLater(ctx, cartID, productID, variantID, false); err != nil {
+		return nil, fmt.Errorf("cart service: could not move item to cart: %w", err)
+	}
+	return s.GetCartContents(ctx, cartID)
+}
+
 func (s *cartService) GetCartContents(ctx context.Context, cartID int64) (*models.Cart, error) {
 	s.logger.Info("getting cart contents", "cart_id", cartID)
 
@@ -142,11 +246,83 @@ func (s *cartService) GetCartContents(ctx context.Context, cartID int64) (*model
 	return s.getCartWithItems(ctx, cart)
 }
 
+// FixCart resolves every warning on the cart so it can be checked out: lines that cannot
+// be bought are removed, quantities above available stock are reduced to it, and the
+// current prices are accepted. It returns the cart afterwards and what was changed.
+func (s *cartService) FixCart(ctx context.Context, cartID int64) (*models.Cart, []models.CartFix, error) {
+	var fixes []models.CartFix
+
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		fixes = nil
+		items, err := q.CartRepo.GetItemsByCartID(ctx, cartID)
+		if err != nil {
+			return err
+		}
+
+		for i := range items {
+			item := &items[i]
+			line := models.CartFix{ProductID: item.Product.ID, VariantID: item.VariantID(), ProductName: item.Product.Name, Quantity: item.Quantity}
+
+			// Stock warnings come before price ones, so a removed line reports nothing else.
+			for _, warning := range item.Check() {
+				fix := line
+				fix.Reason = warning.Code
+				switch warning.Code {
+				case models.CartWarningUnavailable, models.CartWarningOutOfStock:
+					if err := q.CartRepo.RemoveItem(ctx, cartID, line.ProductID, line.VariantID); err != nil {
+						return err
+					}
+					fix.Action, fix.Quantity = models.CartFixRemoved, 0
+				case models.CartWarningInsufficientStock:
+					line.Quantity = item.AvailableStock()
+					if err := q.CartRepo.UpdateItemQuantity(ctx, cartID, line.ProductID, line.VariantID, line.Quantity); err != nil {
+						return err
+					}
+					fix.Action, fix.Quantity = models.CartFixQuantityReduced, line.Quantity
+				default:
+					fix.Action = models.CartFixPriceAccepted
+				}
+				fixes = append(fixes, fix)
+				if fix.Action == models.CartFixRemoved {
+					break
+				}
+			}
+		}
+
+		// Lines kept are now sold at the current price, so that becomes the recorded one.
+		return q.CartRepo.AcceptCurrentPrices(ctx, cartID)
+	})
+	if err != nil {
+		s.logger.Error("failed to fix cart", "cart_id", cartID, "error", err)
+		return nil, nil, fmt.Errorf("cart service: could not fix cart: %w", err)
+	}
+
+	s.logger.Info("fixed cart", "cart_id", cartID, "changes", len(fixes))
+	cart, err := s.GetCartContents(ctx, cartID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cart, fixes, nil
+}
+
 func (s *cartService) HandleLoginWithTransaction(ctx context.Context, q *domain.Queries, userID int64, anonymousCartID int64) error {
     if anonymousCartID == 0 {
         return nil 
     }
 
+    // Only carts that are still anonymous can be claimed at login
+    anonymousCart, err := q.CartRepo.GetByID(ctx, anonymousCartID)
+    if err != nil {
+        if errors.Is(err, apperrors.ErrNotFound) {
+            return nil
+        }
+        return fmt.Errorf("failed to get anonymous cart: %w", err)
+    }
+    if anonymousCart.UserID != nil {
+        s.logger.Warn("refusing to merge a cart owned by a registered user", "cart_id", anonymousCartID, "user_id", userID)
+        return nil
+    }
+
     userCart, err := q.CartRepo.GetByUserID(ctx, userID)
     if err != nil {
         if errors.Is(err, apperrors.ErrNotFound) {
@@ -167,6 +343,9 @@ func (s *cartService) HandleLoginWithTransaction(ctx context.Context, q *domain.
     if err := q.CartRepo.MergeCarts(ctx, anonymousCartID, userCart.ID); err != nil {
         return fmt.Errorf("failed to merge carts: %w", err)
     }
+    if err := q.CouponRepo.MergeCarts(ctx, anonymousCartID, userCart.ID); err != nil {
+        return fmt.Errorf("failed to merge cart coupons: %w", err)
+    }
 
     // Delete anonymous cart
     return q.CartRepo.Delete(ctx, anonymousCartID)
@@ -0,0 +1,251 @@
+// internal/catalog/format.go
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+)
+
+// Format is a supported catalog file format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ErrUnknownFormat is returned for formats other than csv and json.
+var ErrUnknownFormat = errors.New("unknown catalog format")
+
+// ParseFormat accepts a format name or a file extension such as ".csv".
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(s, ".")) {
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// csvColumns is the column order used for exports. Imports match columns by header name,
+// so they may come in any order and optional ones may be left out.
+var csvColumns = []string{
+	"sku", "name", "description", "price", "stock_quantity", "category", "brand",
+	"images", "thumbnail", "width", "height", "depth", "warranty_information", "status",
+}
+
+// legacyCSVColumns are accepted on import so files exported before the status column
+// existed can still be loaded.
+var legacyCSVColumns = []string{"archived"}
+
+// imageSeparator joins multiple image URLs inside a single CSV cell.
+const imageSeparator = "|"
+
+// Parse reads an import file. Problems with individual rows are recorded on the row
+// so they can be reported together; an error is only returned if the file as a whole
+// cannot be read.
+func Parse(r io.Reader, format Format) ([]dto.CatalogImportRow, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatJSON:
+		return parseJSON(r)
+	}
+	return nil, ErrUnknownFormat
+}
+
+func parseCSV(r io.Reader) ([]dto.CatalogImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Short or long rows are reported per row below.
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("csv file is empty")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	known := make(map[string]bool, len(csvColumns)+len(legacyCSVColumns))
+	for _, col := range append(csvColumns, legacyCSVColumns...) {
+		known[col] = true
+	}
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		col = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))
+		if !known[col] {
+			return nil, fmt.Errorf("unknown csv column %q", col)
+		}
+		index[col] = i
+	}
+	if _, ok := index["sku"]; !ok {
+		return nil, fmt.Errorf("csv header must include a sku column")
+	}
+
+	var rows []dto.CatalogImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		get := func(col string) string {
+			if i, ok := index[col]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := dto.CatalogImportRow{Line: line, Errors: map[string]string{}}
+		if len(record) != len(header) {
+			row.Errors["row"] = fmt.Sprintf("expected %d fields, got %d", len(header), len(record))
+		}
+
+		row.Row = dto.CatalogRow{
+			SKU:                 get("sku"),
+			Name:                get("name"),
+			Description:         get("description"),
+			Category:            get("category"),
+			Brand:               get("brand"),
+			Thumbnail:           get("thumbnail"),
+			WarrantyInformation: get("warranty_information"),
+			Status:              models.ProductStatus(strings.ToLower(get("status"))),
+		}
+		if raw := get("archived"); raw != "" {
+			if row.Row.Archived, err = strconv.ParseBool(raw); err != nil {
+				row.Errors["archived"] = "must be true or false"
+			}
+		}
+		if raw := get("price"); raw != "" {
+			if row.Row.Price, err = strconv.ParseFloat(raw, 64); err != nil {
+				row.Errors["price"] = "must be a number"
+			}
+		}
+		if raw := get("stock_quantity"); raw != "" {
+			if row.Row.StockQuantity, err = strconv.Atoi(raw); err != nil {
+				row.Errors["stock_quantity"] = "must be a whole number"
+			}
+		}
+		if raw := get("images"); raw != "" {
+			for _, image := range strings.Split(raw, imageSeparator) {
+				if image = strings.TrimSpace(image); image != "" {
+					row.Row.Images = append(row.Row.Images, image)
+				}
+			}
+		}
+		if width, height, depth := get("width"), get("height"), get("depth"); width != "" || height != "" || depth != "" {
+			row.Row.Dimensions = &models.Dimensions{}
+			for col, dest := range map[string]*float64{
+				"width": &row.Row.Dimensions.Width, "height": &row.Row.Dimensions.Height, "depth": &row.Row.Dimensions.Depth,
+			} {
+				if *dest, err = strconv.ParseFloat(get(col), 64); err != nil {
+					row.Errors[col] = "must be a number when any dimension is given"
+				}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseJSON(r io.Reader) ([]dto.CatalogImportRow, error) {
+	// Decode element by element so one malformed product does not hide errors in the others.
+	var elements []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&elements); err != nil {
+		return nil, fmt.Errorf("json file must contain an array of products: %w", err)
+	}
+
+	rows := make([]dto.CatalogImportRow, len(elements))
+	for i, element := range elements {
+		rows[i] = dto.CatalogImportRow{Line: i + 1, Errors: map[string]string{}}
+
+		decoder := json.NewDecoder(strings.NewReader(string(element)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&rows[i].Row); err != nil {
+			key, msg := jsonRowError(err)
+			rows[i].Errors[key] = msg
+		}
+	}
+	return rows, nil
+}
+
+// jsonRowError turns a decoding error into a validation-style key and message,
+// keyed by the offending field where the decoder tells us which one it was.
+func jsonRowError(err error) (string, string) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return typeErr.Field, fmt.Sprintf("must be a JSON %s", jsonKind(typeErr.Type.Kind()))
+	}
+	return "row", strings.TrimPrefix(err.Error(), "json: ")
+}
+
+func jsonKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice:
+		return "array"
+	case reflect.Struct, reflect.Pointer:
+		return "object"
+	}
+	return "string"
+}
+
+// Write encodes exported rows in the given format.
+func Write(w io.Writer, format Format, rows []dto.CatalogRow) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, rows)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+	return ErrUnknownFormat
+}
+
+func writeCSV(w io.Writer, rows []dto.CatalogRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		var width, height, depth string
+		if row.Dimensions != nil {
+			width = strconv.FormatFloat(row.Dimensions.Width, 'f', -1, 64)
+			height = strconv.FormatFloat(row.Dimensions.Height, 'f', -1, 64)
+			depth = strconv.FormatFloat(row.Dimensions.Depth, 'f', -1, 64)
+		}
+		record := []string{
+			row.SKU, row.Name, row.Description,
+			strconv.FormatFloat(row.Price, 'f', 2, 64), strconv.Itoa(row.StockQuantity),
+			row.Category, row.Brand, strings.Join(row.Images, imageSeparator), row.Thumbnail,
+			width, height, depth, row.WarrantyInformation, string(row.Status),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,161 @@
+// internal/catalog/handler.go
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+)
+
+// maxImportBytes caps the size of an uploaded catalog file.
+const maxImportBytes = 20 << 20
+
+type Handler struct {
+	catalogSvc domain.CatalogService
+	logger     *slog.Logger
+}
+
+func NewHandler(catalogSvc domain.CatalogService, logger *slog.Logger) *Handler {
+	return &Handler{catalogSvc: catalogSvc, logger: logger}
+}
+
+// HandleImport upserts products from a CSV or JSON file. The file is either the raw
+// request body or the "file" field of a multipart form. The format comes from ?format,
+// else the file extension, else the Content-Type. With ?dry_run=true nothing is written.
+func (h *Handler) HandleImport(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	dryRun := false
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(raw); err != nil {
+			response.JSON(w, http.StatusUnprocessableEntity, map[string]string{"dry_run": "must be true or false"})
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	body, format, err := importSource(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			response.Error(w, http.StatusRequestEntityTooLarge, "import file is too large")
+		case errors.Is(err, ErrUnknownFormat):
+			response.Error(w, http.StatusUnsupportedMediaType, "import file must be csv or json")
+		default:
+			h.logger.Warn("invalid catalog import upload", "error", err)
+			response.Error(w, http.StatusBadRequest, "invalid import upload")
+		}
+		return
+	}
+	defer body.Close()
+
+	rows, err := Parse(body, format)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(w, http.StatusRequestEntityTooLarge, "import file is too large")
+			return
+		}
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.catalogSvc.ImportProducts(r.Context(), &adminID, rows, dryRun)
+	if err != nil {
+		h.logger.Error("catalog import failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not import catalog")
+		return
+	}
+
+	status := http.StatusOK
+	if report.Failed > 0 && !dryRun {
+		status = http.StatusUnprocessableEntity
+	}
+	response.JSON(w, status, report)
+}
+
+// HandleExport downloads the full catalog as JSON (the default) or, with ?format=csv, as CSV.
+func (h *Handler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	format := FormatJSON
+	if raw := r.URL.Query().Get("format"); raw != "" {
+		var err error
+		if format, err = ParseFormat(raw); err != nil {
+			response.JSON(w, http.StatusUnprocessableEntity, map[string]string{"format": "must be csv or json"})
+			return
+		}
+	}
+
+	rows, err := h.catalogSvc.ExportProducts(r.Context())
+	if err != nil {
+		h.logger.Error("catalog export failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not export catalog")
+		return
+	}
+
+	contentType := "application/json"
+	if format == FormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	filename := fmt.Sprintf("catalog-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+
+	if err := Write(w, format, rows); err != nil {
+		// Headers are already sent, so all we can do is log.
+		h.logger.Error("failed to write catalog export", "error", err)
+	}
+}
+
+// importSource finds the uploaded file in the request and works out its format.
+func importSource(r *http.Request) (io.ReadCloser, Format, error) {
+	explicit := r.URL.Query().Get("format")
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, "", err
+		}
+		if explicit == "" {
+			explicit = filepath.Ext(header.Filename)
+		}
+		format, err := ParseFormat(explicit)
+		if err != nil {
+			file.Close()
+			return nil, "", err
+		}
+		return file, format, nil
+	}
+
+	if explicit == "" {
+		switch mediaType {
+		case "text/csv":
+			explicit = "csv"
+		case "application/json":
+			explicit = "json"
+		}
+	}
+	format, err := ParseFormat(explicit)
+	if err != nil {
+		return nil, "", err
+	}
+	return r.Body, format, nil
+}
@@ -0,0 +1,28 @@
+// internal/catalog/requests.go
+package catalog
+
+import (
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+// ValidateCatalogRow validates one product from an import file. The rules mirror
+// those for creating a product through the admin API.
+func ValidateCatalogRow(r dto.CatalogRow, v *validator.Validator) {
+	v.Check(validator.NotBlank(r.SKU), "sku", "must be provided")
+	v.Check(len(r.SKU) <= 100, "sku", "must not exceed 100 characters")
+	v.Check(validator.NotBlank(r.Name), "name", "must be provided")
+	v.Check(len(r.Name) <= 255, "name", "must not exceed 255 characters")
+	v.Check(validator.NotBlank(r.Description), "description", "must be provided")
+	v.Check(r.Price > 0, "price", "must be greater than zero")
+	v.Check(r.StockQuantity >= 0, "stock_quantity", "must not be negative")
+	v.Check(validator.NotBlank(r.Category), "category", "must be provided")
+	v.Check(len(r.Category) <= 100, "category", "must not exceed 100 characters")
+	v.Check(r.Status == "" || r.Status.IsValid(), "status", "must be draft, active or archived")
+	v.Check(r.Status == "" || !r.Archived || r.Status == models.ProductStatusArchived, "status", "conflicts with archived")
+	if r.Dimensions != nil {
+		v.Check(r.Dimensions.Width >= 0 && r.Dimensions.Height >= 0 && r.Dimensions.Depth >= 0,
+			"dimensions", "must not be negative")
+	}
+}
@@ -0,0 +1,315 @@
+// internal/catalog/service.go
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/inventory"
+	"github.com/purushothdl/ecommerce-api/internal/pricing"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/jsonutil"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type catalogService struct {
+	productRepo domain.ProductRepository
+	variantRepo domain.VariantRepository
+	categorySvc domain.CategoryService
+	store       domain.Store
+	logger      *slog.Logger
+}
+
+func NewCatalogService(productRepo domain.ProductRepository, variantRepo domain.VariantRepository, categorySvc domain.CategoryService, store domain.Store, logger *slog.Logger) domain.CatalogService {
+	return &catalogService{
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		categorySvc: categorySvc,
+		store:       store,
+		logger:      logger,
+	}
+}
+
+// ImportProducts upserts products by SKU. Every row is validated first; if any row is
+// invalid, or dryRun is set, nothing is written and the report describes what would
+// happen. Otherwise missing categories are created and all rows are written in a single
+// transaction, so an import is applied completely or not at all. Stock changes are
+// recorded against actorID, which is nil when the import runs from the command line.
+func (s *catalogService) ImportProducts(ctx context.Context, actorID *int64, rows []dto.CatalogImportRow, dryRun bool) (*dto.CatalogImportReport, error) {
+	report := &dto.CatalogImportReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]dto.CatalogRowResult, len(rows)),
+	}
+
+	categories, err := s.categorySvc.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("catalog service: could not load categories: %w", err)
+	}
+	knownCategories := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		knownCategories[c.Name] = true
+	}
+
+	seenSKUs := make(map[string]int, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		normalizeRow(&row.Row)
+		result := &report.Rows[i]
+		result.Line = row.Line
+		result.SKU = row.Row.SKU
+
+		v := validator.New()
+		for key, msg := range row.Errors {
+			v.AddError(key, msg)
+		}
+		ValidateCatalogRow(row.Row, v)
+		if first, dup := seenSKUs[row.Row.SKU]; dup && row.Row.SKU != "" {
+			v.AddError("sku", fmt.Sprintf("duplicates the sku on line %d", first))
+		} else {
+			seenSKUs[row.Row.SKU] = row.Line
+		}
+		if !v.Valid() {
+			result.Action = dto.CatalogActionError
+			result.Errors = v.Errors
+			report.Failed++
+			continue
+		}
+
+		existing, err := s.productRepo.GetBySKU(ctx, row.Row.SKU)
+		switch {
+		case err == nil:
+			result.Action = dto.CatalogActionUpdate
+			hasVariants, err := s.hasVariants(ctx, s.variantRepo, existing.ID)
+			if err != nil {
+				return nil, err
+			}
+			if hasVariants {
+				result.Warnings = append(result.Warnings, "stock_quantity is ignored because the product has variants")
+			}
+			report.Updated++
+		case errors.Is(err, apperrors.ErrNotFound):
+			result.Action = dto.CatalogActionCreate
+			report.Created++
+		default:
+			return nil, fmt.Errorf("catalog service: could not look up sku %q: %w", row.Row.SKU, err)
+		}
+
+		if !knownCategories[row.Row.Category] {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("category %q will be created", row.Row.Category))
+		}
+	}
+
+	if dryRun || report.Failed > 0 {
+		s.logger.Info("catalog import not applied", "dry_run", dryRun, "rows", report.Total, "failed", report.Failed)
+		return report, nil
+	}
+
+	// Categories go through the category service, which is not transactional. Creating
+	// them up front is harmless if the product writes below fail: a rerun reuses them.
+	categoryIDs := make(map[string]int64)
+	for _, row := range rows {
+		name := row.Row.Category
+		if _, ok := categoryIDs[name]; ok {
+			continue
+		}
+		category, err := s.categorySvc.GetOrCreate(ctx, name)
+		if err != nil {
+			s.logger.Error("failed to resolve import category", "category", name, "error", err)
+			return nil, fmt.Errorf("catalog service: could not resolve category %q: %w", name, err)
+		}
+		categoryIDs[name] = category.ID
+	}
+
+	err = s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		for _, row := range rows {
+			if err := s.upsertRow(ctx, q, actorID, row.Row, categoryIDs[row.Row.Category]); err != nil {
+				return fmt.Errorf("line %d (sku %s): %w", row.Line, row.Row.SKU, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("catalog import failed", "error", err)
+		return nil, fmt.Errorf("catalog service: could not import products: %w", err)
+	}
+
+	report.Applied = true
+	s.logger.Info("catalog imported", "rows", report.Total, "created", report.Created, "updated", report.Updated)
+	return report, nil
+}
+
+// upsertRow creates the product for a row, or overwrites the existing product with the
+// same SKU. Images, thumbnail and dimensions are only replaced when the row has them.
+func (s *catalogService) upsertRow(ctx context.Context, q *domain.Queries, actorID *int64, row dto.CatalogRow, categoryID int64) error {
+	product, err := q.ProductRepo.GetBySKU(ctx, row.SKU)
+	if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+		return err
+	}
+
+	if product == nil {
+		product = &models.Product{SKU: row.SKU, Images: pq.StringArray{}}
+		applyRow(product, row, categoryID)
+		if err := q.ProductRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		if err := importPrice(ctx, q, actorID, product.ID, nil, product.Price); err != nil {
+			return err
+		}
+		if err := importStock(ctx, q, actorID, product.ID, row.StockQuantity); err != nil {
+			return err
+		}
+		return importStatus(ctx, q, product, row.Status)
+	}
+
+	// Lock the row so the stock delta below is measured against the current level.
+	current, err := q.ProductRepo.GetByIDForUpdate(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+
+	// The stock of a product with variants is the sum of its variants' stock, kept in
+	// sync by the database, so an imported figure would only be overwritten again.
+	hasVariants, err := s.hasVariants(ctx, q.VariantRepo, product.ID)
+	if err != nil {
+		return err
+	}
+	if !hasVariants {
+		if err := importStock(ctx, q, actorID, product.ID, row.StockQuantity-current.StockQuantity); err != nil {
+			return err
+		}
+	}
+	applyRow(product, row, categoryID)
+	if err := importPrice(ctx, q, actorID, product.ID, &current.BasePrice, product.Price); err != nil {
+		return err
+	}
+	// Attribute values belong to the old category's definitions.
+	if product.CategoryID != current.CategoryID {
+		if err := q.AttributeRepo.DeleteValuesOutsideCategory(ctx, product.ID, product.CategoryID); err != nil {
+			return err
+		}
+	}
+	if err := q.ProductRepo.Update(ctx, product); err != nil {
+		return err
+	}
+	return importStatus(ctx, q, product, row.Status)
+}
+
+// importStatus moves a product to the row's status through SetStatus, so archived_at is
+// stamped or cleared as it is for status changes made in the admin API. An empty status
+// keeps the product's current one.
+func importStatus(ctx context.Context, q *domain.Queries, product *models.Product, status models.ProductStatus) error {
+	if status == "" || status == product.Status {
+		return nil
+	}
+	return q.ProductRepo.SetStatus(ctx, product.ID, status)
+}
+
+// importPrice records an imported regular price in the price history.
+func importPrice(ctx context.Context, q *domain.Queries, actorID *int64, productID int64, oldPrice *float64, newPrice float64) error {
+	return pricing.RecordPriceChange(ctx, q, &models.PriceChange{
+		ProductID: productID,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		ActorID:   actorID,
+		Note:      "catalog import",
+	})
+}
+
+// importStock moves a product's stock by delta at the default warehouse, so an imported
+// stock figure becomes the product's total.
+func importStock(ctx context.Context, q *domain.Queries, actorID *int64, productID int64, delta int) error {
+	return inventory.AdjustStock(ctx, q, &models.InventoryMovement{
+		ProductID: productID,
+		Delta:     delta,
+		Reason:    models.MovementReasonManualAdjustment,
+		ActorID:   actorID,
+		Note:      "catalog import",
+	})
+}
+
+func (s *catalogService) hasVariants(ctx context.Context, repo domain.VariantRepository, productID int64) (bool, error) {
+	variants, err := repo.ListByProductID(ctx, productID)
+	if err != nil {
+		return false, fmt.Errorf("catalog service: could not check variants: %w", err)
+	}
+	return len(variants) > 0, nil
+}
+
+// ExportProducts returns every product, archived ones included, in import format.
+func (s *catalogService) ExportProducts(ctx context.Context) ([]dto.CatalogRow, error) {
+	products, err := s.productRepo.ListAll(ctx)
+	if err != nil {
+		s.logger.Error("failed to load products for export", "error", err)
+		return nil, fmt.Errorf("catalog service: could not export products: %w", err)
+	}
+
+	rows := make([]dto.CatalogRow, 0, len(products))
+	for _, p := range products {
+		row := dto.CatalogRow{
+			SKU:                 p.SKU,
+			Name:                p.Name,
+			Description:         p.Description,
+			Price:               p.BasePrice,
+			StockQuantity:       p.StockQuantity,
+			Brand:               p.Brand,
+			Images:              p.Images,
+			Thumbnail:           p.Thumbnail,
+			WarrantyInformation: p.WarrantyInformation,
+			Status:              p.Status,
+		}
+		if p.Category != nil {
+			row.Category = p.Category.Name
+		}
+		if len(p.Dimensions) > 0 && string(p.Dimensions) != "null" {
+			var dims models.Dimensions
+			if err := json.Unmarshal(p.Dimensions, &dims); err != nil {
+				s.logger.Warn("skipping unreadable product dimensions in export", "product_id", p.ID, "error", err)
+			} else {
+				row.Dimensions = &dims
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// normalizeRow trims the free-text identifiers that are used for matching.
+func normalizeRow(row *dto.CatalogRow) {
+	row.SKU = strings.TrimSpace(row.SKU)
+	row.Name = strings.TrimSpace(row.Name)
+	row.Category = strings.TrimSpace(row.Category)
+	row.Brand = strings.TrimSpace(row.Brand)
+	// Older exports only said whether a product was archived; false cannot tell a draft
+	// from an active product, so it leaves the status alone.
+	if row.Status == "" && row.Archived {
+		row.Status = models.ProductStatusArchived
+	}
+}
+
+// applyRow copies a row's fields onto a product.
+func applyRow(product *models.Product, row dto.CatalogRow, categoryID int64) {
+	product.Name = row.Name
+	product.Description = row.Description
+	product.Price = row.Price
+	product.CategoryID = categoryID
+	product.Brand = row.Brand
+	product.WarrantyInformation = row.WarrantyInformation
+	if len(row.Images) > 0 {
+		product.Images = pq.StringArray(row.Images)
+	}
+	if row.Thumbnail != "" {
+		product.Thumbnail = row.Thumbnail
+	}
+	if row.Dimensions != nil {
+		product.Dimensions = jsonutil.MustMarshal(row.Dimensions)
+	}
+}
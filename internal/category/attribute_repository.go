@@ -0,0 +1,207 @@
+// internal/category/attribute_repository.go
+package category
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type attributeRepository struct {
+	db domain.DBTX
+}
+
+func NewAttributeRepository(db domain.DBTX) domain.AttributeRepository {
+	return &attributeRepository{db: db}
+}
+
+const attributeColumns = `id, category_id, code, name, data_type, unit, allowed_values, position, created_at, updated_at`
+
+func scanAttribute(row interface{ Scan(dest ...any) error }, a *models.CategoryAttribute) error {
+	return row.Scan(
+		&a.ID, &a.CategoryID, &a.Code, &a.Name, &a.Type, &a.Unit, &a.AllowedValues,
+		&a.Position, &a.CreatedAt, &a.UpdatedAt,
+	)
+}
+
+// ListDefinitions returns a category's attributes in display order.
+func (r *attributeRepository) ListDefinitions(ctx context.Context, categoryID int64) ([]models.CategoryAttribute, error) {
+	query := `SELECT ` + attributeColumns + ` FROM category_attributes WHERE category_id = $1 ORDER BY position, id`
+	rows, err := r.db.QueryContext(ctx, query, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("attribute repository: failed to list attributes: %w", err)
+	}
+	defer rows.Close()
+
+	attributes := []models.CategoryAttribute{}
+	for rows.Next() {
+		var a models.CategoryAttribute
+		if err := scanAttribute(rows, &a); err != nil {
+			return nil, fmt.Errorf("attribute repository: failed to scan attribute: %w", err)
+		}
+		attributes = append(attributes, a)
+	}
+	return attributes, rows.Err()
+}
+
+func (r *attributeRepository) GetDefinition(ctx context.Context, id int64) (*models.CategoryAttribute, error) {
+	query := `SELECT ` + attributeColumns + ` FROM category_attributes WHERE id = $1`
+	var a models.CategoryAttribute
+	if err := scanAttribute(r.db.QueryRowContext(ctx, query, id), &a); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("attribute repository: failed to get attribute: %w", err)
+	}
+	return &a, nil
+}
+
+func (r *attributeRepository) CreateDefinition(ctx context.Context, a *models.CategoryAttribute) error {
+	if a.AllowedValues == nil {
+		a.AllowedValues = pq.StringArray{}
+	}
+	query := `
+        INSERT INTO category_attributes (category_id, code, name, data_type, unit, allowed_values, position)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query, a.CategoryID, a.Code, a.Name, a.Type, a.Unit, a.AllowedValues, a.Position).
+		Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		if mapped := mapAttributeWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("attribute repository: failed to create attribute: %w", err)
+	}
+	return nil
+}
+
+// UpdateDefinition writes the attribute's name, unit, allowed values and position.
+// The code and type are fixed once created, since filters and stored values rely on them.
+func (r *attributeRepository) UpdateDefinition(ctx context.Context, a *models.CategoryAttribute) error {
+	query := `
+        UPDATE category_attributes
+        SET name = $1, unit = $2, allowed_values = $3, position = $4, updated_at = NOW()
+        WHERE id = $5
+        RETURNING updated_at`
+	err := r.db.QueryRowContext(ctx, query, a.Name, a.Unit, a.AllowedValues, a.Position, a.ID).Scan(&a.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.ErrNotFound
+		}
+		return fmt.Errorf("attribute repository: failed to update attribute: %w", err)
+	}
+	return nil
+}
+
+// DeleteDefinition removes an attribute along with every product's value for it.
+func (r *attributeRepository) DeleteDefinition(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM category_attributes WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("attribute repository: failed to delete attribute: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("attribute repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// HasValuesOutside reports whether any product holds a value for the attribute that
+// is not in allowed.
+func (r *attributeRepository) HasValuesOutside(ctx context.Context, attributeID int64, allowed []string) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM product_attribute_values
+            WHERE attribute_id = $1 AND NOT (value = ANY($2))
+        )`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, attributeID, pq.StringArray(allowed)).Scan(&exists); err != nil {
+		return false, fmt.Errorf("attribute repository: failed to check attribute values: %w", err)
+	}
+	return exists, nil
+}
+
+// ListValues returns the attribute values of the given products, keyed by product ID
+// and ordered like the category's attribute definitions.
+func (r *attributeRepository) ListValues(ctx context.Context, productIDs []int64) (map[int64][]models.ProductAttribute, error) {
+	values := make(map[int64][]models.ProductAttribute, len(productIDs))
+	if len(productIDs) == 0 {
+		return values, nil
+	}
+
+	query := `
+        SELECT pav.product_id, ca.id, ca.code, ca.name, ca.data_type, ca.unit, pav.value
+        FROM product_attribute_values pav
+        JOIN category_attributes ca ON ca.id = pav.attribute_id
+        WHERE pav.product_id = ANY($1)
+        ORDER BY pav.product_id, ca.position, ca.id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Int64Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("attribute repository: failed to list attribute values: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.ProductAttribute
+		if err := rows.Scan(&v.ProductID, &v.AttributeID, &v.Code, &v.Name, &v.Type, &v.Unit, &v.Value); err != nil {
+			return nil, fmt.Errorf("attribute repository: failed to scan attribute value: %w", err)
+		}
+		values[v.ProductID] = append(values[v.ProductID], v)
+	}
+	return values, rows.Err()
+}
+
+// ReplaceValues swaps a product's attribute values for the given set.
+// Callers should run it inside a transaction so readers never see a partial set.
+func (r *attributeRepository) ReplaceValues(ctx context.Context, productID int64, values []models.ProductAttribute) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM product_attribute_values WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("attribute repository: failed to clear attribute values: %w", err)
+	}
+
+	query := `INSERT INTO product_attribute_values (product_id, attribute_id, value) VALUES ($1, $2, $3)`
+	for _, v := range values {
+		if _, err := r.db.ExecContext(ctx, query, productID, v.AttributeID, v.Value); err != nil {
+			return fmt.Errorf("attribute repository: failed to insert attribute value: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteValuesOutsideCategory drops a product's values for attributes that do not belong
+// to categoryID, which is what is left behind when a product moves to another category.
+func (r *attributeRepository) DeleteValuesOutsideCategory(ctx context.Context, productID, categoryID int64) error {
+	query := `
+        DELETE FROM product_attribute_values pav
+        USING category_attributes ca
+        WHERE ca.id = pav.attribute_id AND pav.product_id = $1 AND ca.category_id <> $2`
+	if _, err := r.db.ExecContext(ctx, query, productID, categoryID); err != nil {
+		return fmt.Errorf("attribute repository: failed to delete stale attribute values: %w", err)
+	}
+	return nil
+}
+
+// mapAttributeWriteError translates constraint violations on attribute inserts into domain errors.
+func mapAttributeWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+	switch pgErr.Code {
+	case "23505":
+		return apperrors.ErrDuplicateAttribute
+	case "23503":
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// internal/category/cached_service.go
+package category
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/cache"
+)
+
+// cachedCategoryService serves category reads from an in-process cache and flushes it
+// after every write.
+type cachedCategoryService struct {
+	next  domain.CategoryService
+	cache *cache.Cache
+}
+
+// NewCachedCategoryService wraps next so the category list, tree and attribute
+// definitions are cached. Cached values must be treated as read-only.
+func NewCachedCategoryService(next domain.CategoryService, c *cache.Cache) domain.CategoryService {
+	return &cachedCategoryService{next: next, cache: c}
+}
+
+func (s *cachedCategoryService) ListCategories(ctx context.Context) ([]*models.Category, error) {
+	value, err := s.cache.GetOrLoad("categories", func() (any, error) {
+		return s.next.ListCategories(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*models.Category), nil
+}
+
+func (s *cachedCategoryService) GetCategoryTree(ctx context.Context) ([]*models.Category, error) {
+	value, err := s.cache.GetOrLoad("categories:tree", func() (any, error) {
+		return s.next.GetCategoryTree(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*models.Category), nil
+}
+
+func (s *cachedCategoryService) ListAttributes(ctx context.Context, categoryID int64) ([]models.CategoryAttribute, error) {
+	value, err := s.cache.GetOrLoad(fmt.Sprintf("categories:%d:attributes", categoryID), func() (any, error) {
+		return s.next.ListAttributes(ctx, categoryID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]models.CategoryAttribute), nil
+}
+
+// GetOrCreate always flushes since the caller cannot tell whether a category was created.
+func (s *cachedCategoryService) GetOrCreate(ctx context.Context, name string) (*models.Category, error) {
+	c, err := s.next.GetOrCreate(ctx, name)
+	s.cache.Flush()
+	return c, err
+}
+
+func (s *cachedCategoryService) CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*models.Category, error) {
+	c, err := s.next.CreateCategory(ctx, req)
+	s.cache.Flush()
+	return c, err
+}
+
+func (s *cachedCategoryService) UpdateCategory(ctx context.Context, id int64, req *dto.UpdateCategoryRequest) (*models.Category, error) {
+	c, err := s.next.UpdateCategory(ctx, id, req)
+	s.cache.Flush()
+	return c, err
+}
+
+func (s *cachedCategoryService) MoveCategory(ctx context.Context, id int64, req *dto.MoveCategoryRequest) (*models.Category, error) {
+	c, err := s.next.MoveCategory(ctx, id, req)
+	s.cache.Flush()
+	return c, err
+}
+
+func (s *cachedCategoryService) DeleteCategory(ctx context.Context, id int64) error {
+	err := s.next.DeleteCategory(ctx, id)
+	s.cache.Flush()
+	return err
+}
+
+func (s *cachedCategoryService) CreateAttribute(ctx context.Context, categoryID int64, req *dto.CreateCategoryAttributeRequest) (*models.CategoryAttribute, error) {
+	a, err := s.next.CreateAttribute(ctx, categoryID, req)
+	s.cache.Flush()
+	return a, err
+}
+
+func (s *cachedCategoryService) UpdateAttribute(ctx context.Context, categoryID, attributeID int64, req *dto.UpdateCategoryAttributeRequest) (*models.CategoryAttribute, error) {
+	a, err := s.next.UpdateAttribute(ctx, categoryID, attributeID, req)
+	s.cache.Flush()
+	return a, err
+}
+
+func (s *cachedCategoryService) DeleteAttribute(ctx context.Context, categoryID, attributeID int64) error {
+	err := s.next.DeleteAttribute(ctx, categoryID, attributeID)
+	s.cache.Flush()
+	return err
+}
@@ -0,0 +1,298 @@
+// internal/category/handler.go
+package category
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	categorySvc domain.CategoryService
+	cacheMaxAge time.Duration // Cache-Control max-age for public category reads
+	logger      *slog.Logger
+}
+
+func NewHandler(categorySvc domain.CategoryService, cacheMaxAge time.Duration, logger *slog.Logger) *Handler {
+	return &Handler{categorySvc: categorySvc, cacheMaxAge: cacheMaxAge, logger: logger}
+}
+
+// HandleGetCategoryTree returns all categories nested under their parents.
+func (h *Handler) HandleGetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.categorySvc.GetCategoryTree(r.Context())
+	if err != nil {
+		h.logger.Error("failed to build category tree", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not retrieve categories")
+		return
+	}
+	response.CachedJSON(w, r, tree, models.LatestCategoryUpdate(tree), h.cacheMaxAge)
+}
+
+// HandleCreateCategory lets an admin add a category, optionally under a parent.
+func (h *Handler) HandleCreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid create category payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreateCategoryRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	category, err := h.categorySvc.CreateCategory(r.Context(), &req)
+	if err != nil {
+		h.writeCategoryError(w, err, "could not create category")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, category)
+}
+
+// HandleUpdateCategory renames or reorders a category.
+func (h *Handler) HandleUpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	var req dto.UpdateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid update category payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateUpdateCategoryRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	category, err := h.categorySvc.UpdateCategory(r.Context(), id, &req)
+	if err != nil {
+		h.writeCategoryError(w, err, "could not update category")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, category)
+}
+
+// HandleMoveCategory moves a category under a new parent, or to the top level.
+func (h *Handler) HandleMoveCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	var req dto.MoveCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid move category payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateMoveCategoryRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	category, err := h.categorySvc.MoveCategory(r.Context(), id, &req)
+	if err != nil {
+		h.writeCategoryError(w, err, "could not move category")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, category)
+}
+
+// HandleDeleteCategory deletes a category that has no products and no subcategories.
+func (h *Handler) HandleDeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	if err := h.categorySvc.DeleteCategory(r.Context(), id); err != nil {
+		h.writeCategoryError(w, err, "could not delete category")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "category deleted successfully"})
+}
+
+// HandleListAttributes returns the attributes defined for a category, so clients can
+// show product specs and build attribute filters.
+func (h *Handler) HandleListAttributes(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	attributes, err := h.categorySvc.ListAttributes(r.Context(), categoryID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "category not found")
+			return
+		}
+		h.logger.Error("failed to list category attributes", "category_id", categoryID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not retrieve attributes")
+		return
+	}
+
+	var lastModified time.Time
+	for _, a := range attributes {
+		if a.UpdatedAt.After(lastModified) {
+			lastModified = a.UpdatedAt
+		}
+	}
+	response.CachedJSON(w, r, attributes, lastModified, h.cacheMaxAge)
+}
+
+// HandleCreateAttribute defines a typed attribute for products in a category.
+func (h *Handler) HandleCreateAttribute(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	var req dto.CreateCategoryAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid create attribute payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreateCategoryAttributeRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	attribute, err := h.categorySvc.CreateAttribute(r.Context(), categoryID, &req)
+	if err != nil {
+		h.writeAttributeError(w, err, "could not create attribute")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, attribute)
+}
+
+// HandleUpdateAttribute renames, reorders or changes the allowed values of an attribute.
+func (h *Handler) HandleUpdateAttribute(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+	attributeID, err := strconv.ParseInt(chi.URLParam(r, "attributeId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid attribute ID")
+		return
+	}
+
+	var req dto.UpdateCategoryAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid update attribute payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateUpdateCategoryAttributeRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	attribute, err := h.categorySvc.UpdateAttribute(r.Context(), categoryID, attributeID, &req)
+	if err != nil {
+		h.writeAttributeError(w, err, "could not update attribute")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, attribute)
+}
+
+// HandleDeleteAttribute removes an attribute and every product's value for it.
+func (h *Handler) HandleDeleteAttribute(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+	attributeID, err := strconv.ParseInt(chi.URLParam(r, "attributeId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid attribute ID")
+		return
+	}
+
+	if err := h.categorySvc.DeleteAttribute(r.Context(), categoryID, attributeID); err != nil {
+		h.writeAttributeError(w, err, "could not delete attribute")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "attribute deleted successfully"})
+}
+
+// writeAttributeError maps service errors from admin attribute writes to HTTP responses.
+func (h *Handler) writeAttributeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "category or attribute not found")
+	case errors.Is(err, apperrors.ErrDuplicateAttribute):
+		response.Error(w, http.StatusConflict, "an attribute with this code already exists in the category")
+	case errors.Is(err, apperrors.ErrInvalidAttributeValue):
+		response.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, apperrors.ErrAttributeValuesInUse):
+		response.Error(w, http.StatusConflict, "products still use values that would no longer be allowed")
+	default:
+		h.logger.Error("admin attribute operation failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeCategoryError maps service errors from admin category writes to HTTP responses.
+func (h *Handler) writeCategoryError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "category not found")
+	case errors.Is(err, apperrors.ErrCategoryNotFound):
+		response.Error(w, http.StatusUnprocessableEntity, "parent category does not exist")
+	case errors.Is(err, apperrors.ErrDuplicateCategory):
+		response.Error(w, http.StatusConflict, "a category with this name or slug already exists")
+	case errors.Is(err, apperrors.ErrCategoryCycle):
+		response.Error(w, http.StatusUnprocessableEntity, "a category cannot be moved under itself or one of its subcategories")
+	case errors.Is(err, apperrors.ErrCategoryHasProducts):
+		response.Error(w, http.StatusConflict, "category still has products, move or delete them first")
+	case errors.Is(err, apperrors.ErrCategoryHasChildren):
+		response.Error(w, http.StatusConflict, "category still has subcategories, move or delete them first")
+	default:
+		h.logger.Error("admin category operation failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
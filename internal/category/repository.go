@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/models"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/slug"
 )
 
 type categoryRepository struct {
@@ -20,31 +22,124 @@ func NewCategoryRepository(db *sql.DB) domain.CategoryRepository {
 	return &categoryRepository{db: db}
 }
 
+const categoryColumns = `id, name, slug, parent_id, position, created_at, updated_at`
+
+func scanCategory(row interface{ Scan(dest ...any) error }, cat *models.Category) error {
+	return row.Scan(&cat.ID, &cat.Name, &cat.Slug, &cat.ParentID, &cat.Position, &cat.CreatedAt, &cat.UpdatedAt)
+}
+
+// maxSlugAttempts bounds how often Create retries a derived slug that another insert
+// took between picking it and writing it.
+const maxSlugAttempts = 3
+
+// Create inserts a category. A slug given by the caller must be unique. When none is
+// given it is derived from the name, since callers such as the seeder and catalog
+// import only know the name; names that slugify to nothing use "category", and a
+// derived slug that is already taken gets the first free numeric suffix.
 func (r *categoryRepository) Create(ctx context.Context, category *models.Category) error {
-	query := `INSERT INTO categories (name) VALUES ($1) RETURNING id, created_at`
-	err := r.db.QueryRowContext(ctx, query, category.Name).Scan(&category.ID, &category.CreatedAt)
-	if err != nil {
-		// Note: A more robust implementation would check for specific DB errors like unique violation
+	derived := category.Slug == ""
+	base := category.Slug
+	if derived {
+		if base = slug.Make(category.Name); base == "" {
+			base = "category"
+		}
+	}
+
+	query := `
+        INSERT INTO categories (name, slug, parent_id, position)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at, updated_at`
+	for attempt := 1; ; attempt++ {
+		category.Slug = base
+		if derived {
+			free, err := r.freeSlug(ctx, base)
+			if err != nil {
+				return err
+			}
+			category.Slug = free
+		}
+
+		err := r.db.QueryRowContext(ctx, query, category.Name, category.Slug, category.ParentID, category.Position).
+			Scan(&category.ID, &category.CreatedAt, &category.UpdatedAt)
+		if err == nil {
+			return nil
+		}
+		if derived && attempt < maxSlugAttempts && isSlugConflict(err) {
+			continue
+		}
+		if mapped := mapWriteError(err); mapped != nil {
+			return mapped
+		}
 		return fmt.Errorf("category repository: failed to create category: %w", err)
 	}
-	return nil
+}
+
+// freeSlug returns base if no category uses it, otherwise base-N with the lowest N from
+// 2 up that is free. base must already be a valid slug, so it is safe inside the regex.
+func (r *categoryRepository) freeSlug(ctx context.Context, base string) (string, error) {
+	query := `SELECT slug FROM categories WHERE slug = $1 OR slug ~ ('^' || $1 || '-[0-9]+$')`
+	rows, err := r.db.QueryContext(ctx, query, base)
+	if err != nil {
+		return "", fmt.Errorf("category repository: failed to check slugs: %w", err)
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return "", fmt.Errorf("category repository: failed to scan slug: %w", err)
+		}
+		taken[s] = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("category repository: failed to check slugs: %w", err)
+	}
+
+	if !taken[base] {
+		return base, nil
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s-%d", base, n); !taken[candidate] {
+			return candidate, nil
+		}
+	}
+}
+
+// isSlugConflict reports whether err is a unique violation on the slug, as opposed to
+// the name.
+func isSlugConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "categories_slug_key"
+}
+
+func (r *categoryRepository) GetByID(ctx context.Context, id int64) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+	var cat models.Category
+	if err := scanCategory(r.db.QueryRowContext(ctx, query, id), &cat); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("category repository: failed to get category by id: %w", err)
+	}
+	return &cat, nil
 }
 
 func (r *categoryRepository) GetByName(ctx context.Context, name string) (*models.Category, error) {
-	query := `SELECT id, name, created_at FROM categories WHERE name = $1`
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE name = $1`
 	var cat models.Category
-	err := r.db.QueryRowContext(ctx, query, name).Scan(&cat.ID, &cat.Name, &cat.CreatedAt)
-	if err != nil {
+	if err := scanCategory(r.db.QueryRowContext(ctx, query, name), &cat); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, apperrors.ErrNotFound 
+			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("category repository: failed to get category by name: %w", err)
 	}
 	return &cat, nil
 }
 
+// GetAll returns every category ordered for display: by position, then name.
 func (r *categoryRepository) GetAll(ctx context.Context) ([]*models.Category, error) {
-	query := `SELECT id, name, created_at, updated_at FROM categories ORDER BY name ASC`
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY position ASC, name ASC`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("category repository: failed to get all categories: %w", err)
@@ -54,7 +149,7 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]*models.Category, er
 	var categories []*models.Category
 	for rows.Next() {
 		var cat models.Category
-		if err := rows.Scan(&cat.ID, &cat.Name, &cat.CreatedAt, &cat.UpdatedAt); err != nil {
+		if err := scanCategory(rows, &cat); err != nil {
 			return nil, fmt.Errorf("category repository: failed to scan category row: %w", err)
 		}
 		categories = append(categories, &cat)
@@ -64,4 +159,119 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]*models.Category, er
 		return nil, fmt.Errorf("category repository: error iterating rows: %w", err)
 	}
 	return categories, nil
-}
\ No newline at end of file
+}
+
+// Update writes the category's name, slug and position. The parent is changed through Move.
+func (r *categoryRepository) Update(ctx context.Context, category *models.Category) error {
+	query := `
+        UPDATE categories
+        SET name = $1, slug = $2, position = $3, updated_at = NOW()
+        WHERE id = $4
+        RETURNING updated_at`
+	err := r.db.QueryRowContext(ctx, query, category.Name, category.Slug, category.Position, category.ID).
+		Scan(&category.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.ErrNotFound
+		}
+		if mapped := mapWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("category repository: failed to update category: %w", err)
+	}
+	return nil
+}
+
+// Move re-parents a category. The cycle check and the update happen in one
+// statement, so a category can never end up below one of its own descendants.
+func (r *categoryRepository) Move(ctx context.Context, id int64, parentID *int64, position int) error {
+	query := `
+        WITH RECURSIVE subtree AS (
+            SELECT id FROM categories WHERE id = $1
+            UNION ALL
+            SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+        )
+        UPDATE categories
+        SET parent_id = $2, position = $3, updated_at = NOW()
+        WHERE id = $1
+          AND ($2::bigint IS NULL OR $2::bigint NOT IN (SELECT id FROM subtree))`
+
+	result, err := r.db.ExecContext(ctx, query, id, parentID, position)
+	if err != nil {
+		if mapped := mapWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("category repository: failed to move category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("category repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		// Either the category does not exist or the new parent is inside its subtree.
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return apperrors.ErrCategoryCycle
+	}
+	return nil
+}
+
+func (r *categoryRepository) CountProducts(ctx context.Context, id int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM products WHERE category_id = $1`, id).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("category repository: failed to count products: %w", err)
+	}
+	return count, nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		if mapped := mapDeleteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("category repository: failed to delete category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("category repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// mapWriteError translates constraint violations on category inserts and updates into domain errors.
+func mapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+	switch pgErr.Code {
+	case "23505":
+		return apperrors.ErrDuplicateCategory
+	case "23503":
+		// The requested parent does not exist.
+		return apperrors.ErrCategoryNotFound
+	case "23514":
+		return apperrors.ErrCategoryCycle
+	}
+	return nil
+}
+
+// mapDeleteError translates foreign key violations on delete into the reason the category is still in use.
+func mapDeleteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23503" {
+		return nil
+	}
+	if pgErr.ConstraintName == "products_category_id_fkey" {
+		return apperrors.ErrCategoryHasProducts
+	}
+	return apperrors.ErrCategoryHasChildren
+}
@@ -0,0 +1,77 @@
+// internal/category/requests.go
+package category
+
+import (
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+// ValidateCreateCategoryRequest validates an admin's new category payload
+func ValidateCreateCategoryRequest(r dto.CreateCategoryRequest, v *validator.Validator) {
+	v.Check(validator.NotBlank(r.Name), "name", "must be provided")
+	v.Check(len(r.Name) <= 100, "name", "must not exceed 100 characters")
+	if r.Slug != "" {
+		v.Check(validator.Matches(r.Slug, validator.SlugRX), "slug", "must contain only lowercase letters, digits and single hyphens")
+	}
+	if r.ParentID != nil {
+		v.Check(*r.ParentID > 0, "parent_id", "must be a valid category ID")
+	}
+	v.Check(r.Position >= 0, "position", "must not be negative")
+}
+
+// ValidateUpdateCategoryRequest validates a rename or reorder
+func ValidateUpdateCategoryRequest(r dto.UpdateCategoryRequest, v *validator.Validator) {
+	v.Check(r.Name != nil || r.Slug != nil || r.Position != nil, "request", "at least one field must be provided for an update")
+
+	if r.Name != nil {
+		v.Check(validator.NotBlank(*r.Name), "name", "must not be empty if provided")
+		v.Check(len(*r.Name) <= 100, "name", "must not exceed 100 characters")
+	}
+	if r.Slug != nil {
+		v.Check(validator.Matches(*r.Slug, validator.SlugRX), "slug", "must contain only lowercase letters, digits and single hyphens")
+	}
+	if r.Position != nil {
+		v.Check(*r.Position >= 0, "position", "must not be negative")
+	}
+}
+
+// ValidateMoveCategoryRequest validates a re-parenting request
+func ValidateMoveCategoryRequest(r dto.MoveCategoryRequest, v *validator.Validator) {
+	if r.ParentID != nil {
+		v.Check(*r.ParentID > 0, "parent_id", "must be a valid category ID")
+	}
+	if r.Position != nil {
+		v.Check(*r.Position >= 0, "position", "must not be negative")
+	}
+}
+
+// ValidateCreateCategoryAttributeRequest validates a new attribute definition
+func ValidateCreateCategoryAttributeRequest(r dto.CreateCategoryAttributeRequest, v *validator.Validator) {
+	v.Check(validator.Matches(r.Code, validator.AttributeCodeRX), "code", "must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	v.Check(len(r.Code) <= 50, "code", "must not exceed 50 characters")
+	v.Check(validator.NotBlank(r.Name), "name", "must be provided")
+	v.Check(len(r.Name) <= 100, "name", "must not exceed 100 characters")
+	v.Check(r.Type.IsValid(), "type", "must be one of text, number or boolean")
+	v.Check(len(r.Unit) <= 20, "unit", "must not exceed 20 characters")
+	v.Check(r.Position >= 0, "position", "must not be negative")
+	if len(r.AllowedValues) > 0 {
+		v.Check(r.Type != models.AttributeTypeBoolean, "allowed_values", "cannot be set for a boolean attribute")
+	}
+}
+
+// ValidateUpdateCategoryAttributeRequest validates an attribute edit
+func ValidateUpdateCategoryAttributeRequest(r dto.UpdateCategoryAttributeRequest, v *validator.Validator) {
+	v.Check(r.Name != nil || r.Unit != nil || r.AllowedValues != nil || r.Position != nil, "request", "at least one field must be provided for an update")
+
+	if r.Name != nil {
+		v.Check(validator.NotBlank(*r.Name), "name", "must not be empty if provided")
+		v.Check(len(*r.Name) <= 100, "name", "must not exceed 100 characters")
+	}
+	if r.Unit != nil {
+		v.Check(len(*r.Unit) <= 20, "unit", "must not exceed 20 characters")
+	}
+	if r.Position != nil {
+		v.Check(*r.Position >= 0, "position", "must not be negative")
+	}
+}
@@ -6,19 +6,25 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 
+	"github.com/lib/pq"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/ptr"
 )
 
 type categoryService struct {
-	repo   domain.CategoryRepository
-	logger *slog.Logger
+	repo          domain.CategoryRepository
+	attributeRepo domain.AttributeRepository
+	logger        *slog.Logger
 }
 
-func NewCategoryService(repo domain.CategoryRepository, logger *slog.Logger) domain.CategoryService {
-	return &categoryService{repo: repo, logger: logger}
+func NewCategoryService(repo domain.CategoryRepository, attributeRepo domain.AttributeRepository, logger *slog.Logger) domain.CategoryService {
+	return &categoryService{repo: repo, attributeRepo: attributeRepo, logger: logger}
 }
 
 // GetOrCreate is a perfect utility for our future seeder.
@@ -45,4 +51,241 @@ func (s *categoryService) ListCategories(ctx context.Context) ([]*models.Categor
 		return nil, fmt.Errorf("category service: could not retrieve categories: %w", err)
 	}
 	return categories, nil
-}
\ No newline at end of file
+}
+
+// GetCategoryTree returns the top-level categories with their descendants nested
+// under Children, each level ordered by position and then name.
+func (s *categoryService) GetCategoryTree(ctx context.Context) ([]*models.Category, error) {
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("failed to load categories for tree", "error", err)
+		return nil, fmt.Errorf("category service: could not retrieve categories: %w", err)
+	}
+	return buildTree(categories), nil
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*models.Category, error) {
+	category := &models.Category{
+		Name:     req.Name,
+		Slug:     req.Slug,
+		ParentID: req.ParentID,
+		Position: req.Position,
+	}
+
+	if err := s.repo.Create(ctx, category); err != nil {
+		s.logger.Warn("failed to create category", "name", req.Name, "error", err)
+		return nil, fmt.Errorf("category service: could not create category: %w", err)
+	}
+
+	s.logger.Info("category created", "category_id", category.ID, "slug", category.Slug)
+	return category, nil
+}
+
+// UpdateCategory renames or reorders a category. Renaming does not change the slug
+// unless a new one is given, so existing links keep working.
+func (s *categoryService) UpdateCategory(ctx context.Context, id int64, req *dto.UpdateCategoryRequest) (*models.Category, error) {
+	category, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("category service: could not retrieve category: %w", err)
+	}
+
+	ptr.UpdateStringIfProvided(&category.Name, req.Name)
+	ptr.UpdateStringIfProvided(&category.Slug, req.Slug)
+	if req.Position != nil {
+		category.Position = *req.Position
+	}
+
+	if err := s.repo.Update(ctx, category); err != nil {
+		s.logger.Warn("failed to update category", "category_id", id, "error", err)
+		return nil, fmt.Errorf("category service: could not update category: %w", err)
+	}
+
+	s.logger.Info("category updated", "category_id", id)
+	return category, nil
+}
+
+func (s *categoryService) MoveCategory(ctx context.Context, id int64, req *dto.MoveCategoryRequest) (*models.Category, error) {
+	category, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("category service: could not retrieve category: %w", err)
+	}
+
+	position := category.Position
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	if err := s.repo.Move(ctx, id, req.ParentID, position); err != nil {
+		s.logger.Warn("failed to move category", "category_id", id, "parent_id", req.ParentID, "error", err)
+		return nil, fmt.Errorf("category service: could not move category: %w", err)
+	}
+
+	s.logger.Info("category moved", "category_id", id, "parent_id", req.ParentID)
+	return s.repo.GetByID(ctx, id)
+}
+
+// DeleteCategory removes an empty category. Categories that still hold products or
+// subcategories are refused; those must be moved elsewhere first.
+func (s *categoryService) DeleteCategory(ctx context.Context, id int64) error {
+	count, err := s.repo.CountProducts(ctx, id)
+	if err != nil {
+		return fmt.Errorf("category service: could not check category products: %w", err)
+	}
+	if count > 0 {
+		s.logger.Warn("refusing to delete category with products", "category_id", id, "product_count", count)
+		return apperrors.ErrCategoryHasProducts
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Warn("failed to delete category", "category_id", id, "error", err)
+		return fmt.Errorf("category service: could not delete category: %w", err)
+	}
+
+	s.logger.Info("category deleted", "category_id", id)
+	return nil
+}
+
+// ListAttributes returns the attributes products in a category carry, in display order.
+func (s *categoryService) ListAttributes(ctx context.Context, categoryID int64) ([]models.CategoryAttribute, error) {
+	if _, err := s.repo.GetByID(ctx, categoryID); err != nil {
+		return nil, fmt.Errorf("category service: could not retrieve category: %w", err)
+	}
+
+	attributes, err := s.attributeRepo.ListDefinitions(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("failed to list category attributes", "category_id", categoryID, "error", err)
+		return nil, fmt.Errorf("category service: could not retrieve attributes: %w", err)
+	}
+	return attributes, nil
+}
+
+func (s *categoryService) CreateAttribute(ctx context.Context, categoryID int64, req *dto.CreateCategoryAttributeRequest) (*models.CategoryAttribute, error) {
+	allowed, err := normalizeAllowedValues(req.Type, req.AllowedValues)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := &models.CategoryAttribute{
+		CategoryID:    categoryID,
+		Code:          req.Code,
+		Name:          strings.TrimSpace(req.Name),
+		Type:          req.Type,
+		Unit:          strings.TrimSpace(req.Unit),
+		AllowedValues: allowed,
+		Position:      req.Position,
+	}
+	if err := s.attributeRepo.CreateDefinition(ctx, attribute); err != nil {
+		s.logger.Warn("failed to create category attribute", "category_id", categoryID, "code", req.Code, "error", err)
+		return nil, fmt.Errorf("category service: could not create attribute: %w", err)
+	}
+
+	s.logger.Info("category attribute created", "category_id", categoryID, "attribute_id", attribute.ID, "code", attribute.Code)
+	return attribute, nil
+}
+
+// UpdateAttribute edits an attribute's name, unit, allowed values or position. Narrowing
+// the allowed values is refused while products still use a value that would be dropped.
+func (s *categoryService) UpdateAttribute(ctx context.Context, categoryID, attributeID int64, req *dto.UpdateCategoryAttributeRequest) (*models.CategoryAttribute, error) {
+	attribute, err := s.getCategoryAttribute(ctx, categoryID, attributeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		attribute.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Unit != nil {
+		attribute.Unit = strings.TrimSpace(*req.Unit)
+	}
+	if req.Position != nil {
+		attribute.Position = *req.Position
+	}
+	if req.AllowedValues != nil {
+		allowed, err := normalizeAllowedValues(attribute.Type, req.AllowedValues)
+		if err != nil {
+			return nil, err
+		}
+		if len(allowed) > 0 {
+			inUse, err := s.attributeRepo.HasValuesOutside(ctx, attributeID, allowed)
+			if err != nil {
+				return nil, fmt.Errorf("category service: could not check attribute values: %w", err)
+			}
+			if inUse {
+				s.logger.Warn("attribute change would orphan product values", "attribute_id", attributeID)
+				return nil, apperrors.ErrAttributeValuesInUse
+			}
+		}
+		attribute.AllowedValues = allowed
+	}
+
+	if err := s.attributeRepo.UpdateDefinition(ctx, attribute); err != nil {
+		s.logger.Warn("failed to update category attribute", "attribute_id", attributeID, "error", err)
+		return nil, fmt.Errorf("category service: could not update attribute: %w", err)
+	}
+
+	s.logger.Info("category attribute updated", "category_id", categoryID, "attribute_id", attributeID)
+	return attribute, nil
+}
+
+// DeleteAttribute removes an attribute and every product's value for it.
+func (s *categoryService) DeleteAttribute(ctx context.Context, categoryID, attributeID int64) error {
+	if _, err := s.getCategoryAttribute(ctx, categoryID, attributeID); err != nil {
+		return err
+	}
+
+	if err := s.attributeRepo.DeleteDefinition(ctx, attributeID); err != nil {
+		s.logger.Warn("failed to delete category attribute", "attribute_id", attributeID, "error", err)
+		return fmt.Errorf("category service: could not delete attribute: %w", err)
+	}
+
+	s.logger.Info("category attribute deleted", "category_id", categoryID, "attribute_id", attributeID)
+	return nil
+}
+
+// getCategoryAttribute loads an attribute and makes sure it belongs to the category in the URL.
+func (s *categoryService) getCategoryAttribute(ctx context.Context, categoryID, attributeID int64) (*models.CategoryAttribute, error) {
+	attribute, err := s.attributeRepo.GetDefinition(ctx, attributeID)
+	if err != nil {
+		return nil, fmt.Errorf("category service: could not retrieve attribute: %w", err)
+	}
+	if attribute.CategoryID != categoryID {
+		return nil, apperrors.ErrNotFound
+	}
+	return attribute, nil
+}
+
+// normalizeAllowedValues puts allowed values in the canonical form of the type, so they
+// compare equal to the product values checked against them, and drops duplicates.
+func normalizeAllowedValues(t models.AttributeType, values []string) (pq.StringArray, error) {
+	allowed := pq.StringArray{}
+	for _, raw := range values {
+		value, err := t.Normalize(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: allowed value %q %v", apperrors.ErrInvalidAttributeValue, raw, err)
+		}
+		if !slices.Contains(allowed, value) {
+			allowed = append(allowed, value)
+		}
+	}
+	return allowed, nil
+}
+
+// buildTree nests a flat, display-ordered category list under each parent.
+func buildTree(categories []*models.Category) []*models.Category {
+	byID := make(map[int64]*models.Category, len(categories))
+	for _, cat := range categories {
+		byID[cat.ID] = cat
+	}
+
+	roots := []*models.Category{}
+	for _, cat := range categories {
+		if cat.ParentID == nil {
+			roots = append(roots, cat)
+			continue
+		}
+		if parent, ok := byID[*cat.ParentID]; ok {
+			parent.Children = append(parent.Children, cat)
+		}
+	}
+	return roots
+}
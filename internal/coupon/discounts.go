@@ -0,0 +1,174 @@
+// internal/coupon/discounts.go
+package coupon
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+// Line is a priced cart or order line as coupons see it. Amount is the line total in
+// the currency being charged.
+type Line struct {
+	ProductID  int64
+	CategoryID int64
+	Amount     float64
+}
+
+// CartLines turns cart items into lines at their current prices. Lines that cannot be
+// bought are left out, since checkout would refuse them anyway.
+func CartLines(items []models.CartItem) []Line {
+	lines := make([]Line, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		if item.Product == nil || !item.Product.IsPurchasable() {
+			continue
+		}
+		lines = append(lines, Line{
+			ProductID:  item.Product.ID,
+			CategoryID: item.Product.CategoryID,
+			Amount:     item.UnitPrice() * float64(item.Quantity),
+		})
+	}
+	return lines
+}
+
+// Evaluate works out what each coupon takes off lines, in the order the coupons were
+// applied. rate converts the base currency amounts on the coupons into the currency of
+// the lines, and per-user limits are checked when userID is known. A coupon that cannot
+// be used has Err set and takes nothing off; the returned error is for failed lookups only.
+// Redemption counts are exact when the coupons were loaded with ListByCartForUpdate.
+func Evaluate(ctx context.Context, repo domain.CouponRepository, coupons []*models.Coupon, userID *int64, lines []Line, rate float64, at time.Time) ([]models.AppliedCoupon, error) {
+	var subtotal float64
+	for _, line := range lines {
+		subtotal += line.Amount
+	}
+
+	applied := make([]models.AppliedCoupon, len(coupons))
+	remaining := subtotal
+	var accepted []*models.Coupon
+	for i, c := range coupons {
+		applied[i] = models.AppliedCoupon{CouponID: c.ID, Code: c.Code, Description: c.Description}
+
+		problem, err := usable(ctx, repo, c, userID, at)
+		if err != nil {
+			return nil, err
+		}
+		if problem == nil && !stacks(c, accepted) {
+			problem = apperrors.ErrCouponNotStackable
+		}
+		var amount float64
+		if problem == nil {
+			amount, problem = discount(c, lines, subtotal, rate)
+		}
+		if problem != nil {
+			applied[i].Err, applied[i].Problem = problem, problem.Error()
+			continue
+		}
+
+		// Coupons together never take off more than the lines are worth.
+		amount = min(amount, remaining)
+		remaining -= amount
+		applied[i].Amount = amount
+		accepted = append(accepted, c)
+	}
+	return applied, nil
+}
+
+// FirstProblem returns the reason the first unusable coupon in applied cannot be used,
+// or nil when all of them can.
+func FirstProblem(applied []models.AppliedCoupon) error {
+	for _, a := range applied {
+		if a.Err != nil {
+			return a.Err
+		}
+	}
+	return nil
+}
+
+// IsCouponProblem reports whether err says a coupon cannot be used, as opposed to a
+// failure looking it up. Such errors are safe to show to the customer.
+func IsCouponProblem(err error) bool {
+	for _, problem := range []error{
+		apperrors.ErrCouponNotActive, apperrors.ErrCouponUsageLimit, apperrors.ErrCouponNotStackable,
+		apperrors.ErrCouponMinimumNotMet, apperrors.ErrCouponNotApplicable,
+	} {
+		if errors.Is(err, problem) {
+			return true
+		}
+	}
+	return false
+}
+
+// usable checks a coupon's switch, validity window and usage limits at a moment.
+func usable(ctx context.Context, repo domain.CouponRepository, c *models.Coupon, userID *int64, at time.Time) (problem, err error) {
+	if !c.IsRunning(at) {
+		return apperrors.ErrCouponNotActive, nil
+	}
+	if c.UsageLimit == nil && (c.PerUserLimit == nil || userID == nil) {
+		return nil, nil
+	}
+
+	total, byUser, err := repo.CountRedemptions(ctx, c.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if c.UsageLimit != nil && total >= *c.UsageLimit {
+		return apperrors.ErrCouponUsageLimit, nil
+	}
+	if c.PerUserLimit != nil && userID != nil && byUser >= *c.PerUserLimit {
+		return apperrors.ErrCouponUsageLimit, nil
+	}
+	return nil, nil
+}
+
+// stacks reports whether c may be used together with the coupons already accepted.
+// Only stackable coupons combine; a coupon that is not stackable must be used alone.
+func stacks(c *models.Coupon, accepted []*models.Coupon) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+	if !c.Stackable {
+		return false
+	}
+	for _, other := range accepted {
+		if !other.Stackable {
+			return false
+		}
+	}
+	return true
+}
+
+// discount is what c takes off lines, before it is capped by other coupons. The
+// minimum is checked against the whole cart, the discount against the lines in scope.
+func discount(c *models.Coupon, lines []Line, subtotal, rate float64) (float64, error) {
+	if subtotal < c.MinCartValue*rate {
+		return 0, apperrors.ErrCouponMinimumNotMet
+	}
+
+	var eligible float64
+	for _, line := range lines {
+		if c.Covers(line.ProductID, line.CategoryID) {
+			eligible += line.Amount
+		}
+	}
+	if eligible <= 0 {
+		return 0, apperrors.ErrCouponNotApplicable
+	}
+
+	switch c.DiscountType {
+	case models.CouponDiscountPercentage:
+		amount := eligible * c.Value / 100
+		if c.MaxDiscount != nil {
+			amount = min(amount, *c.MaxDiscount*rate)
+		}
+		return amount, nil
+	case models.CouponDiscountFixed:
+		return min(c.Value*rate, eligible), nil
+	}
+	return 0, errors.New("unknown coupon discount type " + string(c.DiscountType))
+}
@@ -0,0 +1,146 @@
+// internal/coupon/handler.go
+package coupon
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	couponSvc domain.CouponService
+	logger    *slog.Logger
+}
+
+func NewHandler(couponSvc domain.CouponService, logger *slog.Logger) *Handler {
+	return &Handler{couponSvc: couponSvc, logger: logger}
+}
+
+func (h *Handler) HandleListCoupons(w http.ResponseWriter, r *http.Request) {
+	coupons, err := h.couponSvc.ListCoupons(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "could not retrieve coupons")
+		return
+	}
+	response.JSON(w, http.StatusOK, coupons)
+}
+
+func (h *Handler) HandleGetCoupon(w http.ResponseWriter, r *http.Request) {
+	couponID, err := strconv.ParseInt(chi.URLParam(r, "couponId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid coupon ID")
+		return
+	}
+
+	coupon, err := h.couponSvc.GetCoupon(r.Context(), couponID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "coupon not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "could not retrieve coupon")
+		return
+	}
+	response.JSON(w, http.StatusOK, coupon)
+}
+
+func (h *Handler) HandleCreateCoupon(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.CreateCouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreateCouponRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	coupon, err := h.couponSvc.CreateCoupon(r.Context(), adminID, &req)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrDuplicateCoupon) {
+			response.Error(w, http.StatusConflict, "a coupon with this code already exists")
+			return
+		}
+		h.logger.Error("failed to create coupon", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not create coupon")
+		return
+	}
+	response.JSON(w, http.StatusCreated, coupon)
+}
+
+func (h *Handler) HandleUpdateCoupon(w http.ResponseWriter, r *http.Request) {
+	couponID, err := strconv.ParseInt(chi.URLParam(r, "couponId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid coupon ID")
+		return
+	}
+
+	var req dto.UpdateCouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateUpdateCouponRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	coupon, err := h.couponSvc.UpdateCoupon(r.Context(), couponID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "coupon not found")
+		case errors.Is(err, apperrors.ErrInvalidCoupon):
+			response.Error(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.logger.Error("failed to update coupon", "coupon_id", couponID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not update coupon")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, coupon)
+}
+
+func (h *Handler) HandleDeleteCoupon(w http.ResponseWriter, r *http.Request) {
+	couponID, err := strconv.ParseInt(chi.URLParam(r, "couponId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid coupon ID")
+		return
+	}
+
+	if err := h.couponSvc.DeleteCoupon(r.Context(), couponID); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "coupon not found")
+		case errors.Is(err, apperrors.ErrCouponRedeemed):
+			response.Error(w, http.StatusConflict, "coupon has been redeemed; deactivate it instead")
+		default:
+			h.logger.Error("failed to delete coupon", "coupon_id", couponID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not delete coupon")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "coupon deleted successfully"})
+}
@@ -0,0 +1,267 @@
+// internal/coupon/repository.go
+package coupon
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type couponRepository struct {
+	db domain.DBTX
+}
+
+func NewCouponRepository(db domain.DBTX) domain.CouponRepository {
+	return &couponRepository{db: db}
+}
+
+// couponColumns reads a coupon with its live redemption count and expanded category scope.
+const couponColumns = `
+        c.id, c.code, c.description, c.discount_type, c.value, c.max_discount, c.min_cart_value,
+        c.product_ids, c.category_ids, c.usage_limit, c.per_user_limit,
+        (SELECT COUNT(*) FROM coupon_redemptions cr WHERE cr.coupon_id = c.id AND cr.released_at IS NULL),
+        c.stackable, c.starts_at, c.ends_at, c.active, c.created_by, c.created_at, c.updated_at,
+        category_subtree(c.category_ids)`
+
+func scanCoupon(row interface{ Scan(dest ...any) error }, c *models.Coupon) error {
+	return row.Scan(
+		&c.ID, &c.Code, &c.Description, &c.DiscountType, &c.Value, &c.MaxDiscount, &c.MinCartValue,
+		&c.ProductIDs, &c.CategoryIDs, &c.UsageLimit, &c.PerUserLimit,
+		&c.TimesUsed,
+		&c.Stackable, &c.StartsAt, &c.EndsAt, &c.Active, &c.CreatedBy, &c.CreatedAt, &c.UpdatedAt,
+		&c.ScopeCategoryIDs,
+	)
+}
+
+func (r *couponRepository) list(ctx context.Context, query string, args ...any) ([]*models.Coupon, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("coupon repository: failed to list coupons: %w", err)
+	}
+	defer rows.Close()
+
+	coupons := []*models.Coupon{}
+	for rows.Next() {
+		var c models.Coupon
+		if err := scanCoupon(rows, &c); err != nil {
+			return nil, fmt.Errorf("coupon repository: failed to scan coupon: %w", err)
+		}
+		coupons = append(coupons, &c)
+	}
+	return coupons, rows.Err()
+}
+
+func (r *couponRepository) get(ctx context.Context, where string, arg any) (*models.Coupon, error) {
+	var c models.Coupon
+	if err := scanCoupon(r.db.QueryRowContext(ctx, `SELECT `+couponColumns+` FROM coupons c WHERE `+where, arg), &c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("coupon repository: failed to get coupon: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *couponRepository) Create(ctx context.Context, c *models.Coupon) error {
+	if c.ProductIDs == nil {
+		c.ProductIDs = pq.Int64Array{}
+	}
+	if c.CategoryIDs == nil {
+		c.CategoryIDs = pq.Int64Array{}
+	}
+	query := `
+        INSERT INTO coupons (code, description, discount_type, value, max_discount, min_cart_value,
+                             product_ids, category_ids, usage_limit, per_user_limit, stackable,
+                             starts_at, ends_at, active, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+        RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query,
+		c.Code, c.Description, c.DiscountType, c.Value, c.MaxDiscount, c.MinCartValue,
+		c.ProductIDs, c.CategoryIDs, c.UsageLimit, c.PerUserLimit, c.Stackable,
+		c.StartsAt, c.EndsAt, c.Active, c.CreatedBy,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return apperrors.ErrDuplicateCoupon
+		}
+		return fmt.Errorf("coupon repository: failed to create coupon: %w", err)
+	}
+	return nil
+}
+
+func (r *couponRepository) GetByID(ctx context.Context, id int64) (*models.Coupon, error) {
+	return r.get(ctx, `c.id = $1`, id)
+}
+
+// GetByCode looks a coupon up by its code, which callers pass upper-cased.
+func (r *couponRepository) GetByCode(ctx context.Context, code string) (*models.Coupon, error) {
+	return r.get(ctx, `c.code = $1`, code)
+}
+
+// List returns every coupon, newest first.
+func (r *couponRepository) List(ctx context.Context) ([]*models.Coupon, error) {
+	return r.list(ctx, `SELECT `+couponColumns+` FROM coupons c ORDER BY c.created_at DESC, c.id DESC`)
+}
+
+// Update writes every field of the coupon except its code and discount type.
+func (r *couponRepository) Update(ctx context.Context, c *models.Coupon) error {
+	query := `
+        UPDATE coupons
+        SET description = $1, value = $2, max_discount = $3, min_cart_value = $4,
+            product_ids = $5, category_ids = $6, usage_limit = $7, per_user_limit = $8,
+            stackable = $9, starts_at = $10, ends_at = $11, active = $12, updated_at = NOW()
+        WHERE id = $13
+        RETURNING updated_at`
+	err := r.db.QueryRowContext(ctx, query,
+		c.Description, c.Value, c.MaxDiscount, c.MinCartValue,
+		c.ProductIDs, c.CategoryIDs, c.UsageLimit, c.PerUserLimit,
+		c.Stackable, c.StartsAt, c.EndsAt, c.Active, c.ID,
+	).Scan(&c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.ErrNotFound
+		}
+		return fmt.Errorf("coupon repository: failed to update coupon: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a coupon that was never redeemed, taking it off any carts it was applied to.
+func (r *couponRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM coupons WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return apperrors.ErrCouponRedeemed
+		}
+		return fmt.Errorf("coupon repository: failed to delete coupon: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("coupon repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// ListByCart returns the coupons applied to a cart in the order they were applied.
+func (r *couponRepository) ListByCart(ctx context.Context, cartID int64) ([]*models.Coupon, error) {
+	query := `
+        SELECT ` + couponColumns + `
+        FROM cart_coupons cc
+        JOIN coupons c ON c.id = cc.coupon_id
+        WHERE cc.cart_id = $1
+        ORDER BY cc.created_at, c.id`
+	return r.list(ctx, query, cartID)
+}
+
+// ListByCartForUpdate is ListByCart with the coupon rows locked, so concurrent checkouts
+// using the same coupon count each other's redemptions. Redemptions must be counted with
+// CountRedemptions after the lock is taken, not from TimesUsed.
+func (r *couponRepository) ListByCartForUpdate(ctx context.Context, cartID int64) ([]*models.Coupon, error) {
+	query := `
+        SELECT ` + couponColumns + `
+        FROM cart_coupons cc
+        JOIN coupons c ON c.id = cc.coupon_id
+        WHERE cc.cart_id = $1
+        ORDER BY cc.created_at, c.id
+        FOR UPDATE OF c`
+	return r.list(ctx, query, cartID)
+}
+
+// AddToCart applies a coupon to a cart. Applying it again is a no-op.
+func (r *couponRepository) AddToCart(ctx context.Context, cartID, couponID int64) error {
+	query := `INSERT INTO cart_coupons (cart_id, coupon_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, cartID, couponID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return apperrors.ErrNotFound
+		}
+		return fmt.Errorf("coupon repository: failed to apply coupon to cart: %w", err)
+	}
+	return nil
+}
+
+func (r *couponRepository) RemoveFromCart(ctx context.Context, cartID, couponID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM cart_coupons WHERE cart_id = $1 AND coupon_id = $2`, cartID, couponID)
+	if err != nil {
+		return fmt.Errorf("coupon repository: failed to remove coupon from cart: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("coupon repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *couponRepository) ClearCart(ctx context.Context, cartID int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM cart_coupons WHERE cart_id = $1`, cartID); err != nil {
+		return fmt.Errorf("coupon repository: failed to clear cart coupons: %w", err)
+	}
+	return nil
+}
+
+// MergeCarts carries the coupons of one cart over to another, e.g. on login.
+func (r *couponRepository) MergeCarts(ctx context.Context, fromCartID, toCartID int64) error {
+	query := `
+        INSERT INTO cart_coupons (cart_id, coupon_id, created_at)
+        SELECT $1, coupon_id, created_at FROM cart_coupons WHERE cart_id = $2
+        ON CONFLICT DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, toCartID, fromCartID); err != nil {
+		return fmt.Errorf("coupon repository: failed to merge cart coupons: %w", err)
+	}
+	return nil
+}
+
+// CountRedemptions returns how many times a coupon has been used on orders that were
+// not cancelled, in total and by the given user. byUser is zero when userID is nil.
+func (r *couponRepository) CountRedemptions(ctx context.Context, couponID int64, userID *int64) (int, int, error) {
+	query := `
+        SELECT COUNT(*), COUNT(*) FILTER (WHERE user_id = $2)
+        FROM coupon_redemptions
+        WHERE coupon_id = $1 AND released_at IS NULL`
+	var total, byUser int
+	if err := r.db.QueryRowContext(ctx, query, couponID, userID).Scan(&total, &byUser); err != nil {
+		return 0, 0, fmt.Errorf("coupon repository: failed to count redemptions: %w", err)
+	}
+	return total, byUser, nil
+}
+
+func (r *couponRepository) Redeem(ctx context.Context, redemption *models.CouponRedemption) error {
+	query := `
+        INSERT INTO coupon_redemptions (coupon_id, order_id, user_id, amount, currency)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+	err := r.db.QueryRowContext(ctx, query,
+		redemption.CouponID, redemption.OrderID, redemption.UserID, redemption.Amount, redemption.Currency,
+	).Scan(&redemption.ID, &redemption.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("coupon repository: failed to record redemption: %w", err)
+	}
+	return nil
+}
+
+// ReleaseByOrderID gives back the coupon uses of a cancelled order. The redemptions
+// are kept for the record but stop counting against the coupons' limits.
+func (r *couponRepository) ReleaseByOrderID(ctx context.Context, orderID int64) error {
+	query := `UPDATE coupon_redemptions SET released_at = NOW() WHERE order_id = $1 AND released_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, orderID); err != nil {
+		return fmt.Errorf("coupon repository: failed to release redemptions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// internal/coupon/requests.go
+package coupon
+
+import (
+	"regexp"
+
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+var codeRX = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateCreateCouponRequest validates a new coupon.
+func ValidateCreateCouponRequest(r dto.CreateCouponRequest, v *validator.Validator) {
+	ValidateCode(r.Code, v)
+	v.Check(len(r.Description) <= 255, "description", "must not exceed 255 characters")
+	discountType := models.CouponDiscountType(r.DiscountType)
+	v.Check(discountType == models.CouponDiscountPercentage || discountType == models.CouponDiscountFixed,
+		"discount_type", "must be percentage or fixed")
+	v.Check(r.Value > 0, "value", "must be greater than zero")
+	if discountType == models.CouponDiscountPercentage {
+		v.Check(r.Value <= 100, "value", "must not exceed 100 for a percentage coupon")
+	}
+	if r.MaxDiscount != nil {
+		v.Check(discountType == models.CouponDiscountPercentage, "max_discount", "only applies to percentage coupons")
+	}
+	validateShared(r.MaxDiscount, &r.MinCartValue, &r.ProductIDs, &r.CategoryIDs, r.UsageLimit, r.PerUserLimit, v)
+	if r.StartsAt != nil && r.EndsAt != nil {
+		v.Check(r.EndsAt.After(*r.StartsAt), "ends_at", "must be after starts_at")
+	}
+}
+
+// ValidateUpdateCouponRequest validates a partial update. Rules that depend on fields
+// left unchanged, like the validity window, are checked by the service.
+func ValidateUpdateCouponRequest(r dto.UpdateCouponRequest, v *validator.Validator) {
+	if r.Description != nil {
+		v.Check(len(*r.Description) <= 255, "description", "must not exceed 255 characters")
+	}
+	if r.Value != nil {
+		v.Check(*r.Value > 0, "value", "must be greater than zero")
+	}
+	validateShared(r.MaxDiscount, r.MinCartValue, r.ProductIDs, r.CategoryIDs, r.UsageLimit, r.PerUserLimit, v)
+}
+
+// ValidateCode checks a coupon code as typed by an admin or a customer.
+func ValidateCode(code string, v *validator.Validator) {
+	v.Check(validator.NotBlank(code), "code", "must be provided")
+	v.Check(len(code) <= 32, "code", "must not exceed 32 characters")
+	v.Check(validator.Matches(code, codeRX), "code", "may only contain letters, digits, '-' and '_'")
+}
+
+func validateShared(maxDiscount, minCartValue *float64, productIDs, categoryIDs *[]int64, usageLimit, perUserLimit *int, v *validator.Validator) {
+	if maxDiscount != nil {
+		v.Check(*maxDiscount > 0, "max_discount", "must be greater than zero")
+	}
+	if minCartValue != nil {
+		v.Check(*minCartValue >= 0, "min_cart_value", "must not be negative")
+	}
+	if productIDs != nil {
+		v.Check(allPositive(*productIDs), "product_ids", "must contain positive integers")
+	}
+	if categoryIDs != nil {
+		v.Check(allPositive(*categoryIDs), "category_ids", "must contain positive integers")
+	}
+	if usageLimit != nil {
+		v.Check(*usageLimit > 0, "usage_limit", "must be greater than zero")
+	}
+	if perUserLimit != nil {
+		v.Check(*perUserLimit > 0, "per_user_limit", "must be greater than zero")
+	}
+}
+
+func allPositive(ids []int64) bool {
+	for _, id := range ids {
+		if id <= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,210 @@
+// internal/coupon/service.go
+package coupon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type couponService struct {
+	couponRepo domain.CouponRepository
+	cartSvc    domain.CartService
+	logger     *slog.Logger
+}
+
+func NewCouponService(couponRepo domain.CouponRepository, cartSvc domain.CartService, logger *slog.Logger) domain.CouponService {
+	return &couponService{
+		couponRepo: couponRepo,
+		cartSvc:    cartSvc,
+		logger:     logger,
+	}
+}
+
+// NormalizeCode trims and upper-cases a coupon code, so codes are matched case-insensitively.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (s *couponService) ListCoupons(ctx context.Context) ([]*models.Coupon, error) {
+	coupons, err := s.couponRepo.List(ctx)
+	if err != nil {
+		s.logger.Error("failed to list coupons", "error", err)
+		return nil, fmt.Errorf("coupon service: could not list coupons: %w", err)
+	}
+	return coupons, nil
+}
+
+func (s *couponService) GetCoupon(ctx context.Context, id int64) (*models.Coupon, error) {
+	coupon, err := s.couponRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("coupon service: could not retrieve coupon: %w", err)
+	}
+	return coupon, nil
+}
+
+func (s *couponService) CreateCoupon(ctx context.Context, actorID int64, req *dto.CreateCouponRequest) (*models.Coupon, error) {
+	coupon := &models.Coupon{
+		Code:         NormalizeCode(req.Code),
+		Description:  strings.TrimSpace(req.Description),
+		DiscountType: models.CouponDiscountType(req.DiscountType),
+		Value:        req.Value,
+		MaxDiscount:  req.MaxDiscount,
+		MinCartValue: req.MinCartValue,
+		ProductIDs:   pq.Int64Array(req.ProductIDs),
+		CategoryIDs:  pq.Int64Array(req.CategoryIDs),
+		UsageLimit:   req.UsageLimit,
+		PerUserLimit: req.PerUserLimit,
+		Stackable:    req.Stackable,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+		Active:       req.Active == nil || *req.Active,
+		CreatedBy:    &actorID,
+	}
+
+	if err := s.couponRepo.Create(ctx, coupon); err != nil {
+		s.logger.Warn("failed to create coupon", "code", coupon.Code, "error", err)
+		return nil, fmt.Errorf("coupon service: could not create coupon: %w", err)
+	}
+
+	s.logger.Info("coupon created", "coupon_id", coupon.ID, "code", coupon.Code)
+	return s.couponRepo.GetByID(ctx, coupon.ID)
+}
+
+// UpdateCoupon changes a coupon. Orders already placed keep the discount they got;
+// carts see the change the next time they are priced.
+func (s *couponService) UpdateCoupon(ctx context.Context, id int64, req *dto.UpdateCouponRequest) (*models.Coupon, error) {
+	coupon, err := s.couponRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("coupon service: could not retrieve coupon: %w", err)
+	}
+
+	if req.Description != nil {
+		coupon.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Value != nil {
+		coupon.Value = *req.Value
+	}
+	if req.MaxDiscount != nil {
+		coupon.MaxDiscount = req.MaxDiscount
+	}
+	if req.MinCartValue != nil {
+		coupon.MinCartValue = *req.MinCartValue
+	}
+	if req.ProductIDs != nil {
+		coupon.ProductIDs = pq.Int64Array(*req.ProductIDs)
+	}
+	if req.CategoryIDs != nil {
+		coupon.CategoryIDs = pq.Int64Array(*req.CategoryIDs)
+	}
+	if req.UsageLimit != nil {
+		coupon.UsageLimit = req.UsageLimit
+	}
+	if req.PerUserLimit != nil {
+		coupon.PerUserLimit = req.PerUserLimit
+	}
+	if req.Stackable != nil {
+		coupon.Stackable = *req.Stackable
+	}
+	if req.StartsAt != nil {
+		coupon.StartsAt = req.StartsAt
+	}
+	if req.EndsAt != nil {
+		coupon.EndsAt = req.EndsAt
+	}
+	if req.Active != nil {
+		coupon.Active = *req.Active
+	}
+
+	// These rules span fields the request may not have touched.
+	switch {
+	case coupon.DiscountType == models.CouponDiscountPercentage && coupon.Value > 100:
+		return nil, fmt.Errorf("%w: value must not exceed 100 for a percentage coupon", apperrors.ErrInvalidCoupon)
+	case coupon.DiscountType != models.CouponDiscountPercentage && coupon.MaxDiscount != nil:
+		return nil, fmt.Errorf("%w: max_discount only applies to percentage coupons", apperrors.ErrInvalidCoupon)
+	case coupon.StartsAt != nil && coupon.EndsAt != nil && !coupon.EndsAt.After(*coupon.StartsAt):
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", apperrors.ErrInvalidCoupon)
+	}
+
+	if err := s.couponRepo.Update(ctx, coupon); err != nil {
+		s.logger.Warn("failed to update coupon", "coupon_id", id, "error", err)
+		return nil, fmt.Errorf("coupon service: could not update coupon: %w", err)
+	}
+
+	s.logger.Info("coupon updated", "coupon_id", id)
+	return s.couponRepo.GetByID(ctx, id)
+}
+
+// DeleteCoupon removes a coupon that was never redeemed. Redeemed coupons are kept for
+// the orders that used them and can be deactivated instead.
+func (s *couponService) DeleteCoupon(ctx context.Context, id int64) error {
+	if err := s.couponRepo.Delete(ctx, id); err != nil {
+		s.logger.Warn("failed to delete coupon", "coupon_id", id, "error", err)
+		return fmt.Errorf("coupon service: could not delete coupon: %w", err)
+	}
+	s.logger.Info("coupon deleted", "coupon_id", id)
+	return nil
+}
+
+// ApplyToCart adds a coupon to a cart after checking it would take something off the
+// cart as it is now, alongside the coupons already applied. Applying a coupon twice is
+// a no-op. Checkout checks every coupon again, so a coupon that stops being usable
+// afterwards is reported on the cart rather than silently dropped.
+func (s *couponService) ApplyToCart(ctx context.Context, cartID int64, userID *int64, code string) (*models.Cart, error) {
+	coupon, err := s.couponRepo.GetByCode(ctx, NormalizeCode(code))
+	if err != nil {
+		return nil, fmt.Errorf("coupon service: could not find coupon: %w", err)
+	}
+
+	cart, err := s.cartSvc.GetCartContents(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := s.couponRepo.ListByCart(ctx, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("coupon service: could not list cart coupons: %w", err)
+	}
+	for _, existing := range applied {
+		if existing.ID == coupon.ID {
+			return cart, nil
+		}
+	}
+
+	priced, err := Evaluate(ctx, s.couponRepo, append(applied, coupon), userID, CartLines(cart.Items), 1, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("coupon service: could not price coupon: %w", err)
+	}
+	if problem := priced[len(priced)-1].Err; problem != nil {
+		s.logger.Info("coupon rejected for cart", "cart_id", cartID, "code", coupon.Code, "reason", problem)
+		return nil, problem
+	}
+
+	if err := s.couponRepo.AddToCart(ctx, cartID, coupon.ID); err != nil {
+		return nil, fmt.Errorf("coupon service: could not apply coupon: %w", err)
+	}
+
+	s.logger.Info("coupon applied to cart", "cart_id", cartID, "code", coupon.Code)
+	return s.cartSvc.GetCartContents(ctx, cartID)
+}
+
+// RemoveFromCart takes a coupon off a cart.
+func (s *couponService) RemoveFromCart(ctx context.Context, cartID int64, code string) (*models.Cart, error) {
+	coupon, err := s.couponRepo.GetByCode(ctx, NormalizeCode(code))
+	if err != nil {
+		return nil, fmt.Errorf("coupon service: could not find coupon: %w", err)
+	}
+	if err := s.couponRepo.RemoveFromCart(ctx, cartID, coupon.ID); err != nil {
+		return nil, fmt.Errorf("coupon service: could not remove coupon: %w", err)
+	}
+
+	s.logger.Info("coupon removed from cart", "cart_id", cartID, "code", coupon.Code)
+	return s.cartSvc.GetCartContents(ctx, cartID)
+}
@@ -8,10 +8,18 @@ import (
 	"github.com/purushothdl/ecommerce-api/internal/address"
 	"github.com/purushothdl/ecommerce-api/internal/auth"
 	"github.com/purushothdl/ecommerce-api/internal/cart"
+	"github.com/purushothdl/ecommerce-api/internal/category"
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/inventory"
 	"github.com/purushothdl/ecommerce-api/internal/order"
+	"github.com/purushothdl/ecommerce-api/internal/pricing"
 	"github.com/purushothdl/ecommerce-api/internal/product"
+	"github.com/purushothdl/ecommerce-api/internal/recommendation"
+	"github.com/purushothdl/ecommerce-api/internal/reminder"
 	"github.com/purushothdl/ecommerce-api/internal/user"
+	"github.com/purushothdl/ecommerce-api/internal/warehouse"
+	"github.com/purushothdl/ecommerce-api/internal/wishlist"
 )
 
 // sqlStore provides all functions to execute SQL queries and transactions.
@@ -28,7 +36,17 @@ func NewStore(db *sql.DB) domain.Store {
 
 // ExecTx executes a function within a database transaction.
 func (s *sqlStore) ExecTx(ctx context.Context, fn func(q *domain.Queries) error) error {
-    tx, err := s.db.BeginTx(ctx, nil)
+    return s.execTx(ctx, nil, fn)
+}
+
+// ExecReadOnlyTx executes a function within a read-only transaction. Postgres rejects
+// any write or row lock attempted inside it.
+func (s *sqlStore) ExecReadOnlyTx(ctx context.Context, fn func(q *domain.Queries) error) error {
+    return s.execTx(ctx, &sql.TxOptions{ReadOnly: true}, fn)
+}
+
+func (s *sqlStore) execTx(ctx context.Context, opts *sql.TxOptions, fn func(q *domain.Queries) error) error {
+    tx, err := s.db.BeginTx(ctx, opts)
     if err != nil {
         return fmt.Errorf("store: failed to begin transaction: %w", err)
     }
@@ -36,12 +54,26 @@ func (s *sqlStore) ExecTx(ctx context.Context, fn func(q *domain.Queries) error)
 
     // Create a single Queries object, initializing all repositories with the transaction `tx`.
     q := &domain.Queries{
-        UserRepo:    user.NewUserRepository(tx),
-        CartRepo:    cart.NewCartRepository(tx),
-        ProductRepo: product.NewProductRepository(tx),
-        AuthRepo:    auth.NewAuthRepository(tx),
-        AddressRepo: address.NewAddressRepository(tx),
-        OrderRepo:   order.NewOrderRepository(tx),
+        UserRepo:           user.NewUserRepository(tx),
+        CartRepo:           cart.NewCartRepository(tx),
+        ProductRepo:        product.NewProductRepository(tx),
+        VariantRepo:        product.NewVariantRepository(tx),
+        AuthRepo:           auth.NewAuthRepository(tx),
+        AddressRepo:        address.NewAddressRepository(tx),
+        OrderRepo:          order.NewOrderRepository(tx),
+        ReservationRepo:    inventory.NewReservationRepository(tx),
+        MovementRepo:       inventory.NewMovementRepository(tx),
+        StockAlertRepo:     inventory.NewStockAlertRepository(tx),
+        StockSubRepo:       inventory.NewStockSubscriptionRepository(tx),
+        WarehouseRepo:      warehouse.NewWarehouseRepository(tx),
+        WarehouseStockRepo: warehouse.NewWarehouseStockRepository(tx),
+        PriceScheduleRepo:  pricing.NewPriceScheduleRepository(tx),
+        PriceHistoryRepo:   pricing.NewPriceHistoryRepository(tx),
+        RecommendationRepo: recommendation.NewRecommendationRepository(tx),
+        WishlistRepo:       wishlist.NewWishlistRepository(tx),
+        AttributeRepo:      category.NewAttributeRepository(tx),
+        CouponRepo:         coupon.NewCouponRepository(tx),
+        CartReminderRepo:   reminder.NewCartReminderRepository(tx),
     }
 
     // Execute the callback, passing our single Queries object.
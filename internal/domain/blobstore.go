@@ -0,0 +1,16 @@
+// internal/domain/blobstore.go
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore persists binary files such as product images. Keys are slash-separated
+// paths (e.g. "products/12/ab34.jpg"); each backend decides where they physically live.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public address clients should use to fetch the blob.
+	URL(key string) string
+}
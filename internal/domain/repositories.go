@@ -17,6 +17,7 @@ type UserRepository interface {
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id int64) error
 	GetAll(ctx context.Context) ([]*models.User, error) 
+	GetByRole(ctx context.Context, role models.Role) ([]*models.User, error)
 }
 
 // AuthRepository handles authentication data operations
@@ -35,16 +36,61 @@ type AuthRepository interface {
 type ProductRepository interface {
 	Create(ctx context.Context, product *models.Product) error
 	GetAll(ctx context.Context, filters ProductFilters) ([]*models.Product, error)
+	GetFacets(ctx context.Context, filters ProductFilters) (*ProductFacets, error)
 	GetByID(ctx context.Context, id int64) (*models.Product, error)
+	GetBySKU(ctx context.Context, sku string) (*models.Product, error)
 	GetByIDForUpdate(ctx context.Context, id int64) (*models.Product, error) 
-	UpdateStock(ctx context.Context, productID int64, quantityChange int) error
+	ListAll(ctx context.Context) ([]*models.Product, error)
+	Update(ctx context.Context, product *models.Product) error
+	SetStatus(ctx context.Context, id int64, status models.ProductStatus) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// VariantRepository handles product option types and variant data operations
+type VariantRepository interface {
+	ListOptions(ctx context.Context, productID int64) ([]models.ProductOption, error)
+	ReplaceOptions(ctx context.Context, productID int64, options []models.ProductOption) error
+	ListByProductID(ctx context.Context, productID int64) ([]models.ProductVariant, error)
+	GetByID(ctx context.Context, id int64) (*models.ProductVariant, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*models.ProductVariant, error)
+	Create(ctx context.Context, variant *models.ProductVariant) error
+	Update(ctx context.Context, variant *models.ProductVariant) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// ReviewRepository handles product review data operations
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models.Review) error
+	GetByID(ctx context.Context, id int64) (*models.Review, error)
+	List(ctx context.Context, filters ReviewFilters) ([]*models.Review, int, error)
+	HasDeliveredPurchase(ctx context.Context, userID, productID int64) (bool, error)
+	UpdateStatus(ctx context.Context, id int64, status models.ReviewStatus, note string) error
+	Delete(ctx context.Context, id int64) error
 }
 
 // CategoryRepository handles category data operations
 type CategoryRepository interface {
+	GetByID(ctx context.Context, id int64) (*models.Category, error)
 	GetByName(ctx context.Context, name string) (*models.Category, error)
 	GetAll(ctx context.Context) ([]*models.Category, error)
 	Create(ctx context.Context, category *models.Category) error
+	Update(ctx context.Context, category *models.Category) error
+	Move(ctx context.Context, id int64, parentID *int64, position int) error
+	CountProducts(ctx context.Context, id int64) (int, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// AttributeRepository handles category attribute definitions and the values products hold for them
+type AttributeRepository interface {
+	ListDefinitions(ctx context.Context, categoryID int64) ([]models.CategoryAttribute, error)
+	GetDefinition(ctx context.Context, id int64) (*models.CategoryAttribute, error)
+	CreateDefinition(ctx context.Context, attribute *models.CategoryAttribute) error
+	UpdateDefinition(ctx context.Context, attribute *models.CategoryAttribute) error
+	DeleteDefinition(ctx context.Context, id int64) error
+	HasValuesOutside(ctx context.Context, attributeID int64, allowed []string) (bool, error)
+	ListValues(ctx context.Context, productIDs []int64) (map[int64][]models.ProductAttribute, error)
+	ReplaceValues(ctx context.Context, productID int64, values []models.ProductAttribute) error
+	DeleteValuesOutsideCategory(ctx context.Context, productID, categoryID int64) error
 }
 
 // CartRepository handles shopping cart data operations
@@ -57,15 +103,45 @@ type CartRepository interface {
 	Delete(ctx context.Context, cartID int64) error
 	ClearCart(ctx context.Context, cartID int64) error
 
+	// Named carts, which users keep next to their default cart
+	ListByUserID(ctx context.Context, userID int64) ([]models.Cart, error)
+	CreateNamed(ctx context.Context, userID int64, name string) (*models.Cart, error)
+	Rename(ctx context.Context, cartID int64, name string) error
+
     // CartItem methods
-    AddItem(ctx context.Context, cartID int64, productID int64, quantity int) error
-    UpdateItemQuantity(ctx context.Context, cartID int64, productID int64, quantity int) error
-    RemoveItem(ctx context.Context, cartID int64, productID int64) error
+    // A nil variantID addresses the product itself, for products sold without variants.
+    AddItem(ctx context.Context, cartID int64, productID int64, variantID *int64, quantity int) error
+    UpdateItemQuantity(ctx context.Context, cartID int64, productID int64, variantID *int64, quantity int) error
+    RemoveItem(ctx context.Context, cartID int64, productID int64, variantID *int64) error
 	GetItemsByCartID(ctx context.Context, cartID int64) ([]models.CartItem, error)
+	GetSavedItemsByCartID(ctx context.Context, cartID int64) ([]models.CartItem, error)
+	SetSavedForLater(ctx context.Context, cartID int64, productID int64, variantID *int64, saved bool) error
+	AcceptCurrentPrices(ctx context.Context, cartID int64) error
 	CleanupOldAnonymousCarts(ctx context.Context, olderThan time.Time) (int64, error)
 
 }
 
+// CartReminderRepository handles abandoned cart reminders and who has opted out of them
+type CartReminderRepository interface {
+	ClaimNextAbandoned(ctx context.Context, idleSince time.Time, maxReminders int) (*models.AbandonedCart, error)
+	Create(ctx context.Context, reminder *models.CartReminder) error
+	MarkConverted(ctx context.Context, cartID, orderID int64) error
+	DisableForUser(ctx context.Context, userID int64) error
+	Stats(ctx context.Context, since time.Time) (*models.CartReminderStats, error)
+}
+
+// WishlistRepository handles saved-for-later product data operations
+type WishlistRepository interface {
+	GetByUserID(ctx context.Context, userID int64) (*models.Wishlist, error)
+	GetByID(ctx context.Context, id int64) (*models.Wishlist, error)
+	Create(ctx context.Context, userID *int64) (*models.Wishlist, error)
+	Delete(ctx context.Context, id int64) error
+	AddItem(ctx context.Context, wishlistID, productID int64, variantID *int64) error
+	RemoveItem(ctx context.Context, wishlistID, itemID int64) error
+	GetItemsByWishlistID(ctx context.Context, wishlistID int64) ([]models.WishlistItem, error)
+	MergeWishlists(ctx context.Context, fromWishlistID, toWishlistID int64) error
+}
+
 // AddressRepository handles user address data operations
 type AddressRepository interface {
     Create(ctx context.Context, addr *models.UserAddress) error
@@ -91,6 +167,94 @@ type OrderRepository interface {
 	UpdateStatus(ctx context.Context,id int64,status models.OrderStatus,paymentStatus models.PaymentStatus,trackingNumber *string, estimatedDeliveryDate *time.Time) error
 }
 
+// ReservationRepository handles inventory reservation data operations
+type ReservationRepository interface {
+	Create(ctx context.Context, reservation *models.InventoryReservation) error
+	ListByOrderIDForUpdate(ctx context.Context, orderID int64) ([]models.InventoryReservation, error)
+	ReservedStock(ctx context.Context, productID int64, variantID *int64) (int, error)
+	CommitByOrderID(ctx context.Context, orderID int64) error
+	ReleaseByOrderID(ctx context.Context, orderID int64) (int64, error)
+	ReleaseExpired(ctx context.Context) (int64, error)
+}
+
+// MovementRepository handles the inventory movement ledger
+type MovementRepository interface {
+	Record(ctx context.Context, movement *models.InventoryMovement) error
+	List(ctx context.Context, filters MovementFilters) ([]*models.InventoryMovement, int, error)
+}
+
+// StockAlertRepository handles the outbox of low-stock and back-in-stock alerts
+type StockAlertRepository interface {
+	ClaimNext(ctx context.Context) (*models.StockAlert, error)
+	MarkProcessed(ctx context.Context, id int64) error
+}
+
+// StockSubscriptionRepository handles back-in-stock subscriptions
+type StockSubscriptionRepository interface {
+	Upsert(ctx context.Context, productID, userID int64) (*models.StockSubscription, error)
+	Delete(ctx context.Context, productID, userID int64) error
+	ListPending(ctx context.Context, productID int64) ([]models.StockSubscriber, error)
+	MarkNotified(ctx context.Context, subscriptionIDs []int64) error
+}
+
+// WarehouseRepository handles stock location data operations
+type WarehouseRepository interface {
+	Create(ctx context.Context, warehouse *models.Warehouse) error
+	GetByID(ctx context.Context, id int64) (*models.Warehouse, error)
+	GetDefault(ctx context.Context) (*models.Warehouse, error)
+	List(ctx context.Context, activeOnly bool) ([]*models.Warehouse, error)
+	Update(ctx context.Context, warehouse *models.Warehouse) error
+	ClearDefault(ctx context.Context, exceptID int64) error
+}
+
+// WarehouseStockRepository handles per-warehouse stock levels
+type WarehouseStockRepository interface {
+	Adjust(ctx context.Context, warehouseID, productID int64, variantID *int64, delta int) error
+	ListByProduct(ctx context.Context, productID int64) ([]models.WarehouseStock, error)
+	ListAvailable(ctx context.Context, productID int64, variantID *int64) ([]models.WarehouseStock, error)
+}
+
+// PriceScheduleRepository handles scheduled sale prices
+type PriceScheduleRepository interface {
+	Create(ctx context.Context, schedule *models.PriceSchedule) error
+	GetByID(ctx context.Context, id int64) (*models.PriceSchedule, error)
+	ListByProduct(ctx context.Context, productID int64) ([]*models.PriceSchedule, error)
+	HasOverlap(ctx context.Context, productID int64, variantID *int64, startsAt, endsAt time.Time) (bool, error)
+	Cancel(ctx context.Context, id int64) error
+	GetActive(ctx context.Context, productID int64, variantID *int64, at time.Time) (*models.PriceSchedule, error)
+}
+
+// PriceHistoryRepository handles the record of regular price changes
+type PriceHistoryRepository interface {
+	Record(ctx context.Context, change *models.PriceChange) error
+	ListByProduct(ctx context.Context, productID int64) ([]*models.PriceChange, error)
+}
+
+// CouponRepository handles coupons, the coupons applied to carts and their redemptions
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *models.Coupon) error
+	GetByID(ctx context.Context, id int64) (*models.Coupon, error)
+	GetByCode(ctx context.Context, code string) (*models.Coupon, error)
+	List(ctx context.Context) ([]*models.Coupon, error)
+	Update(ctx context.Context, coupon *models.Coupon) error
+	Delete(ctx context.Context, id int64) error
+	ListByCart(ctx context.Context, cartID int64) ([]*models.Coupon, error)
+	ListByCartForUpdate(ctx context.Context, cartID int64) ([]*models.Coupon, error)
+	AddToCart(ctx context.Context, cartID, couponID int64) error
+	RemoveFromCart(ctx context.Context, cartID, couponID int64) error
+	ClearCart(ctx context.Context, cartID int64) error
+	MergeCarts(ctx context.Context, fromCartID, toCartID int64) error
+	CountRedemptions(ctx context.Context, couponID int64, userID *int64) (total int, byUser int, err error)
+	Redeem(ctx context.Context, redemption *models.CouponRedemption) error
+	ReleaseByOrderID(ctx context.Context, orderID int64) error
+}
+
+// RecommendationRepository handles precomputed related products
+type RecommendationRepository interface {
+	Replace(ctx context.Context, perProduct int) (int, error)
+	ListByProduct(ctx context.Context, productID int64, limit int) ([]*models.Recommendation, error)
+}
+
 type DBTX interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
@@ -99,11 +263,25 @@ type DBTX interface {
 
 // Queries is a container for all your repository types. This is the key change.
 type Queries struct {
-	UserRepo     UserRepository
-	CartRepo     CartRepository
-	ProductRepo  ProductRepository
-	AuthRepo     AuthRepository
-	AddressRepo  AddressRepository
-	OrderRepo    OrderRepository
+	UserRepo           UserRepository
+	CartRepo           CartRepository
+	ProductRepo        ProductRepository
+	VariantRepo        VariantRepository
+	AuthRepo           AuthRepository
+	AddressRepo        AddressRepository
+	OrderRepo          OrderRepository
+	ReservationRepo    ReservationRepository
+	MovementRepo       MovementRepository
+	StockAlertRepo     StockAlertRepository
+	StockSubRepo       StockSubscriptionRepository
+	WarehouseRepo      WarehouseRepository
+	WarehouseStockRepo WarehouseStockRepository
+	PriceScheduleRepo  PriceScheduleRepository
+	PriceHistoryRepo   PriceHistoryRepository
+	RecommendationRepo RecommendationRepository
+	WishlistRepo       WishlistRepository
+	AttributeRepo      AttributeRepository
+	CouponRepo         CouponRepository
+	CartReminderRepo   CartReminderRepository
 
 }
\ No newline at end of file
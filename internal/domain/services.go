@@ -13,7 +13,7 @@ import (
 // UserService handles user business logic
 type UserService interface {
 	Register(ctx context.Context, name, email, password string) (*models.User, error)
-	RegisterWithCartMerge(ctx context.Context, store Store, name, email, password string, anonymousCartID *int64) (*models.User, *models.RefreshToken, error)
+	RegisterWithCartMerge(ctx context.Context, store Store, name, email, password string, anonymousCartID, anonymousWishlistID *int64) (*models.User, *models.RefreshToken, error)
 	GetProfile(ctx context.Context, userID int64) (*models.User, error)
 	UpdateProfile(ctx context.Context, userID int64, name, email *string) (*models.User, error) 
 	ChangePassword(ctx context.Context, userID int64, currentPassword, newPassword string) error
@@ -22,7 +22,7 @@ type UserService interface {
 
 // AuthService handles authentication business logic
 type AuthService interface {
-	LoginWithCartMerge(ctx context.Context, store Store, email, password string, anonymousCartID *int64) (*models.User, *models.RefreshToken, error)
+	LoginWithCartMerge(ctx context.Context, store Store, email, password string, anonymousCartID, anonymousWishlistID *int64) (*models.User, *models.RefreshToken, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*models.User, *models.RefreshToken, error)
 	Logout(ctx context.Context, refreshToken string) error
 	GetUserSessions(ctx context.Context, userID int64) ([]*models.RefreshToken, error)
@@ -45,26 +45,128 @@ type AdminService interface {
 // ProductService handles product business logic
 type ProductService interface {
 	ListProducts(ctx context.Context, filters ProductFilters) ([]*models.Product, error)
+	GetProductFacets(ctx context.Context, filters ProductFilters) (*ProductFacets, error)
 	GetProduct(ctx context.Context, id int64) (*models.Product, error)
+	CreateProduct(ctx context.Context, actorID int64, req *dto.CreateProductRequest) (*models.Product, error)
+	UpdateProduct(ctx context.Context, actorID int64, id int64, req *dto.UpdateProductRequest) (*models.Product, error)
+	SetProductStatus(ctx context.Context, id int64, status models.ProductStatus) (*models.Product, error)
+	ArchiveProduct(ctx context.Context, id int64) (*models.Product, error)
+	DeleteProduct(ctx context.Context, id int64) (archived bool, err error)
+	SetProductAttributes(ctx context.Context, productID int64, req *dto.SetProductAttributesRequest) ([]models.ProductAttribute, error)
+	SetProductOptions(ctx context.Context, productID int64, req *dto.SetProductOptionsRequest) ([]models.ProductOption, error)
+	CreateVariant(ctx context.Context, actorID int64, productID int64, req *dto.CreateVariantRequest) (*models.ProductVariant, error)
+	UpdateVariant(ctx context.Context, actorID int64, productID, variantID int64, req *dto.UpdateVariantRequest) (*models.ProductVariant, error)
+	DeleteVariant(ctx context.Context, productID, variantID int64) error
+	UploadProductImage(ctx context.Context, productID int64, data []byte, makePrimary bool) (*models.Product, error)
+}
+
+// CatalogService handles bulk catalog import and export
+type CatalogService interface {
+	ImportProducts(ctx context.Context, actorID *int64, rows []dto.CatalogImportRow, dryRun bool) (*dto.CatalogImportReport, error)
+	ExportProducts(ctx context.Context) ([]dto.CatalogRow, error)
 }
 
 // CategoryService handles category business logic
 type CategoryService interface {
 	ListCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategoryTree(ctx context.Context) ([]*models.Category, error)
 	GetOrCreate(ctx context.Context, name string) (*models.Category, error)
+	CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*models.Category, error)
+	UpdateCategory(ctx context.Context, id int64, req *dto.UpdateCategoryRequest) (*models.Category, error)
+	MoveCategory(ctx context.Context, id int64, req *dto.MoveCategoryRequest) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id int64) error
+	ListAttributes(ctx context.Context, categoryID int64) ([]models.CategoryAttribute, error)
+	CreateAttribute(ctx context.Context, categoryID int64, req *dto.CreateCategoryAttributeRequest) (*models.CategoryAttribute, error)
+	UpdateAttribute(ctx context.Context, categoryID, attributeID int64, req *dto.UpdateCategoryAttributeRequest) (*models.CategoryAttribute, error)
+	DeleteAttribute(ctx context.Context, categoryID, attributeID int64) error
+}
+
+// ReviewService handles product review business logic
+type ReviewService interface {
+	CreateReview(ctx context.Context, userID, productID int64, req *dto.CreateReviewRequest) (*models.Review, error)
+	ListReviews(ctx context.Context, filters ReviewFilters) ([]*models.Review, int, error)
+	ModerateReview(ctx context.Context, id int64, req *dto.ModerateReviewRequest) (*models.Review, error)
+	DeleteReview(ctx context.Context, id int64) error
+}
+
+// InventoryService handles the stock ledger and admin stock corrections
+type InventoryService interface {
+	ListMovements(ctx context.Context, filters MovementFilters) ([]*models.InventoryMovement, int, error)
+	AdjustStock(ctx context.Context, actorID, productID int64, req *dto.StockAdjustmentRequest) (*models.InventoryMovement, error)
+	Subscribe(ctx context.Context, userID, productID int64) (*models.StockSubscription, error)
+	Unsubscribe(ctx context.Context, userID, productID int64) error
+	ProcessStockAlerts(ctx context.Context) (int, error)
+}
+
+// WarehouseService handles stock locations and the stock held at each
+type WarehouseService interface {
+	ListWarehouses(ctx context.Context) ([]*models.Warehouse, error)
+	CreateWarehouse(ctx context.Context, req *dto.CreateWarehouseRequest) (*models.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, id int64, req *dto.UpdateWarehouseRequest) (*models.Warehouse, error)
+	ListProductStock(ctx context.Context, productID int64) ([]models.WarehouseStock, error)
+}
+
+// PricingService handles scheduled sales and the regular price history
+type PricingService interface {
+	ListSchedules(ctx context.Context, productID int64) ([]*models.PriceSchedule, error)
+	CreateSchedule(ctx context.Context, actorID, productID int64, req *dto.CreatePriceScheduleRequest) (*models.PriceSchedule, error)
+	CancelSchedule(ctx context.Context, productID, scheduleID int64) (*models.PriceSchedule, error)
+	ListPriceHistory(ctx context.Context, productID int64) ([]*models.PriceChange, error)
+}
+
+// CouponService handles promotion codes and applying them to carts
+type CouponService interface {
+	ListCoupons(ctx context.Context) ([]*models.Coupon, error)
+	GetCoupon(ctx context.Context, id int64) (*models.Coupon, error)
+	CreateCoupon(ctx context.Context, actorID int64, req *dto.CreateCouponRequest) (*models.Coupon, error)
+	UpdateCoupon(ctx context.Context, id int64, req *dto.UpdateCouponRequest) (*models.Coupon, error)
+	DeleteCoupon(ctx context.Context, id int64) error
+	ApplyToCart(ctx context.Context, cartID int64, userID *int64, code string) (*models.Cart, error)
+	RemoveFromCart(ctx context.Context, cartID int64, code string) (*models.Cart, error)
+}
+
+// RecommendationService handles related-product suggestions
+type RecommendationService interface {
+	GetRecommendations(ctx context.Context, productID int64, limit int) ([]*models.Recommendation, error)
+	RefreshRecommendations(ctx context.Context) (int, error)
 }
 
 // CartService handles shopping cart operations
 type CartService interface {
     GetOrCreateCart(ctx context.Context, userID *int64, anonymousCartID *int64) (*models.Cart, error)
-    AddProductToCart(ctx context.Context, cartID int64, productID int64, quantity int) (*models.Cart, error)
-    UpdateProductInCart(ctx context.Context, cartID int64, productID int64, quantity int) (*models.Cart, error)
-    RemoveProductFromCart(ctx context.Context, cartID int64, productID int64) (*models.Cart, error)
+    GetUserCart(ctx context.Context, userID int64, cartID int64) (*models.Cart, error)
+    ListCarts(ctx context.Context, userID int64) ([]models.Cart, error)
+    CreateNamedCart(ctx context.Context, userID int64, name string) (*models.Cart, error)
+    RenameCart(ctx context.Context, userID int64, cartID int64, name string) (*models.Cart, error)
+    DeleteCart(ctx context.Context, userID int64, cartID int64) error
+    AddProductToCart(ctx context.Context, cartID int64, productID int64, variantID *int64, quantity int) (*models.Cart, error)
+    UpdateProductInCart(ctx context.Context, cartID int64, productID int64, variantID *int64, quantity int) (*models.Cart, error)
+    RemoveProductFromCart(ctx context.Context, cartID int64, productID int64, variantID *int64) (*models.Cart, error)
     GetCartContents(ctx context.Context, cartID int64) (*models.Cart, error)
+	FixCart(ctx context.Context, cartID int64) (*models.Cart, []models.CartFix, error)
+	SaveForLater(ctx context.Context, cartID int64, productID int64, variantID *int64) (*models.Cart, error)
+	MoveToCart(ctx context.Context, cartID int64, productID int64, variantID *int64) (*models.Cart, error)
 	HandleLoginWithTransaction(ctx context.Context, q *Queries, userID int64, anonymousCartID int64) error
 	CleanupOldAnonymousCarts(ctx context.Context, olderThan time.Duration) (int64, error)
 }
 
+// CartReminderService handles abandoned cart reminder emails
+type CartReminderService interface {
+	SendAbandonedCartReminders(ctx context.Context, idleFor time.Duration, maxReminders int) (int, error)
+	Unsubscribe(ctx context.Context, token string) error
+	GetStats(ctx context.Context, since time.Time) (*models.CartReminderStats, error)
+}
+
+// WishlistService handles saved-for-later products
+type WishlistService interface {
+	GetOrCreateWishlist(ctx context.Context, userID *int64, anonymousWishlistID *int64) (*models.Wishlist, error)
+	GetWishlistContents(ctx context.Context, wishlistID int64) (*models.Wishlist, error)
+	AddItem(ctx context.Context, wishlistID, productID int64, variantID *int64) (*models.Wishlist, error)
+	RemoveItem(ctx context.Context, wishlistID, itemID int64) (*models.Wishlist, error)
+	MoveItemToCart(ctx context.Context, wishlistID, itemID, cartID int64, quantity int) (*models.Cart, error)
+	HandleLoginWithTransaction(ctx context.Context, q *Queries, userID int64, anonymousWishlistID int64) error
+}
+
 // AddressService handles user address operations
 type AddressService interface {
     Create(ctx context.Context, userID int64, req *dto.CreateAddressRequest) (*models.UserAddress, error)
@@ -78,16 +180,18 @@ type AddressService interface {
 // OrderService handles order business logic
 type OrderService interface {
 	CreateOrder(ctx context.Context, userID int64, cartID int64, req *dto.CreateOrderRequest) (*dto.CreateOrderResponse, error)
+	QuoteCheckout(ctx context.Context, userID int64, cartID int64, req *dto.CheckoutQuoteRequest) (*dto.CheckoutQuoteResponse, error)
 	HandlePaymentSucceeded(ctx context.Context, paymentIntentID string) error
 	ListUserOrders(ctx context.Context, userID int64) ([]*dto.OrderResponse, error) 
 	GetUserOrder(ctx context.Context, userID, orderID int64) (*dto.OrderWithItemsResponse, error) 
 	CleanupPendingOrders(ctx context.Context, olderThan time.Duration) (int, error)
+	ReleaseExpiredReservations(ctx context.Context) (int64, error)
 	CancelOrder(ctx context.Context, userID, orderID int64) error 
 	UpdateOrderStatus(ctx context.Context, orderID int64, status models.OrderStatus, paymentStatus *models.PaymentStatus, trackingNumber *string, estimatedDeliveryDate *time.Time,) error
 }
 
 // PaymentService defines the interface for a payment provider like Stripe.
 type PaymentService interface {
-	CreatePaymentIntent(ctx context.Context, amount float64) (*dto.PaymentIntent, error)
+	CreatePaymentIntent(ctx context.Context, amount float64, currency string) (*dto.PaymentIntent, error)
 	RefundPaymentIntent(ctx context.Context, paymentIntentID string) error
 }
\ No newline at end of file
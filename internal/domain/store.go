@@ -4,4 +4,7 @@ import "context"
 
 type Store interface {
     ExecTx(ctx context.Context, fn func(q *Queries) error) error
+    // ExecReadOnlyTx runs fn in a read-only transaction, for reads that must see one
+    // consistent snapshot but never write or lock rows.
+    ExecReadOnlyTx(ctx context.Context, fn func(q *Queries) error) error
 }
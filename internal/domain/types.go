@@ -1,11 +1,73 @@
 package domain
 
+import "github.com/purushothdl/ecommerce-api/internal/models"
+
+// ProductSort names an ordering for product listings
+type ProductSort string
+
+const (
+	ProductSortDefault   ProductSort = ""
+	ProductSortRelevance ProductSort = "relevance"
+	ProductSortPriceAsc  ProductSort = "price_asc"
+	ProductSortPriceDesc ProductSort = "price_desc"
+	ProductSortNewest    ProductSort = "newest"
+	ProductSortNameAsc   ProductSort = "name_asc"
+	ProductSortNameDesc  ProductSort = "name_desc"
+	ProductSortRating    ProductSort = "rating"
+)
+
+// IsValid reports whether the sort option is supported
+func (s ProductSort) IsValid() bool {
+	switch s {
+	case ProductSortDefault, ProductSortRelevance, ProductSortPriceAsc, ProductSortPriceDesc,
+		ProductSortNewest, ProductSortNameAsc, ProductSortNameDesc, ProductSortRating:
+		return true
+	}
+	return false
+}
 
 // ProductFilters defines options for filtering products
 type ProductFilters struct {
 	Category    string
-	SearchQuery string 
+	SearchQuery string
+	Brands      []string
+	MinPrice    *float64
+	MaxPrice    *float64
+	InStockOnly bool
+	Attributes  map[string][]string // Attribute code to accepted values, from ?attr.<code>=
+	Sort        ProductSort
 	Page        int
 	PageSize    int
 }
 
+// FacetCount is the number of matching products for a single facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// ProductFacets summarises a filtered product listing for the storefront
+type ProductFacets struct {
+	Brands     []FacetCount `json:"brands"`
+	Categories []FacetCount `json:"categories"`
+	MinPrice   float64      `json:"min_price"`
+	MaxPrice   float64      `json:"max_price"`
+	Total      int          `json:"total"`
+}
+
+// ReviewFilters narrows a review listing. A zero ProductID or Status means "any".
+type ReviewFilters struct {
+	ProductID int64
+	Status    models.ReviewStatus
+	Page      int
+	PageSize  int
+}
+
+// MovementFilters narrows a product's stock history. A nil VariantID means every
+// movement of the product, including those of its variants.
+type MovementFilters struct {
+	ProductID int64
+	VariantID *int64
+	Page      int
+	PageSize  int
+}
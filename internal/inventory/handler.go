@@ -0,0 +1,162 @@
+// internal/inventory/handler.go
+package inventory
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	inventorySvc domain.InventoryService
+	logger       *slog.Logger
+}
+
+func NewHandler(inventorySvc domain.InventoryService, logger *slog.Logger) *Handler {
+	return &Handler{inventorySvc: inventorySvc, logger: logger}
+}
+
+// HandleListMovements returns a product's stock history, optionally for one ?variant_id.
+func (h *Handler) HandleListMovements(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	v := validator.New()
+	filters := ParseMovementFilters(r.URL.Query(), v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+	filters.ProductID = productID
+
+	movements, total, err := h.inventorySvc.ListMovements(r.Context(), filters)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "product not found")
+			return
+		}
+		h.logger.Error("failed to list inventory movements", "product_id", productID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not retrieve stock history")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, NewMovementListResponse(movements, total, filters))
+}
+
+// HandleAdjustStock posts a manual stock correction for a product or one of its variants.
+func (h *Handler) HandleAdjustStock(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.StockAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateStockAdjustmentRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	movement, err := h.inventorySvc.AdjustStock(r.Context(), adminID, productID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "product, variant or warehouse not found")
+		case errors.Is(err, apperrors.ErrVariantRequired):
+			response.JSON(w, http.StatusUnprocessableEntity, map[string]string{"variant_id": "must be provided for a product with variants"})
+		case errors.Is(err, apperrors.ErrInsufficientStock):
+			response.Error(w, http.StatusConflict, "adjustment would take the warehouse's stock below zero")
+		default:
+			h.logger.Error("failed to adjust stock", "product_id", productID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not adjust stock")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, movement)
+}
+
+// HandleSubscribe asks for an email when a sold-out product is back in stock. It is
+// idempotent; subscribing again after a notification re-arms the subscription.
+func (h *Handler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	subscription, err := h.inventorySvc.Subscribe(r.Context(), userID, productID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, apperrors.ErrProductInStock):
+			response.Error(w, http.StatusConflict, "product is in stock")
+		default:
+			h.logger.Error("failed to subscribe to product", "user_id", userID, "product_id", productID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not subscribe to product")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, subscription)
+}
+
+// HandleUnsubscribe cancels a back-in-stock subscription.
+func (h *Handler) HandleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	if err := h.inventorySvc.Unsubscribe(r.Context(), userID, productID); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "subscription not found")
+			return
+		}
+		h.logger.Error("failed to unsubscribe from product", "user_id", userID, "product_id", productID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not unsubscribe from product")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "unsubscribed from back-in-stock alerts"})
+}
@@ -0,0 +1,81 @@
+// internal/inventory/movement_repository.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+type movementRepository struct {
+	db domain.DBTX
+}
+
+func NewMovementRepository(db domain.DBTX) domain.MovementRepository {
+	return &movementRepository{db: db}
+}
+
+// Record writes a movement after its stock change has been applied. stock_after is read
+// from the variant, or the product when there is no variant, within the same statement,
+// so it reflects the change as long as both run in one transaction. It is the total
+// across warehouses, not the stock left at the movement's warehouse.
+func (r *movementRepository) Record(ctx context.Context, m *models.InventoryMovement) error {
+	query := `
+        INSERT INTO inventory_movements (product_id, variant_id, warehouse_id, delta, stock_after, reason, reference_id, actor_id, note)
+        VALUES ($1, $2, $8, $3,
+            CASE WHEN $2::bigint IS NULL
+                THEN (SELECT stock_quantity FROM products WHERE id = $1)
+                ELSE (SELECT stock_quantity FROM product_variants WHERE id = $2)
+            END,
+            $4, $5, $6, $7)
+        RETURNING id, stock_after, created_at`
+	err := r.db.QueryRowContext(ctx, query,
+		m.ProductID, m.VariantID, m.Delta, m.Reason, m.ReferenceID, m.ActorID, m.Note, m.WarehouseID,
+	).Scan(&m.ID, &m.StockAfter, &m.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("movement repository: failed to record movement: %w", err)
+	}
+	return nil
+}
+
+func (r *movementRepository) List(ctx context.Context, filters domain.MovementFilters) ([]*models.InventoryMovement, int, error) {
+	conditions := []string{"product_id = $1"}
+	args := []any{filters.ProductID}
+	if filters.VariantID != nil {
+		args = append(args, *filters.VariantID)
+		conditions = append(conditions, fmt.Sprintf("variant_id = $%d", len(args)))
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM inventory_movements`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("movement repository: failed to count movements: %w", err)
+	}
+
+	args = append(args, filters.PageSize, (filters.Page-1)*filters.PageSize)
+	query := `
+        SELECT id, product_id, variant_id, warehouse_id, delta, stock_after, reason, reference_id, actor_id, note, created_at
+        FROM inventory_movements` + where + fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("movement repository: failed to list movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []*models.InventoryMovement{}
+	for rows.Next() {
+		var m models.InventoryMovement
+		if err := rows.Scan(
+			&m.ID, &m.ProductID, &m.VariantID, &m.WarehouseID, &m.Delta, &m.StockAfter, &m.Reason,
+			&m.ReferenceID, &m.ActorID, &m.Note, &m.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("movement repository: failed to scan movement: %w", err)
+		}
+		movements = append(movements, &m)
+	}
+	return movements, total, rows.Err()
+}
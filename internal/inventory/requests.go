@@ -0,0 +1,53 @@
+// internal/inventory/requests.go
+package inventory
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+// ValidateStockAdjustmentRequest validates an admin stock adjustment. Order-driven
+// reasons are reserved for the order flow and cannot be posted by hand.
+func ValidateStockAdjustmentRequest(r dto.StockAdjustmentRequest, v *validator.Validator) {
+	v.Check(r.Delta != 0, "delta", "must not be zero")
+	v.Check(r.Reason == models.MovementReasonManualAdjustment || r.Reason == models.MovementReasonReturn, "reason", "must be manual_adjustment or return")
+	v.Check(validator.NotBlank(r.Note), "note", "must be provided")
+	v.Check(len(r.Note) <= 500, "note", "must not exceed 500 characters")
+	if r.VariantID != nil {
+		v.Check(*r.VariantID > 0, "variant_id", "must be a positive integer")
+	}
+	if r.WarehouseID != nil {
+		v.Check(*r.WarehouseID > 0, "warehouse_id", "must be a positive integer")
+	}
+}
+
+// ParseMovementFilters reads pagination (?page, ?limit) and ?variant_id from the query string.
+func ParseMovementFilters(query url.Values, v *validator.Validator) domain.MovementFilters {
+	filters := domain.MovementFilters{
+		Page:     1,
+		PageSize: 20,
+	}
+
+	if variantIDStr := query.Get("variant_id"); variantIDStr != "" {
+		variantID, err := strconv.ParseInt(variantIDStr, 10, 64)
+		v.Check(err == nil && variantID > 0, "variant_id", "must be a positive integer")
+		filters.VariantID = &variantID
+	}
+	if pageStr := query.Get("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filters.Page = page
+		}
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		v.Check(err == nil && limit > 0 && limit <= 100, "limit", "must be between 1 and 100")
+		filters.PageSize = limit
+	}
+
+	return filters
+}
@@ -0,0 +1,111 @@
+// internal/inventory/reservation_repository.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+type reservationRepository struct {
+	db domain.DBTX
+}
+
+func NewReservationRepository(db domain.DBTX) domain.ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+func (r *reservationRepository) Create(ctx context.Context, res *models.InventoryReservation) error {
+	query := `
+        INSERT INTO inventory_reservations (order_id, order_item_id, product_id, variant_id, warehouse_id, quantity, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, status, created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query,
+		res.OrderID, res.OrderItemID, res.ProductID, res.VariantID, res.WarehouseID, res.Quantity, res.ExpiresAt,
+	).Scan(&res.ID, &res.Status, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("reservation repository: failed to create reservation: %w", err)
+	}
+	return nil
+}
+
+// ListByOrderIDForUpdate returns an order's reservations and locks them, so a payment
+// webhook and a cancellation cannot both act on the same reservation.
+func (r *reservationRepository) ListByOrderIDForUpdate(ctx context.Context, orderID int64) ([]models.InventoryReservation, error) {
+	query := `
+        SELECT id, order_id, order_item_id, product_id, variant_id, warehouse_id, quantity, status, expires_at, created_at, updated_at
+        FROM inventory_reservations
+        WHERE order_id = $1
+        ORDER BY id
+        FOR UPDATE`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation repository: failed to list reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []models.InventoryReservation
+	for rows.Next() {
+		var res models.InventoryReservation
+		if err := rows.Scan(
+			&res.ID, &res.OrderID, &res.OrderItemID, &res.ProductID, &res.VariantID, &res.WarehouseID, &res.Quantity,
+			&res.Status, &res.ExpiresAt, &res.CreatedAt, &res.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("reservation repository: failed to scan reservation: %w", err)
+		}
+		reservations = append(reservations, res)
+	}
+	return reservations, rows.Err()
+}
+
+// ReservedStock returns the quantity held by unexpired active reservations. A nil
+// variant covers the whole product, including reservations on any of its variants.
+func (r *reservationRepository) ReservedStock(ctx context.Context, productID int64, variantID *int64) (int, error) {
+	var reserved int
+	if err := r.db.QueryRowContext(ctx, `SELECT reserved_stock($1, $2)`, productID, variantID).Scan(&reserved); err != nil {
+		return 0, fmt.Errorf("reservation repository: failed to get reserved stock: %w", err)
+	}
+	return reserved, nil
+}
+
+// CommitByOrderID marks every uncommitted reservation of an order as committed.
+func (r *reservationRepository) CommitByOrderID(ctx context.Context, orderID int64) error {
+	query := `
+        UPDATE inventory_reservations
+        SET status = 'committed', updated_at = NOW()
+        WHERE order_id = $1 AND status <> 'committed'`
+	if _, err := r.db.ExecContext(ctx, query, orderID); err != nil {
+		return fmt.Errorf("reservation repository: failed to commit reservations: %w", err)
+	}
+	return nil
+}
+
+// ReleaseByOrderID releases an order's active reservations and reports how many there were.
+func (r *reservationRepository) ReleaseByOrderID(ctx context.Context, orderID int64) (int64, error) {
+	query := `
+        UPDATE inventory_reservations
+        SET status = 'released', updated_at = NOW()
+        WHERE order_id = $1 AND status = 'active'`
+	result, err := r.db.ExecContext(ctx, query, orderID)
+	if err != nil {
+		return 0, fmt.Errorf("reservation repository: failed to release reservations: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ReleaseExpired marks active reservations past their expiry as released. They already
+// stopped counting against available stock when they expired; this records the fact.
+func (r *reservationRepository) ReleaseExpired(ctx context.Context) (int64, error) {
+	query := `
+        UPDATE inventory_reservations
+        SET status = 'released', updated_at = NOW()
+        WHERE status = 'active' AND expires_at <= NOW()`
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("reservation repository: failed to release expired reservations: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,32 @@
+// internal/inventory/responses.go
+package inventory
+
+import (
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+// MovementListResponse is a page of a product's stock history
+type MovementListResponse struct {
+	Movements  []*models.InventoryMovement `json:"movements"`
+	Page       int                         `json:"page"`
+	Limit      int                         `json:"limit"`
+	Total      int                         `json:"total"`
+	TotalPages int                         `json:"total_pages"`
+}
+
+// NewMovementListResponse assembles the listing response
+func NewMovementListResponse(movements []*models.InventoryMovement, total int, filters domain.MovementFilters) *MovementListResponse {
+	totalPages := 0
+	if filters.PageSize > 0 {
+		totalPages = (total + filters.PageSize - 1) / filters.PageSize
+	}
+
+	return &MovementListResponse{
+		Movements:  movements,
+		Page:       filters.Page,
+		Limit:      filters.PageSize,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
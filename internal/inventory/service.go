@@ -0,0 +1,252 @@
+// internal/inventory/service.go
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/events"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/internal/shared/tasks"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/jsonutil"
+)
+
+type inventoryService struct {
+	productRepo      domain.ProductRepository
+	movementRepo     domain.MovementRepository
+	subscriptionRepo domain.StockSubscriptionRepository
+	store            domain.Store
+	taskCreator      *tasks.TaskCreator
+	currency         string // Currency catalog prices are in, quoted in back-in-stock emails
+	logger           *slog.Logger
+}
+
+// NewInventoryService creates a new InventoryService. taskCreator is only needed by
+// callers that send stock alerts.
+func NewInventoryService(productRepo domain.ProductRepository, movementRepo domain.MovementRepository, subscriptionRepo domain.StockSubscriptionRepository, store domain.Store, taskCreator *tasks.TaskCreator, currency string, logger *slog.Logger) domain.InventoryService {
+	return &inventoryService{
+		productRepo:      productRepo,
+		movementRepo:     movementRepo,
+		subscriptionRepo: subscriptionRepo,
+		store:            store,
+		taskCreator:      taskCreator,
+		currency:         currency,
+		logger:           logger,
+	}
+}
+
+// ListMovements returns a page of a product's stock history, newest first.
+func (s *inventoryService) ListMovements(ctx context.Context, filters domain.MovementFilters) ([]*models.InventoryMovement, int, error) {
+	if _, err := s.productRepo.GetByID(ctx, filters.ProductID); err != nil {
+		return nil, 0, fmt.Errorf("inventory service: could not retrieve product: %w", err)
+	}
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.PageSize <= 0 {
+		filters.PageSize = 20
+	}
+
+	movements, total, err := s.movementRepo.List(ctx, filters)
+	if err != nil {
+		s.logger.Error("failed to list inventory movements", "product_id", filters.ProductID, "error", err)
+		return nil, 0, fmt.Errorf("inventory service: could not list movements: %w", err)
+	}
+	return movements, total, nil
+}
+
+// AdjustStock applies an admin's stock correction at one warehouse, the default one
+// unless the request names another, and records it in the ledger. The stock of a
+// product with variants is the sum of its variants' stock, so such products must be
+// adjusted per variant. A warehouse's stock can never be taken below zero.
+func (s *inventoryService) AdjustStock(ctx context.Context, actorID, productID int64, req *dto.StockAdjustmentRequest) (*models.InventoryMovement, error) {
+	movement := &models.InventoryMovement{
+		ProductID:   productID,
+		VariantID:   req.VariantID,
+		WarehouseID: req.WarehouseID,
+		Delta:       req.Delta,
+		Reason:      req.Reason,
+		ActorID:     &actorID,
+		Note:        strings.TrimSpace(req.Note),
+	}
+
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		if _, err := q.ProductRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+
+		if req.VariantID != nil {
+			variant, err := q.VariantRepo.GetByIDForUpdate(ctx, *req.VariantID)
+			if err != nil {
+				return err
+			}
+			if variant.ProductID != productID {
+				return apperrors.ErrNotFound
+			}
+		} else {
+			variants, err := q.VariantRepo.ListByProductID(ctx, productID)
+			if err != nil {
+				return err
+			}
+			if len(variants) > 0 {
+				return apperrors.ErrVariantRequired
+			}
+		}
+
+		return AdjustStock(ctx, q, movement)
+	})
+	if err != nil {
+		s.logger.Warn("failed to adjust stock", "product_id", productID, "variant_id", req.VariantID, "delta", req.Delta, "error", err)
+		return nil, fmt.Errorf("inventory service: could not adjust stock: %w", err)
+	}
+
+	s.logger.Info("stock adjusted", "product_id", productID, "variant_id", req.VariantID, "warehouse_id", *movement.WarehouseID, "delta", req.Delta, "reason", req.Reason, "actor_id", actorID)
+	return movement, nil
+}
+
+// Subscribe asks for an email when a sold-out product is back in stock.
+func (s *inventoryService) Subscribe(ctx context.Context, userID, productID int64) (*models.StockSubscription, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("inventory service: could not retrieve product: %w", err)
+	}
+	if !product.IsPurchasable() {
+		return nil, fmt.Errorf("inventory service: product %d is %s: %w", productID, product.Status, apperrors.ErrNotFound)
+	}
+	if product.StockQuantity > 0 {
+		return nil, apperrors.ErrProductInStock
+	}
+
+	subscription, err := s.subscriptionRepo.Upsert(ctx, productID, userID)
+	if err != nil {
+		s.logger.Error("failed to subscribe to product", "user_id", userID, "product_id", productID, "error", err)
+		return nil, fmt.Errorf("inventory service: could not subscribe: %w", err)
+	}
+
+	s.logger.Info("back-in-stock subscription created", "user_id", userID, "product_id", productID)
+	return subscription, nil
+}
+
+func (s *inventoryService) Unsubscribe(ctx context.Context, userID, productID int64) error {
+	if err := s.subscriptionRepo.Delete(ctx, productID, userID); err != nil {
+		s.logger.Warn("failed to unsubscribe from product", "user_id", userID, "product_id", productID, "error", err)
+		return fmt.Errorf("inventory service: could not unsubscribe: %w", err)
+	}
+	return nil
+}
+
+// ProcessStockAlerts sends every pending stock alert through the notification worker.
+// Each alert is handled in its own transaction, so a failure leaves only that alert
+// pending for the next run; processing stops there and reports how many were sent.
+func (s *inventoryService) ProcessStockAlerts(ctx context.Context) (int, error) {
+	processed := 0
+	for {
+		claimed := false
+		err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+			alert, err := q.StockAlertRepo.ClaimNext(ctx)
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			claimed = true
+
+			if err := s.sendStockAlert(ctx, q, alert); err != nil {
+				return fmt.Errorf("alert %d: %w", alert.ID, err)
+			}
+			return q.StockAlertRepo.MarkProcessed(ctx, alert.ID)
+		})
+		if err != nil {
+			s.logger.Error("failed to process stock alert", "processed", processed, "error", err)
+			return processed, fmt.Errorf("inventory service: could not process stock alerts: %w", err)
+		}
+		if !claimed {
+			return processed, nil
+		}
+		processed++
+	}
+}
+
+// sendStockAlert enqueues the emails for one alert: every admin for low stock, every
+// waiting subscriber for back in stock. If the product sold out again before the alert
+// was sent, subscribers stay pending and are told the next time it comes back.
+func (s *inventoryService) sendStockAlert(ctx context.Context, q *domain.Queries, alert *models.StockAlert) error {
+	product, err := q.ProductRepo.GetByID(ctx, alert.ProductID)
+	if err != nil {
+		return err
+	}
+
+	switch alert.Kind {
+	case models.StockAlertLowStock:
+		if product.LowStockThreshold == nil {
+			return nil
+		}
+		admins, err := q.UserRepo.GetByRole(ctx, models.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		event := events.LowStockEvent{
+			ProductID:     product.ID,
+			ProductName:   product.Name,
+			SKU:           product.SKU,
+			StockQuantity: alert.StockQuantity,
+			Threshold:     *product.LowStockThreshold,
+		}
+		for _, admin := range admins {
+			if err := s.enqueueNotification(ctx, "LOW_STOCK", admin.Email, event); err != nil {
+				return err
+			}
+		}
+		s.logger.Info("low stock alert sent", "product_id", product.ID, "stock", alert.StockQuantity, "admins", len(admins))
+
+	case models.StockAlertBackInStock:
+		if product.StockQuantity <= 0 || !product.IsPurchasable() {
+			s.logger.Info("skipping back-in-stock alert, product no longer available", "product_id", product.ID)
+			return nil
+		}
+		subscribers, err := q.StockSubRepo.ListPending(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+		if len(subscribers) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(subscribers))
+		for i, sub := range subscribers {
+			event := events.BackInStockEvent{
+				ProductID:   product.ID,
+				ProductName: product.Name,
+				Thumbnail:   product.Thumbnail,
+				Price:       product.Price,
+				Currency:    s.currency,
+				UserName:    sub.Name,
+			}
+			if err := s.enqueueNotification(ctx, "BACK_IN_STOCK", sub.Email, event); err != nil {
+				return err
+			}
+			ids[i] = sub.SubscriptionID
+		}
+		if err := q.StockSubRepo.MarkNotified(ctx, ids); err != nil {
+			return err
+		}
+		s.logger.Info("back-in-stock alert sent", "product_id", product.ID, "subscribers", len(subscribers))
+	}
+	return nil
+}
+
+func (s *inventoryService) enqueueNotification(ctx context.Context, notificationType, email string, payload any) error {
+	notificationEvent := events.NotificationRequestEvent{
+		Type:      notificationType,
+		UserEmail: email,
+		Payload:   jsonutil.MustMarshal(payload),
+	}
+	return s.taskCreator.CreateFulfillmentTask(ctx, "/handle/notification-request", notificationEvent)
+}
@@ -0,0 +1,31 @@
+// internal/inventory/stock.go
+package inventory
+
+import (
+	"context"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+// AdjustStock applies movement.Delta to the stock held at movement.WarehouseID, or at
+// the default warehouse when it is nil, and records the movement in the ledger. The
+// database carries the change on to the variant and product totals. A zero delta is
+// skipped. q must come from Store.ExecTx so the change and its record commit together.
+func AdjustStock(ctx context.Context, q *domain.Queries, movement *models.InventoryMovement) error {
+	if movement.Delta == 0 {
+		return nil
+	}
+	if movement.WarehouseID == nil {
+		warehouse, err := q.WarehouseRepo.GetDefault(ctx)
+		if err != nil {
+			return err
+		}
+		movement.WarehouseID = &warehouse.ID
+	}
+
+	if err := q.WarehouseStockRepo.Adjust(ctx, *movement.WarehouseID, movement.ProductID, movement.VariantID, movement.Delta); err != nil {
+		return err
+	}
+	return q.MovementRepo.Record(ctx, movement)
+}
@@ -0,0 +1,51 @@
+// internal/inventory/stock_alert_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type stockAlertRepository struct {
+	db domain.DBTX
+}
+
+func NewStockAlertRepository(db domain.DBTX) domain.StockAlertRepository {
+	return &stockAlertRepository{db: db}
+}
+
+// ClaimNext locks the oldest unprocessed alert. SKIP LOCKED lets several workers drain
+// the outbox at once without sending the same alert twice.
+func (r *stockAlertRepository) ClaimNext(ctx context.Context) (*models.StockAlert, error) {
+	query := `
+        SELECT id, product_id, kind, stock_quantity, created_at, processed_at
+        FROM stock_alerts
+        WHERE processed_at IS NULL
+        ORDER BY id
+        LIMIT 1
+        FOR UPDATE SKIP LOCKED`
+
+	var a models.StockAlert
+	err := r.db.QueryRowContext(ctx, query).Scan(&a.ID, &a.ProductID, &a.Kind, &a.StockQuantity, &a.CreatedAt, &a.ProcessedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("stock alert repository: failed to claim alert: %w", err)
+	}
+	return &a, nil
+}
+
+func (r *stockAlertRepository) MarkProcessed(ctx context.Context, id int64) error {
+	query := `UPDATE stock_alerts SET processed_at = NOW() WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("stock alert repository: failed to mark alert processed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// internal/inventory/stock_subscription_repository.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type stockSubscriptionRepository struct {
+	db domain.DBTX
+}
+
+func NewStockSubscriptionRepository(db domain.DBTX) domain.StockSubscriptionRepository {
+	return &stockSubscriptionRepository{db: db}
+}
+
+// Upsert subscribes a user to a product. Subscribing again after being notified
+// re-arms the subscription for the next time the product comes back.
+func (r *stockSubscriptionRepository) Upsert(ctx context.Context, productID, userID int64) (*models.StockSubscription, error) {
+	query := `
+        INSERT INTO stock_subscriptions (product_id, user_id)
+        VALUES ($1, $2)
+        ON CONFLICT (product_id, user_id) DO UPDATE SET notified_at = NULL
+        RETURNING id, product_id, user_id, notified_at, created_at`
+
+	var s models.StockSubscription
+	err := r.db.QueryRowContext(ctx, query, productID, userID).Scan(&s.ID, &s.ProductID, &s.UserID, &s.NotifiedAt, &s.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("stock subscription repository: failed to subscribe: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *stockSubscriptionRepository) Delete(ctx context.Context, productID, userID int64) error {
+	query := `DELETE FROM stock_subscriptions WHERE product_id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, productID, userID)
+	if err != nil {
+		return fmt.Errorf("stock subscription repository: failed to unsubscribe: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("stock subscription repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// ListPending returns the subscribers of a product who have not been notified yet.
+func (r *stockSubscriptionRepository) ListPending(ctx context.Context, productID int64) ([]models.StockSubscriber, error) {
+	query := `
+        SELECT s.id, u.id, u.name, u.email
+        FROM stock_subscriptions s
+        JOIN users u ON u.id = s.user_id
+        WHERE s.product_id = $1 AND s.notified_at IS NULL
+        ORDER BY s.id`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("stock subscription repository: failed to list subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	var subscribers []models.StockSubscriber
+	for rows.Next() {
+		var s models.StockSubscriber
+		if err := rows.Scan(&s.SubscriptionID, &s.UserID, &s.Name, &s.Email); err != nil {
+			return nil, fmt.Errorf("stock subscription repository: failed to scan subscriber: %w", err)
+		}
+		subscribers = append(subscribers, s)
+	}
+	return subscribers, rows.Err()
+}
+
+func (r *stockSubscriptionRepository) MarkNotified(ctx context.Context, subscriptionIDs []int64) error {
+	query := `UPDATE stock_subscriptions SET notified_at = NOW() WHERE id = ANY($1)`
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(subscriptionIDs)); err != nil {
+		return fmt.Errorf("stock subscription repository: failed to mark subscriptions notified: %w", err)
+	}
+	return nil
+}
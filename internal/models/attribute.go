@@ -0,0 +1,98 @@
+// internal/models/attribute.go
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// AttributeType is the kind of value a category attribute holds.
+type AttributeType string
+
+const (
+	AttributeTypeText    AttributeType = "text"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+)
+
+// IsValid reports whether t is a known attribute type.
+func (t AttributeType) IsValid() bool {
+	switch t {
+	case AttributeTypeText, AttributeTypeNumber, AttributeTypeBoolean:
+		return true
+	}
+	return false
+}
+
+// Normalize returns raw in the canonical form values of the type are stored and
+// filtered in: numbers without trailing zeros, booleans as true or false, text trimmed.
+func (t AttributeType) Normalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	switch t {
+	case AttributeTypeNumber:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", errors.New("must be a number")
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case AttributeTypeBoolean:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", errors.New("must be true or false")
+		}
+		return strconv.FormatBool(b), nil
+	}
+	if raw == "" {
+		return "", errors.New("must not be empty")
+	}
+	if len(raw) > 255 {
+		return "", errors.New("must not exceed 255 characters")
+	}
+	return raw, nil
+}
+
+// CategoryAttribute defines a typed spec that products in a category carry,
+// e.g. RAM or screen size for laptops.
+type CategoryAttribute struct {
+	ID            int64          `json:"id"`
+	CategoryID    int64          `json:"category_id"`
+	Code          string         `json:"code"` // Used in listing filters, e.g. ?attr.ram=16GB
+	Name          string         `json:"name"`
+	Type          AttributeType  `json:"type"`
+	Unit          string         `json:"unit,omitempty"`
+	AllowedValues pq.StringArray `json:"allowed_values"` // Empty means any value of the type
+	Position      int            `json:"position"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// NormalizeValue checks raw against the attribute's type and allowed values and
+// returns it in canonical form.
+func (a *CategoryAttribute) NormalizeValue(raw string) (string, error) {
+	value, err := a.Type.Normalize(raw)
+	if err != nil {
+		return "", err
+	}
+	if len(a.AllowedValues) > 0 && !slices.Contains(a.AllowedValues, value) {
+		return "", fmt.Errorf("must be one of %s", strings.Join(a.AllowedValues, ", "))
+	}
+	return value, nil
+}
+
+// ProductAttribute is a product's value for one of its category's attributes.
+type ProductAttribute struct {
+	ProductID   int64         `json:"-"`
+	AttributeID int64         `json:"-"`
+	Code        string        `json:"code"`
+	Name        string        `json:"name"`
+	Type        AttributeType `json:"type"`
+	Unit        string        `json:"unit,omitempty"`
+	Value       string        `json:"value"`
+}
@@ -1,17 +1,135 @@
 // internal/models/cart.go
 package models
 
+import (
+	"fmt"
+	"math"
+)
 
 type Cart struct {
 	BaseModel
     UserID    *int64     `json:"user_id"` // Pointer to handle NULL for anonymous users
+    Name      *string    `json:"name,omitempty"` // Set for a user's named carts
+    IsDefault bool       `json:"is_default"` // The user's cart when no other is chosen
     Items     []CartItem `json:"items,omitempty"` // For eager loading items
+    SavedItems []CartItem `json:"saved_items,omitempty"` // Lines saved for later, left out of Total
     Total     float64    `json:"total,omitempty"` // Calculated field
+    Coupons   []AppliedCoupon `json:"coupons,omitempty"` // Coupons applied to the cart, priced against its items
+    Discount  float64    `json:"discount,omitempty"` // What the coupons take off Total
 }
 
 type CartItem struct {
 	BaseModel
 	CartID    int64    `json:"-"`
-    Product   *Product `json:"product"` // Eager load product details
-    Quantity  int      `json:"quantity"`
-}
\ No newline at end of file
+    Product   *Product        `json:"product"` // Eager load product details
+    Variant   *ProductVariant `json:"variant,omitempty"` // Set when a specific variant was chosen
+    Quantity  int             `json:"quantity"`
+    PriceAtAdd *float64       `json:"price_at_add,omitempty"` // Unit price when the line was added; nil for older lines
+    SavedForLater bool        `json:"saved_for_later"`        // Kept in the cart but not checked out
+    Warnings  []CartWarning   `json:"warnings,omitempty"`     // Problems found when the cart was loaded
+}
+
+// CartWarningCode identifies a problem with a cart line that would change or block checkout.
+type CartWarningCode string
+
+const (
+	CartWarningPriceIncreased    CartWarningCode = "price_increased"
+	CartWarningPriceDecreased    CartWarningCode = "price_decreased"
+	CartWarningInsufficientStock CartWarningCode = "insufficient_stock"
+	CartWarningOutOfStock        CartWarningCode = "out_of_stock"
+	CartWarningUnavailable       CartWarningCode = "product_unavailable"
+)
+
+// CartWarning describes one problem with a cart line.
+type CartWarning struct {
+	Code    CartWarningCode `json:"code"`
+	Message string          `json:"message"`
+}
+
+// CartFixAction is what FixCart did to a cart line.
+type CartFixAction string
+
+const (
+	CartFixRemoved         CartFixAction = "removed"
+	CartFixQuantityReduced CartFixAction = "quantity_reduced"
+	CartFixPriceAccepted   CartFixAction = "price_accepted"
+)
+
+// CartFix records one change made to a cart line when fixing the cart.
+type CartFix struct {
+	ProductID   int64           `json:"product_id"`
+	VariantID   *int64          `json:"variant_id,omitempty"`
+	ProductName string          `json:"product_name"`
+	Action      CartFixAction   `json:"action"`
+	Reason      CartWarningCode `json:"reason"`
+	Quantity    int             `json:"quantity"` // Quantity left on the line afterwards
+}
+
+// UnitPrice is the price of one unit on this line, taken from the variant when one was chosen.
+func (i *CartItem) UnitPrice() float64 {
+	if i.Variant != nil {
+		return i.Variant.Price
+	}
+	return i.Product.Price
+}
+
+// CompareAtPrice is the regular price of one unit while this line is on sale, or nil.
+func (i *CartItem) CompareAtPrice() *float64 {
+	if i.Variant != nil {
+		return i.Variant.CompareAtPrice
+	}
+	return i.Product.CompareAtPrice
+}
+
+// AvailableStock is the unreserved stock that backs this line, at variant level when one was chosen.
+func (i *CartItem) AvailableStock() int {
+	if i.Variant != nil {
+		return i.Variant.AvailableStock
+	}
+	return i.Product.AvailableStock
+}
+
+// VariantID is the ID of the chosen variant, or nil for lines without one.
+func (i *CartItem) VariantID() *int64 {
+	if i.Variant != nil {
+		return &i.Variant.ID
+	}
+	return nil
+}
+
+// Check reports what would go wrong if the line were checked out now. A line whose
+// product can no longer be bought gets only that warning, since nothing else matters.
+func (i *CartItem) Check() []CartWarning {
+	if !i.Product.IsPurchasable() {
+		return []CartWarning{{Code: CartWarningUnavailable, Message: fmt.Sprintf("%s is no longer available", i.Product.Name)}}
+	}
+
+	var warnings []CartWarning
+	switch available := i.AvailableStock(); {
+	case available <= 0:
+		warnings = append(warnings, CartWarning{Code: CartWarningOutOfStock, Message: fmt.Sprintf("%s is out of stock", i.Product.Name)})
+	case i.Quantity > available:
+		warnings = append(warnings, CartWarning{
+			Code:    CartWarningInsufficientStock,
+			Message: fmt.Sprintf("only %d of %s left in stock", available, i.Product.Name),
+		})
+	}
+
+	// Prices are compared in cents so float noise does not raise a warning.
+	if i.PriceAtAdd != nil {
+		was, now := math.Round(*i.PriceAtAdd*100), math.Round(i.UnitPrice()*100)
+		switch {
+		case now > was:
+			warnings = append(warnings, CartWarning{
+				Code:    CartWarningPriceIncreased,
+				Message: fmt.Sprintf("price of %s went up from %.2f to %.2f", i.Product.Name, *i.PriceAtAdd, i.UnitPrice()),
+			})
+		case now < was:
+			warnings = append(warnings, CartWarning{
+				Code:    CartWarningPriceDecreased,
+				Message: fmt.Sprintf("price of %s went down from %.2f to %.2f", i.Product.Name, *i.PriceAtAdd, i.UnitPrice()),
+			})
+		}
+	}
+	return warnings
+}
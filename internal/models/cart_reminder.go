@@ -0,0 +1,37 @@
+// internal/models/cart_reminder.go
+package models
+
+import "time"
+
+// CartReminder records one abandoned cart email. OrderID and ConvertedAt are set when
+// the customer goes on to place an order from the cart.
+type CartReminder struct {
+	ID          int64      `json:"id"`
+	CartID      int64      `json:"cart_id"`
+	UserID      int64      `json:"user_id"`
+	ItemCount   int        `json:"item_count"`
+	CartTotal   float64    `json:"cart_total"`
+	SentAt      time.Time  `json:"sent_at"`
+	OrderID     *int64     `json:"order_id,omitempty"`
+	ConvertedAt *time.Time `json:"converted_at,omitempty"`
+}
+
+// AbandonedCart is a signed-in customer's idle cart that is due a reminder, joined
+// with the customer to email.
+type AbandonedCart struct {
+	CartID        int64
+	CartName      *string
+	UserID        int64
+	Name          string
+	Email         string
+	RemindersSent int // Reminders already sent since the cart's last order
+}
+
+// CartReminderStats sums up the reminders sent since a point in time.
+type CartReminderStats struct {
+	Since          time.Time `json:"since"`
+	RemindersSent  int       `json:"reminders_sent"`
+	CartsReminded  int       `json:"carts_reminded"`
+	CartsConverted int       `json:"carts_converted"`
+	ConversionRate float64   `json:"conversion_rate"` // CartsConverted / CartsReminded
+}
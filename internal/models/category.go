@@ -1,8 +1,27 @@
 // internal/models/category.go
 package models
 
+import "time"
 
 type Category struct {
 	BaseModel
-	Name  string `json:"name"` 
-}
\ No newline at end of file
+	Name     string      `json:"name"` 
+	Slug     string      `json:"slug"`
+	ParentID *int64      `json:"parent_id"` // NULL for top-level categories
+	Position int         `json:"position"`  // Sort order among siblings
+	Children []*Category `json:"children,omitempty"` // Populated for the category tree
+}
+
+// LatestCategoryUpdate returns the latest update time across categories and their children.
+func LatestCategoryUpdate(categories []*Category) time.Time {
+	var latest time.Time
+	for _, c := range categories {
+		if c.UpdatedAt.After(latest) {
+			latest = c.UpdatedAt
+		}
+		if child := LatestCategoryUpdate(c.Children); child.After(latest) {
+			latest = child
+		}
+	}
+	return latest
+}
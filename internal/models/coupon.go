@@ -0,0 +1,88 @@
+// internal/models/coupon.go
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CouponDiscountType says how a coupon's value is applied
+type CouponDiscountType string
+
+const (
+	CouponDiscountPercentage CouponDiscountType = "percentage" // Value is a percentage of the eligible amount
+	CouponDiscountFixed      CouponDiscountType = "fixed"      // Value is an amount in the base currency
+)
+
+// Coupon is a promotion code. Fixed values, MaxDiscount and MinCartValue are in the
+// base currency. With no ProductIDs and no CategoryIDs it applies to the whole cart.
+type Coupon struct {
+	ID           int64              `json:"id"`
+	Code         string             `json:"code"`
+	Description  string             `json:"description,omitempty"`
+	DiscountType CouponDiscountType `json:"discount_type"`
+	Value        float64            `json:"value"`
+	MaxDiscount  *float64           `json:"max_discount,omitempty"` // Cap on a percentage discount
+	MinCartValue float64            `json:"min_cart_value"`
+	ProductIDs   pq.Int64Array      `json:"product_ids"`
+	CategoryIDs  pq.Int64Array      `json:"category_ids"`             // Subcategories are included
+	UsageLimit   *int               `json:"usage_limit,omitempty"`    // Redemptions across all customers
+	PerUserLimit *int               `json:"per_user_limit,omitempty"` // Redemptions by one customer
+	TimesUsed    int                `json:"times_used"`               // Redemptions not released by a cancellation
+	Stackable    bool               `json:"stackable"`                // Can be combined with other stackable coupons
+	StartsAt     *time.Time         `json:"starts_at,omitempty"`
+	EndsAt       *time.Time         `json:"ends_at,omitempty"`
+	Active       bool               `json:"active"`
+	CreatedBy    *int64             `json:"created_by,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+
+	// ScopeCategoryIDs is CategoryIDs with every category below them, as loaded.
+	ScopeCategoryIDs pq.Int64Array `json:"-"`
+}
+
+// IsCartWide reports whether the coupon applies to every product.
+func (c *Coupon) IsCartWide() bool {
+	return len(c.ProductIDs) == 0 && len(c.CategoryIDs) == 0
+}
+
+// Covers reports whether the coupon applies to a product in the given category.
+func (c *Coupon) Covers(productID, categoryID int64) bool {
+	return c.IsCartWide() || slices.Contains(c.ProductIDs, productID) || slices.Contains(c.ScopeCategoryIDs, categoryID)
+}
+
+// IsRunning reports whether the coupon is switched on and inside its validity window at t.
+func (c *Coupon) IsRunning(t time.Time) bool {
+	if !c.Active {
+		return false
+	}
+	if c.StartsAt != nil && t.Before(*c.StartsAt) {
+		return false
+	}
+	return c.EndsAt == nil || t.Before(*c.EndsAt)
+}
+
+// AppliedCoupon is what one coupon takes off a cart or order. A coupon that cannot be
+// used right now takes nothing off and says why in Problem.
+type AppliedCoupon struct {
+	CouponID    int64   `json:"-"`
+	Code        string  `json:"code"`
+	Description string  `json:"description,omitempty"`
+	Amount      float64 `json:"amount"`
+	Problem     string  `json:"problem,omitempty"`
+	Err         error   `json:"-"`
+}
+
+// CouponRedemption records a coupon used on an order. Amount is in the order currency.
+type CouponRedemption struct {
+	ID         int64      `json:"id"`
+	CouponID   int64      `json:"coupon_id"`
+	OrderID    int64      `json:"order_id"`
+	UserID     int64      `json:"user_id"`
+	Amount     float64    `json:"amount"`
+	Currency   string     `json:"currency"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+}
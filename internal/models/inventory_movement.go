@@ -0,0 +1,33 @@
+// internal/models/inventory_movement.go
+package models
+
+import "time"
+
+// MovementReason says why a product's stock changed
+type MovementReason string
+
+const (
+	MovementReasonOrder            MovementReason = "order"             // Stock taken for a paid order
+	MovementReasonCancellation     MovementReason = "cancellation"      // Stock returned when a customer cancels
+	MovementReasonCleanup          MovementReason = "cleanup"           // Stock returned when an unpaid order is cleaned up
+	MovementReasonManualAdjustment MovementReason = "manual_adjustment" // Set or adjusted by an admin or a catalog import
+	MovementReasonReturn           MovementReason = "return"            // Goods sent back by a customer and restocked
+)
+
+// InventoryMovement is one entry in the stock ledger. ReferenceID points at the
+// record that caused it, e.g. the order ID for order, cancellation and cleanup
+// movements. ActorID is the user behind the change, nil for system jobs.
+// WarehouseID is the location whose stock moved; nil on entries from before warehouses.
+type InventoryMovement struct {
+	ID          int64          `json:"id"`
+	ProductID   int64          `json:"product_id"`
+	VariantID   *int64         `json:"variant_id,omitempty"`
+	WarehouseID *int64         `json:"warehouse_id,omitempty"`
+	Delta       int            `json:"delta"`
+	StockAfter  int            `json:"stock_after"`
+	Reason      MovementReason `json:"reason"`
+	ReferenceID *int64         `json:"reference_id,omitempty"`
+	ActorID     *int64         `json:"actor_id,omitempty"`
+	Note        string         `json:"note,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
@@ -10,6 +10,7 @@ type OrderStatus string
 
 const (
     OrderStatusPendingPayment   OrderStatus = "pending_payment"
+    OrderStatusOnHold           OrderStatus = "on_hold" // Paid, but the stock is gone; awaiting refund or manual review
     OrderStatusConfirmed        OrderStatus = "confirmed"
     OrderStatusProcessing       OrderStatus = "processing"
     OrderStatusShipped          OrderStatus = "shipped"
@@ -56,9 +57,12 @@ type Order struct {
     ShippingCost          float64        `json:"shipping_cost"`
     DiscountAmount        float64        `json:"discount_amount"`
     TotalAmount           float64        `json:"total_amount"`
+    Currency              string         `json:"currency"`      // ISO code all amounts on the order and its items are in
+    ExchangeRate          float64        `json:"exchange_rate"` // Units of Currency per unit of the base currency at checkout
     Notes                 string         `json:"notes,omitempty"`
     TrackingNumber        string         `json:"tracking_number,omitempty"`
     EstimatedDeliveryDate time.Time      `json:"estimated_delivery_date,omitempty"`
+    WarehouseID           *int64         `json:"warehouse_id,omitempty"` // Location allocated to ship the order
     CreatedAt             time.Time      `json:"created_at"`
     UpdatedAt             time.Time      `json:"updated_at"`
 }
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+    "encoding/json"
+    "time"
+)
 
 // OrderItem represents a line item in an order
 type OrderItem struct {
@@ -10,7 +13,12 @@ type OrderItem struct {
     ProductName   string  `json:"product_name"`
     ProductSKU    string  `json:"product_sku"`
     ProductImage  string  `json:"product_image,omitempty"`
+    VariantID     *int64  `json:"variant_id,omitempty"`
+    VariantOptions json.RawMessage `json:"variant_options,omitempty"` // Snapshot of the chosen option values
     UnitPrice     float64 `json:"unit_price"`
+    CompareAtPrice *float64 `json:"compare_at_price,omitempty"` // Regular price when sold on sale
+    PriceRule     PriceRule `json:"price_rule"`
+    PriceScheduleID *int64  `json:"price_schedule_id,omitempty"` // The sale that set UnitPrice
     Quantity      int     `json:"quantity"`
     TotalPrice    float64 `json:"total_price"`
     CreatedAt     time.Time `json:"created_at"`
@@ -0,0 +1,64 @@
+// internal/models/pricing.go
+package models
+
+import "time"
+
+// PriceRule says which price an order line was sold at
+type PriceRule string
+
+const (
+	PriceRuleBase          PriceRule = "base"           // The regular price
+	PriceRuleScheduledSale PriceRule = "scheduled_sale" // A sale from a price schedule
+)
+
+// PriceSchedule is a sale price for a product, or one of its variants, between
+// StartsAt and EndsAt. A cancelled schedule never applies again.
+type PriceSchedule struct {
+	ID          int64      `json:"id"`
+	ProductID   int64      `json:"product_id"`
+	VariantID   *int64     `json:"variant_id,omitempty"`
+	SalePrice   float64    `json:"sale_price"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Label       string     `json:"label,omitempty"`
+	CreatedBy   *int64     `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// PriceChange is one entry in a product's regular price history. OldPrice is nil
+// for the price a product or variant was created with.
+type PriceChange struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"product_id"`
+	VariantID *int64    `json:"variant_id,omitempty"`
+	OldPrice  *float64  `json:"old_price,omitempty"`
+	NewPrice  float64   `json:"new_price"`
+	ActorID   *int64    `json:"actor_id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// EffectivePrice is what a buyer pays at a given moment. CompareAtPrice is the
+// regular price, set only while a sale brings the price below it.
+type EffectivePrice struct {
+	Price           float64
+	CompareAtPrice  *float64
+	Rule            PriceRule
+	PriceScheduleID *int64
+}
+
+// ResolvePrice applies the sale running at the moment, if any, to a regular price.
+// A sale never raises the price: if the regular price has since dropped below the
+// sale price, the regular price applies.
+func ResolvePrice(base float64, salePrice *float64, scheduleID *int64) EffectivePrice {
+	if salePrice == nil || scheduleID == nil || *salePrice >= base {
+		return EffectivePrice{Price: base, Rule: PriceRuleBase}
+	}
+	return EffectivePrice{
+		Price:           *salePrice,
+		CompareAtPrice:  &base,
+		Rule:            PriceRuleScheduledSale,
+		PriceScheduleID: scheduleID,
+	}
+}
@@ -8,6 +8,24 @@ import (
 	"github.com/lib/pq" 
 )
 
+// ProductStatus is where a product is in its lifecycle.
+type ProductStatus string
+
+const (
+	ProductStatusDraft    ProductStatus = "draft"    // Being prepared; hidden from the public catalog
+	ProductStatusActive   ProductStatus = "active"   // Listed and sellable
+	ProductStatusArchived ProductStatus = "archived" // No longer sold; kept for order history
+)
+
+// IsValid reports whether s is a known product status.
+func (s ProductStatus) IsValid() bool {
+	switch s {
+	case ProductStatusDraft, ProductStatusActive, ProductStatusArchived:
+		return true
+	}
+	return false
+}
+
 // Dimensions represents the product's size.
 type Dimensions struct {
 	Width  float64 `json:"width"`
@@ -19,8 +37,12 @@ type Product struct {
 	ID                  int64           `json:"id"`
 	Name                string          `json:"name"`
 	Description         string          `json:"description"`
-	Price               float64         `json:"price"`
-	StockQuantity       int             `json:"stock_quantity"`
+	Price               float64         `json:"price"`                      // What a buyer pays now, sale included
+	BasePrice           float64         `json:"-"`                          // The regular price admins edit
+	CompareAtPrice      *float64        `json:"compare_at_price,omitempty"` // The regular price while on sale
+	StockQuantity       int             `json:"stock_quantity"`  // On hand
+	AvailableStock      int             `json:"available_stock"` // On hand minus active reservations
+	LowStockThreshold   *int            `json:"low_stock_threshold,omitempty"` // Admins are alerted when stock falls to it
 	CategoryID          int64           `json:"-"` 					// Foreign key
 	Category            *Category       `json:"category,omitempty"` // For joining data
 	Brand               string          `json:"brand,omitempty"`
@@ -32,4 +54,45 @@ type Product struct {
 	CreatedAt           time.Time       `json:"created_at"`
 	UpdatedAt           time.Time       `json:"updated_at"`
 	Version             int             `json:"version"`
-}
\ No newline at end of file
+	Status              ProductStatus   `json:"status"`
+	ArchivedAt          *time.Time      `json:"archived_at,omitempty"`
+	RatingAverage       float64         `json:"rating_average"` // Average of published reviews
+	ReviewCount         int             `json:"review_count"`
+	Attributes          []ProductAttribute `json:"attributes,omitempty"` // Typed specs defined by the category
+	Options             []ProductOption  `json:"options,omitempty"`  // Loaded for single-product views
+	Variants            []ProductVariant `json:"variants,omitempty"` // Loaded for single-product views
+}
+
+// IsArchived reports whether the product has been withdrawn from the catalog.
+func (p *Product) IsArchived() bool {
+	return p.Status == ProductStatusArchived
+}
+
+// IsDraft reports whether the product has not been published yet.
+func (p *Product) IsDraft() bool {
+	return p.Status == ProductStatusDraft
+}
+
+// IsPurchasable reports whether the product can be added to carts and ordered.
+func (p *Product) IsPurchasable() bool {
+	return p.Status == ProductStatusActive
+}
+
+// LastModified returns the latest update time of the product and its loaded variants.
+func (p *Product) LastModified() time.Time {
+	latest := p.UpdatedAt
+	for _, v := range p.Variants {
+		if v.UpdatedAt.After(latest) {
+			latest = v.UpdatedAt
+		}
+	}
+	return latest
+}
+
+// SetPrice fills the price fields from the regular price and the sale running, if any.
+func (p *Product) SetPrice(base float64, salePrice *float64, scheduleID *int64) {
+	resolved := ResolvePrice(base, salePrice, scheduleID)
+	p.BasePrice = base
+	p.Price = resolved.Price
+	p.CompareAtPrice = resolved.CompareAtPrice
+}
@@ -0,0 +1,19 @@
+// internal/models/recommendation.go
+package models
+
+// RecommendationKind says why a product is recommended
+type RecommendationKind string
+
+const (
+	RecommendationBoughtTogether RecommendationKind = "bought_together" // Often in the same paid order
+	RecommendationSimilar        RecommendationKind = "similar"         // Same category or brand
+)
+
+// Recommendation is a product shown alongside another. Score is the number of paid
+// orders both were in for bought-together products, and how closely a similar product
+// matches (same category, same brand, or both) otherwise.
+type Recommendation struct {
+	Kind    RecommendationKind `json:"kind"`
+	Score   float64            `json:"score"`
+	Product *Product           `json:"product"`
+}
@@ -0,0 +1,29 @@
+// internal/models/reservation.go
+package models
+
+import "time"
+
+// ReservationStatus is the lifecycle state of an inventory reservation
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"    // Holding stock for an unpaid order
+	ReservationStatusCommitted ReservationStatus = "committed" // Converted into a stock decrement on payment
+	ReservationStatusReleased  ReservationStatus = "released"  // Expired or cancelled; stock was never taken
+)
+
+// InventoryReservation holds stock for one order line between checkout and payment.
+// While active and unexpired it is subtracted from the stock shown as available.
+type InventoryReservation struct {
+	ID          int64             `json:"id"`
+	OrderID     int64             `json:"order_id"`
+	OrderItemID int64             `json:"order_item_id"`
+	ProductID   int64             `json:"product_id"`
+	VariantID   *int64            `json:"variant_id,omitempty"`
+	WarehouseID *int64            `json:"warehouse_id,omitempty"` // Where the stock is held; nil only for legacy rows
+	Quantity    int               `json:"quantity"`
+	Status      ReservationStatus `json:"status"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,27 @@
+// internal/models/review.go
+package models
+
+import "time"
+
+// ReviewStatus controls whether a review is shown on the storefront
+type ReviewStatus string
+
+const (
+	ReviewStatusPublished ReviewStatus = "published"
+	ReviewStatusHidden    ReviewStatus = "hidden"
+)
+
+// Review is a customer's rating and write-up of a product they received
+type Review struct {
+	ID             int64        `json:"id"`
+	ProductID      int64        `json:"product_id"`
+	UserID         int64        `json:"user_id"`
+	UserName       string       `json:"user_name"` // Joined from users for display
+	Rating         int          `json:"rating"`
+	Title          string       `json:"title"`
+	Body           string       `json:"body"`
+	Status         ReviewStatus `json:"status"`
+	ModerationNote string       `json:"moderation_note,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,41 @@
+// internal/models/stock_alert.go
+package models
+
+import "time"
+
+// StockAlertKind is the stock crossing that raised an alert
+type StockAlertKind string
+
+const (
+	StockAlertLowStock    StockAlertKind = "low_stock"     // Stock fell to the product's low-stock threshold
+	StockAlertBackInStock StockAlertKind = "back_in_stock" // Stock went from zero to positive with subscribers waiting
+)
+
+// StockAlert is a pending notification about a product's stock, written by the
+// database when the stock crosses a threshold and sent out by the mega-worker.
+type StockAlert struct {
+	ID            int64          `json:"id"`
+	ProductID     int64          `json:"product_id"`
+	Kind          StockAlertKind `json:"kind"`
+	StockQuantity int            `json:"stock_quantity"` // Stock right after the crossing
+	CreatedAt     time.Time      `json:"created_at"`
+	ProcessedAt   *time.Time     `json:"processed_at,omitempty"`
+}
+
+// StockSubscription is a customer's request to be emailed when a sold-out product
+// is back. NotifiedAt is set once the email is sent; subscribing again clears it.
+type StockSubscription struct {
+	ID         int64      `json:"id"`
+	ProductID  int64      `json:"product_id"`
+	UserID     int64      `json:"user_id"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// StockSubscriber is a pending subscription joined with the user to email.
+type StockSubscriber struct {
+	SubscriptionID int64
+	UserID         int64
+	Name           string
+	Email          string
+}
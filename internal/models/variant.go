@@ -0,0 +1,44 @@
+// internal/models/variant.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ProductOption is an axis a product varies along, e.g. Size with values S, M and L.
+type ProductOption struct {
+	ID        int64          `json:"id"`
+	ProductID int64          `json:"-"`
+	Name      string         `json:"name"`
+	Values    pq.StringArray `json:"values"`
+	Position  int            `json:"position"`
+}
+
+// ProductVariant is one purchasable combination of option values.
+// It carries its own SKU, price, stock and images.
+type ProductVariant struct {
+	ID             int64           `json:"id"`
+	ProductID      int64           `json:"product_id"`
+	SKU            string          `json:"sku"`
+	Price          float64         `json:"price"`                      // What a buyer pays now, sale included
+	BasePrice      float64         `json:"-"`                          // The regular price admins edit
+	CompareAtPrice *float64        `json:"compare_at_price,omitempty"` // The regular price while on sale
+	StockQuantity  int             `json:"stock_quantity"`
+	AvailableStock int             `json:"available_stock"` // StockQuantity minus active reservations
+	Images         pq.StringArray  `json:"images"`
+	Options        json.RawMessage `json:"options"` // e.g. {"Size": "M", "Colour": "Red"}
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	Version        int             `json:"version"`
+}
+
+// SetPrice fills the price fields from the regular price and the sale running, if any.
+func (v *ProductVariant) SetPrice(base float64, salePrice *float64, scheduleID *int64) {
+	resolved := ResolvePrice(base, salePrice, scheduleID)
+	v.BasePrice = base
+	v.Price = resolved.Price
+	v.CompareAtPrice = resolved.CompareAtPrice
+}
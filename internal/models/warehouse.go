@@ -0,0 +1,34 @@
+// internal/models/warehouse.go
+package models
+
+import "time"
+
+// Warehouse is a stock location orders can ship from. Lower Priority is preferred
+// when allocating; the default warehouse takes stock edits that name no location.
+type Warehouse struct {
+	ID         int64     `json:"id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Street1    string    `json:"street1,omitempty"`
+	City       string    `json:"city,omitempty"`
+	State      string    `json:"state,omitempty"`
+	PostalCode string    `json:"postal_code,omitempty"`
+	Country    string    `json:"country,omitempty"`
+	Priority   int       `json:"priority"`
+	IsDefault  bool      `json:"is_default"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WarehouseStock is the stock of a product, or one of its variants, held at a
+// warehouse. Available is Quantity less the unexpired reservations at that warehouse.
+type WarehouseStock struct {
+	WarehouseID   int64     `json:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code"`
+	ProductID     int64     `json:"product_id"`
+	VariantID     *int64    `json:"variant_id,omitempty"`
+	Quantity      int       `json:"quantity"`
+	Available     int       `json:"available"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+// internal/models/wishlist.go
+package models
+
+import "time"
+
+type Wishlist struct {
+	BaseModel
+	UserID *int64         `json:"user_id"` // NULL for anonymous wishlists
+	Items  []WishlistItem `json:"items,omitempty"`
+}
+
+// WishlistItem is a saved product, or one variant of it. Product and Variant carry
+// the current price and stock, not those at the time it was saved.
+type WishlistItem struct {
+	ID         int64           `json:"id"`
+	WishlistID int64           `json:"-"`
+	Product    *Product        `json:"product"`
+	Variant    *ProductVariant `json:"variant,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AvailableStock is the unreserved stock of the saved product, at variant level when one was chosen.
+func (i *WishlistItem) AvailableStock() int {
+	if i.Variant != nil {
+		return i.Variant.AvailableStock
+	}
+	return i.Product.AvailableStock
+}
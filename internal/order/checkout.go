@@ -0,0 +1,148 @@
+// internal/order/checkout.go
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/warehouse"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/money"
+)
+
+// checkout is a cart priced for ordering. CreateOrder charges it and QuoteCheckout
+// shows it, so a quote always matches what ordering the same cart would charge.
+type checkout struct {
+	ShippingAddress *models.UserAddress
+	BillingAddress  *models.UserAddress
+	Items           []*models.OrderItem
+	Coupons         []models.AppliedCoupon
+	Warehouse       *models.Warehouse
+	Currency        string
+	ExchangeRate    float64
+	PricedAt        time.Time
+	Subtotal        float64
+	TaxAmount       float64
+	ShippingCost    float64
+	DiscountAmount  float64
+	TotalAmount     float64
+}
+
+// resolveCurrency returns the currency an order is placed in, the base currency unless
+// one was requested, and the rate base currency prices are converted at.
+func (s *orderService) resolveCurrency(requested string) (string, float64, error) {
+	currency := s.rates.Base()
+	if requested != "" {
+		currency = money.Normalize(requested)
+	}
+	rate, err := s.rates.Rate(currency)
+	if err != nil {
+		return "", 0, err
+	}
+	return currency, rate, nil
+}
+
+// priceCheckout prices a user's cart for an order shipped and billed to the given
+// addresses. It reads but never writes. With forUpdate set, as when placing an order
+// inside Store.ExecTx, it locks the products, variants and coupons involved so the
+// stock, warehouse and coupon checks hold until the transaction ends. Quotes leave it
+// unset and run in Store.ExecReadOnlyTx, so they never hold up real checkouts.
+func (s *orderService) priceCheckout(ctx context.Context, q *domain.Queries, userID, cartID, shippingAddressID, billingAddressID int64, currency string, rate float64, forUpdate bool) (*checkout, error) {
+	// 1. Get cart items from the user's cart.
+	cartItems, err := q.CartRepo.GetItemsByCartID(ctx, cartID)
+	if err != nil {
+		s.logger.Error("failed to get cart items for checkout", "cart_id", cartID, "error", err)
+		return nil, fmt.Errorf("could not retrieve cart for order: %w", err)
+	}
+	if len(cartItems) == 0 {
+		return nil, apperrors.ErrEmptyCart
+	}
+
+	// 2. Fetch and validate addresses.
+	shippingAddr, err := q.AddressRepo.GetByID(ctx, shippingAddressID)
+	if err != nil {
+		return nil, fmt.Errorf("shipping address not found: %w", err)
+	}
+	if shippingAddr.UserID != userID {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	billingAddr, err := q.AddressRepo.GetByID(ctx, billingAddressID)
+	if err != nil {
+		return nil, fmt.Errorf("billing address not found: %w", err)
+	}
+	if billingAddr.UserID != userID {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	// 3. Lock products (and chosen variants), validate stock, and calculate totals.
+	// The same product can appear on several lines, one per variant, so the
+	// snapshots are kept per line rather than per product. Every line is priced at
+	// the same moment, so a sale that starts or ends mid-checkout applies to all or none.
+	// Lines are converted to the order currency and rounded before they are summed,
+	// so the total always matches the lines the customer sees.
+	c := &checkout{
+		ShippingAddress: shippingAddr,
+		BillingAddress:  billingAddr,
+		Items:           make([]*models.OrderItem, 0, len(cartItems)),
+		Currency:        currency,
+		ExchangeRate:    rate,
+		PricedAt:        time.Now(),
+	}
+	couponLines := make([]coupon.Line, 0, len(cartItems))
+
+	for _, item := range cartItems {
+		orderItem, err := s.priceOrderItem(ctx, q, item, c.PricedAt, forUpdate)
+		if err != nil {
+			return nil, err
+		}
+		convertOrderItem(orderItem, currency, rate)
+		c.Subtotal += orderItem.TotalPrice
+		c.Items = append(c.Items, orderItem)
+		couponLines = append(couponLines, coupon.Line{ProductID: orderItem.ProductID, CategoryID: item.Product.CategoryID, Amount: orderItem.TotalPrice})
+	}
+
+	// Pick the one warehouse that ships the whole order; its stock is what gets reserved.
+	c.Warehouse, err = warehouse.Allocate(ctx, q, s.stockConfig.AllocationStrategy, shippingAddr, c.Items)
+	if err != nil {
+		s.logger.Warn("no warehouse can fulfil order", "cart_id", cartID, "error", err)
+		return nil, err
+	}
+
+	// 4. The cart's coupons are checked again, with their rows locked when ordering, so
+	// usage limits hold however many checkouts race for the last use. A coupon that can
+	// no longer be used fails the checkout rather than quietly raising the price.
+	var coupons []*models.Coupon
+	if forUpdate {
+		coupons, err = q.CouponRepo.ListByCartForUpdate(ctx, cartID)
+	} else {
+		coupons, err = q.CouponRepo.ListByCart(ctx, cartID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve cart coupons: %w", err)
+	}
+	c.Coupons, err = coupon.Evaluate(ctx, q.CouponRepo, coupons, &userID, couponLines, rate, c.PricedAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not price coupons: %w", err)
+	}
+	for i := range c.Coupons {
+		applied := &c.Coupons[i]
+		if applied.Err != nil {
+			return nil, fmt.Errorf("coupon %s: %w", applied.Code, applied.Err)
+		}
+		applied.Amount = money.Round(applied.Amount, currency)
+		c.DiscountAmount += applied.Amount
+	}
+
+	// 5. Calculate tax and shipping. Shipping is configured in the base currency.
+	c.Subtotal = money.Round(c.Subtotal, currency)
+	c.TaxAmount = money.Round(c.Subtotal*s.config.OrderTaxRate, currency)
+	c.ShippingCost = money.Round(s.config.OrderShippingCost*rate, currency)
+	c.DiscountAmount = money.Round(c.DiscountAmount, currency)
+	c.TotalAmount = money.Round(max(c.Subtotal+c.TaxAmount+c.ShippingCost-c.DiscountAmount, 0), currency)
+	return c, nil
+}
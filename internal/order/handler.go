@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/purushothdl/ecommerce-api/configs"
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/shared/context"
 	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
@@ -62,9 +63,7 @@ func (h *Handler) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
 
 	paymentIntent, err := h.orderService.CreateOrder(r.Context(), userID, cartCtx.ID, &req)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrInsufficientStock) {
-			response.Error(w, http.StatusConflict, err.Error())
-		} else {
+		if !writeCheckoutError(w, err) {
 			h.logger.Error("failed to create order", "user_id", userID, "error", err)
 			response.Error(w, http.StatusInternalServerError, "Could not create order")
 		}
@@ -74,6 +73,60 @@ func (h *Handler) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusCreated, paymentIntent)
 }
 
+// HandleCheckoutQuote prices the cart for the chosen addresses exactly as placing the
+// order would, without creating anything.
+func (h *Handler) HandleCheckoutQuote(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	cartCtx, err := context.GetCart(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Cart not found in context")
+		return
+	}
+
+	var req dto.CheckoutQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCheckoutQuoteRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	quote, err := h.orderService.QuoteCheckout(r.Context(), userID, cartCtx.ID, &req)
+	if err != nil {
+		if !writeCheckoutError(w, err) {
+			h.logger.Error("failed to quote checkout", "user_id", userID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "Could not price checkout")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, quote)
+}
+
+// writeCheckoutError answers for the errors ordering and quoting share, which are the
+// customer's to fix. It reports false for anything else.
+func writeCheckoutError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, apperrors.ErrInsufficientStock) || errors.Is(err, apperrors.ErrProductUnavailable):
+		response.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, apperrors.ErrUnsupportedCurrency) || errors.Is(err, apperrors.ErrEmptyCart) || coupon.IsCouponProblem(err):
+		response.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		return false
+	}
+	return true
+}
+
 func (h *Handler) HandleStripeWebhook(w http.ResponseWriter, r *http.Request) {
 	const MaxBodyBytes = int64(65536)
 	r.Body = http.MaxBytesReader(w, r.Body, MaxBodyBytes)
@@ -25,14 +25,14 @@ func (r *orderRepository) Create(ctx context.Context, order *models.Order) error
         INSERT INTO orders (
             user_id, order_number, status, payment_status, payment_method, payment_intent_id,
             shipping_address, billing_address, subtotal, tax_amount, shipping_cost, discount_amount, total_amount,
-            notes, tracking_number, estimated_delivery_date
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+            notes, tracking_number, estimated_delivery_date, warehouse_id, currency, exchange_rate
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
         RETURNING id, created_at, updated_at
     `
     err := r.db.QueryRowContext(ctx, query,
         order.UserID, order.OrderNumber, order.Status, order.PaymentStatus, order.PaymentMethod, order.PaymentIntentID,
         order.ShippingAddress, order.BillingAddress, order.Subtotal, order.TaxAmount, order.ShippingCost, order.DiscountAmount, order.TotalAmount,
-        order.Notes, order.TrackingNumber, order.EstimatedDeliveryDate,
+        order.Notes, order.TrackingNumber, order.EstimatedDeliveryDate, order.WarehouseID, order.Currency, order.ExchangeRate,
     ).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
     if err != nil {
         return fmt.Errorf("failed to create order: %w", err)
@@ -45,13 +45,23 @@ func (r *orderRepository) CreateItems(ctx context.Context, items []*models.Order
         query := `
             INSERT INTO order_items (
                 order_id, product_id, product_name, product_sku, product_image,
-                unit_price, quantity, total_price
-            ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+                unit_price, quantity, total_price, variant_id, variant_options,
+                price_rule, price_schedule_id, compare_at_price
+            ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
             RETURNING id, created_at
         `
+        // A nil RawMessage would be sent as an empty string, which is not valid jsonb.
+        var variantOptions any
+        if item.VariantOptions != nil {
+            variantOptions = item.VariantOptions
+        }
+        if item.PriceRule == "" {
+            item.PriceRule = models.PriceRuleBase
+        }
         err := r.db.QueryRowContext(ctx, query,
             item.OrderID, item.ProductID, item.ProductName, item.ProductSKU, item.ProductImage,
-            item.UnitPrice, item.Quantity, item.TotalPrice,
+            item.UnitPrice, item.Quantity, item.TotalPrice, item.VariantID, variantOptions,
+            item.PriceRule, item.PriceScheduleID, item.CompareAtPrice,
         ).Scan(&item.ID, &item.CreatedAt)
         if err != nil {
             return fmt.Errorf("failed to create order item: %w", err)
@@ -64,14 +74,14 @@ func (r *orderRepository) GetByID(ctx context.Context, id int64, userID int64) (
     query := `
         SELECT id, user_id, order_number, status, payment_status, payment_method, payment_intent_id,
                shipping_address, billing_address, subtotal, tax_amount, shipping_cost, discount_amount, total_amount,
-               notes, tracking_number, estimated_delivery_date, created_at, updated_at
+               notes, tracking_number, estimated_delivery_date, warehouse_id, currency, exchange_rate, created_at, updated_at
         FROM orders WHERE id = $1 AND user_id = $2
     `
     order := &models.Order{}
     err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
         &order.ID, &order.UserID, &order.OrderNumber, &order.Status, &order.PaymentStatus, &order.PaymentMethod, &order.PaymentIntentID,
         &order.ShippingAddress, &order.BillingAddress, &order.Subtotal, &order.TaxAmount, &order.ShippingCost, &order.DiscountAmount, &order.TotalAmount,
-        &order.Notes, &order.TrackingNumber, &order.EstimatedDeliveryDate, &order.CreatedAt, &order.UpdatedAt,
+        &order.Notes, &order.TrackingNumber, &order.EstimatedDeliveryDate, &order.WarehouseID, &order.Currency, &order.ExchangeRate, &order.CreatedAt, &order.UpdatedAt,
     )
     if err == sql.ErrNoRows {
         return nil, apperrors.ErrNotFound
@@ -84,7 +94,8 @@ func (r *orderRepository) GetByID(ctx context.Context, id int64, userID int64) (
 func (r *orderRepository) GetItemsByOrderID(ctx context.Context, orderID int64) ([]*models.OrderItem, error) {
     query := `
         SELECT id, order_id, product_id, product_name, product_sku, product_image,
-               unit_price, quantity, total_price, created_at
+               unit_price, quantity, total_price, created_at, variant_id, variant_options,
+               price_rule, price_schedule_id, compare_at_price
         FROM order_items WHERE order_id = $1
     `
     rows, err := r.db.QueryContext(ctx, query, orderID)
@@ -96,12 +107,15 @@ func (r *orderRepository) GetItemsByOrderID(ctx context.Context, orderID int64)
     var items []*models.OrderItem
     for rows.Next() {
         item := &models.OrderItem{}
+        var variantOptions []byte
         if err := rows.Scan(
             &item.ID, &item.OrderID, &item.ProductID, &item.ProductName, &item.ProductSKU, &item.ProductImage,
-            &item.UnitPrice, &item.Quantity, &item.TotalPrice, &item.CreatedAt,
+            &item.UnitPrice, &item.Quantity, &item.TotalPrice, &item.CreatedAt, &item.VariantID, &variantOptions,
+            &item.PriceRule, &item.PriceScheduleID, &item.CompareAtPrice,
         ); err != nil {
             return nil, fmt.Errorf("failed to scan order item: %w", err)
         }
+        item.VariantOptions = variantOptions
         items = append(items, item)
     }
     return items, nil
@@ -111,7 +125,7 @@ func (r *orderRepository) GetByUserID(ctx context.Context, userID int64) ([]*mod
     query := `
         SELECT id, user_id, order_number, status, payment_status, payment_method, payment_intent_id,
                shipping_address, billing_address, subtotal, tax_amount, shipping_cost, discount_amount, total_amount,
-               notes, tracking_number, estimated_delivery_date, created_at, updated_at
+               notes, tracking_number, estimated_delivery_date, warehouse_id, currency, exchange_rate, created_at, updated_at
         FROM orders WHERE user_id = $1 ORDER BY created_at DESC
     `
     rows, err := r.db.QueryContext(ctx, query, userID)
@@ -126,7 +140,7 @@ func (r *orderRepository) GetByUserID(ctx context.Context, userID int64) ([]*mod
         if err := rows.Scan(
             &order.ID, &order.UserID, &order.OrderNumber, &order.Status, &order.PaymentStatus, &order.PaymentMethod, &order.PaymentIntentID,
             &order.ShippingAddress, &order.BillingAddress, &order.Subtotal, &order.TaxAmount, &order.ShippingCost, &order.DiscountAmount, &order.TotalAmount,
-            &order.Notes, &order.TrackingNumber, &order.EstimatedDeliveryDate, &order.CreatedAt, &order.UpdatedAt,
+            &order.Notes, &order.TrackingNumber, &order.EstimatedDeliveryDate, &order.WarehouseID, &order.Currency, &order.ExchangeRate, &order.CreatedAt, &order.UpdatedAt,
         ); err != nil {
             return nil, fmt.Errorf("failed to scan order: %w", err)
         }
@@ -142,7 +156,8 @@ func (r *orderRepository) GetByPaymentIntentID(ctx context.Context, paymentInten
             id, user_id, order_number, status, payment_status, payment_method, 
             payment_intent_id, shipping_address, billing_address, subtotal, 
             tax_amount, shipping_cost, discount_amount, total_amount, notes, 
-            tracking_number, estimated_delivery_date, created_at, updated_at
+            tracking_number, estimated_delivery_date, warehouse_id, currency, exchange_rate,
+            created_at, updated_at
         FROM orders 
         WHERE payment_intent_id = $1`
 
@@ -152,7 +167,7 @@ func (r *orderRepository) GetByPaymentIntentID(ctx context.Context, paymentInten
 		&order.PaymentMethod, &order.PaymentIntentID, &order.ShippingAddress, &order.BillingAddress,
 		&order.Subtotal, &order.TaxAmount, &order.ShippingCost, &order.DiscountAmount,
 		&order.TotalAmount, &order.Notes, &order.TrackingNumber, &order.EstimatedDeliveryDate,
-		&order.CreatedAt, &order.UpdatedAt,
+		&order.WarehouseID, &order.Currency, &order.ExchangeRate, &order.CreatedAt, &order.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -213,14 +228,14 @@ func (r *orderRepository) GetByIDForUpdate(ctx context.Context, id int64, userID
 	query := `
         SELECT id, user_id, order_number, status, payment_status, payment_method, payment_intent_id,
                shipping_address, billing_address, subtotal, tax_amount, shipping_cost, discount_amount, total_amount,
-               notes, tracking_number, estimated_delivery_date, created_at, updated_at
+               notes, tracking_number, estimated_delivery_date, warehouse_id, currency, exchange_rate, created_at, updated_at
         FROM orders WHERE id = $1 AND user_id = $2 FOR UPDATE
     `
 	order := &models.Order{}
 	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
 		&order.ID, &order.UserID, &order.OrderNumber, &order.Status, &order.PaymentStatus, &order.PaymentMethod, &order.PaymentIntentID,
 		&order.ShippingAddress, &order.BillingAddress, &order.Subtotal, &order.TaxAmount, &order.ShippingCost, &order.DiscountAmount, &order.TotalAmount,
-		&order.Notes, &order.TrackingNumber, &order.EstimatedDeliveryDate, &order.CreatedAt, &order.UpdatedAt,
+		&order.Notes, &order.TrackingNumber, &order.EstimatedDeliveryDate, &order.WarehouseID, &order.Currency, &order.ExchangeRate, &order.CreatedAt, &order.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, apperrors.ErrNotFound
@@ -245,7 +260,7 @@ func (r *orderRepository) GetOrderByID(ctx context.Context, id int64) (*models.O
 
 func (r *orderRepository) FindPendingOrdersOlderThan(ctx context.Context, olderThan time.Time) ([]*models.Order, error) {
 	query := `
-        SELECT id, user_id, order_number, status, payment_status, payment_intent_id, created_at
+        SELECT id, user_id, order_number, status, payment_status, payment_intent_id, warehouse_id, created_at
         FROM orders 
         WHERE status = $1 AND created_at < $2
     `
@@ -265,6 +280,7 @@ func (r *orderRepository) FindPendingOrdersOlderThan(ctx context.Context, olderT
 			&order.Status,
 			&order.PaymentStatus,
 			&order.PaymentIntentID,
+			&order.WarehouseID,
 			&order.CreatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("order repo: failed to scan pending order row: %w", err)
@@ -11,6 +11,18 @@ func ValidateCreateOrderRequest(r dto.CreateOrderRequest, v *validator.Validator
     v.Check(r.ShippingAddressID > 0, "shipping_address_id", "must be a valid address ID")
     v.Check(r.BillingAddressID > 0, "billing_address_id", "must be a valid address ID")
     v.Check(validator.NotBlank(r.PaymentMethod), "payment_method", "must be provided")
+    if r.Currency != "" {
+        v.Check(validator.Matches(r.Currency, validator.CurrencyRX), "currency", "must be a three-letter ISO currency code")
+    }
+}
+
+// ValidateCheckoutQuoteRequest validates the checkout quote request
+func ValidateCheckoutQuoteRequest(r dto.CheckoutQuoteRequest, v *validator.Validator) {
+    v.Check(r.ShippingAddressID > 0, "shipping_address_id", "must be a valid address ID")
+    v.Check(r.BillingAddressID > 0, "billing_address_id", "must be a valid address ID")
+    if r.Currency != "" {
+        v.Check(validator.Matches(r.Currency, validator.CurrencyRX), "currency", "must be a three-letter ISO currency code")
+    }
 }
 
 // ValidateConfirmPaymentRequest validates the confirm payment request
@@ -3,7 +3,6 @@ package order
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -11,10 +10,14 @@ import (
 	"github.com/purushothdl/ecommerce-api/configs"
 	"github.com/purushothdl/ecommerce-api/events"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/inventory"
 	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/pricing"
 	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
 	"github.com/purushothdl/ecommerce-api/internal/shared/tasks"
+	"github.com/purushothdl/ecommerce-api/pkg/cache"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/money"
 	"github.com/purushothdl/ecommerce-api/pkg/utils/jsonutil"
 	"github.com/purushothdl/ecommerce-api/pkg/utils/orders"
 	"github.com/purushothdl/ecommerce-api/pkg/utils/timeutil"
@@ -26,16 +29,26 @@ type orderService struct {
 	taskCreator    *tasks.TaskCreator
 	logger         *slog.Logger
 	config         *configs.OrderFinancialsConfig
+	stockConfig    configs.InventoryConfig
+	rates          *money.Rates
+	catalogCache   *cache.Cache
 }
 
-// NewOrderService creates a new OrderService
-func NewOrderService(store domain.Store, paymentService domain.PaymentService, taskCreator *tasks.TaskCreator, logger *slog.Logger, config *configs.OrderFinancialsConfig) domain.OrderService {
+// NewOrderService creates a new OrderService. stockConfig sets how long checkout holds
+// stock for an unpaid order and how it picks the warehouse, and rates converts base
+// currency prices into the currency an order is placed in; both are only needed by
+// callers that create orders. catalogCache, when set, is flushed after a payment moves
+// stock so catalog reads show the new levels.
+func NewOrderService(store domain.Store, paymentService domain.PaymentService, taskCreator *tasks.TaskCreator, logger *slog.Logger, config *configs.OrderFinancialsConfig, stockConfig configs.InventoryConfig, rates *money.Rates, catalogCache *cache.Cache) domain.OrderService {
 	return &orderService{
 		store:          store,
 		paymentService: paymentService,
 		taskCreator:    taskCreator, 
 		logger:         logger,
 		config:         config,
+		stockConfig:    stockConfig,
+		rates:          rates,
+		catalogCache:   catalogCache,
 	}
 }
 
@@ -44,69 +57,30 @@ func NewOrderService(store domain.Store, paymentService domain.PaymentService, t
 func (s *orderService) CreateOrder(ctx context.Context, userID int64, cartID int64, req *dto.CreateOrderRequest) (*dto.CreateOrderResponse, error) {
 	var response *dto.CreateOrderResponse
 
-	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
-		// 1. Get cart items from the user's cart in context.
-		cartItems, err := q.CartRepo.GetItemsByCartID(ctx, cartID)
-		if err != nil {
-			s.logger.Error("failed to get cart items for order creation", "cart_id", cartID, "error", err)
-			return fmt.Errorf("could not retrieve cart for order: %w", err)
-		}
-		if len(cartItems) == 0 {
-			return errors.New("cannot create an order from an empty cart")
-		}
-
-		// 2. Fetch and validate addresses.
-		shippingAddr, err := q.AddressRepo.GetByID(ctx, req.ShippingAddressID)
-		if err != nil {
-			return fmt.Errorf("shipping address not found: %w", err)
-		}
-		if shippingAddr.UserID != userID {
-			return apperrors.ErrUnauthorized 
-		}
+	// Prices are kept in the base currency and converted at the configured rate.
+	currency, rate, err := s.resolveCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
 
-		billingAddr, err := q.AddressRepo.GetByID(ctx, req.BillingAddressID)
+	err = s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		// 1-5. Lock and price the cart, exactly as a checkout quote does.
+		priced, err := s.priceCheckout(ctx, q, userID, cartID, req.ShippingAddressID, req.BillingAddressID, currency, rate, true)
 		if err != nil {
-			return fmt.Errorf("billing address not found: %w", err)
-		}
-		if billingAddr.UserID != userID {
-			return apperrors.ErrUnauthorized 
-		}
-		
-		// 3. Lock products, validate stock, and calculate totals.
-		var subtotal float64
-		productSnapshots := make(map[int64]*models.Product)
-
-		for _, item := range cartItems {
-			product, err := q.ProductRepo.GetByIDForUpdate(ctx, item.Product.ID)
-			if err != nil {
-				return fmt.Errorf("product with ID %d not found: %w", item.Product.ID, err)
-			}
-			if product.StockQuantity < item.Quantity {
-				return fmt.Errorf("insufficient stock for %s. available: %d, requested: %d", product.Name, product.StockQuantity, item.Quantity)
-			}
-			subtotal += product.Price * float64(item.Quantity)
-			productSnapshots[item.Product.ID] = product
+			return err
 		}
 
-		// Calculate tax, shipping, and discount amounts
-		taxAmount := subtotal * s.config.OrderTaxRate
-		shippingCost := s.config.OrderShippingCost
-		discountAmount := s.config.OrderDiscountAmount
-        
-        // Calculate total amount
-        totalAmount := max(subtotal + taxAmount + shippingCost - discountAmount, 0)
-
-		// 4. Create Stripe Payment Intent.
-		stripePI, err := s.paymentService.CreatePaymentIntent(ctx, totalAmount)
+		// 6. Create Stripe Payment Intent.
+		stripePI, err := s.paymentService.CreatePaymentIntent(ctx, priced.TotalAmount, currency)
 		if err != nil {
 			s.logger.Error("failed to create stripe payment intent", "error", err)
 			return fmt.Errorf("payment provider error: %w", err)
 		}
 
-		// 5. Create the main Order record.
+		// 7. Create the main Order record.
         // Marshal address structs to JSONB
-        shippingJSON, _ := json.Marshal(orders.ToOrderAddress(shippingAddr))
-        billingJSON, _ := json.Marshal(orders.ToOrderAddress(billingAddr))
+        shippingJSON, _ := json.Marshal(orders.ToOrderAddress(priced.ShippingAddress))
+        billingJSON, _ := json.Marshal(orders.ToOrderAddress(priced.BillingAddress))
 
 		defaultEDD := timeutil.CalculateEDD(time.Now(), 4)
 		
@@ -117,39 +91,28 @@ func (s *orderService) CreateOrder(ctx context.Context, userID int64, cartID int
 			PaymentStatus:         models.PaymentStatusPending,
 			PaymentMethod:         req.PaymentMethod,
 			PaymentIntentID:       stripePI.ID,
-			Subtotal:              subtotal,
-			TaxAmount:             taxAmount,
-			ShippingCost:          shippingCost,
-			DiscountAmount:        discountAmount,
-			TotalAmount:           totalAmount,
+			Subtotal:              priced.Subtotal,
+			TaxAmount:             priced.TaxAmount,
+			ShippingCost:          priced.ShippingCost,
+			DiscountAmount:        priced.DiscountAmount,
+			TotalAmount:           priced.TotalAmount,
+			Currency:              currency,
+			ExchangeRate:          rate,
 			ShippingAddress:       json.RawMessage(shippingJSON),
 			BillingAddress:        json.RawMessage(billingJSON),
 			EstimatedDeliveryDate: defaultEDD,
+			WarehouseID:           &priced.Warehouse.ID,
 		}
 		if err := q.OrderRepo.Create(ctx, order); err != nil {
 			s.logger.Error("failed to save order", "error", err)
 			return fmt.Errorf("could not save order: %w", err)
 		}
 
-		// 6. Create Order Items and update stock.
-		var orderItemsToCreate []*models.OrderItem
-		for _, item := range cartItems {
-			product := productSnapshots[item.Product.ID]
-			orderItem := &models.OrderItem{
-				OrderID:     order.ID,
-				ProductID:   product.ID,
-				ProductName: product.Name,
-				ProductSKU:  product.SKU,
-				UnitPrice:   product.Price,
-				Quantity:    item.Quantity,
-				TotalPrice:  product.Price * float64(item.Quantity),
-			}
-            orderItemsToCreate = append(orderItemsToCreate, orderItem)
-
-			// Decrement stock
-			if err := q.ProductRepo.UpdateStock(ctx, product.ID, -item.Quantity); err != nil {
-				return fmt.Errorf("failed to update stock for product %d: %w", product.ID, err)
-			}
+		// 8. Create Order Items and reserve their stock. Stock is only decremented
+		// once payment succeeds; until then the reservation keeps others from buying it.
+		orderItemsToCreate := priced.Items
+		for _, orderItem := range orderItemsToCreate {
+			orderItem.OrderID = order.ID
 		}
 
         if err := q.OrderRepo.CreateItems(ctx, orderItemsToCreate); err != nil {
@@ -157,17 +120,56 @@ func (s *orderService) CreateOrder(ctx context.Context, userID int64, cartID int
             return fmt.Errorf("could not save order items: %w", err)
         }
 
-		// 7. Clear the cart.
+		reservedUntil := time.Now().Add(s.stockConfig.ReservationTTL)
+		for _, orderItem := range orderItemsToCreate {
+			reservation := &models.InventoryReservation{
+				OrderID:     order.ID,
+				OrderItemID: orderItem.ID,
+				ProductID:   orderItem.ProductID,
+				VariantID:   orderItem.VariantID,
+				WarehouseID: &priced.Warehouse.ID,
+				Quantity:    orderItem.Quantity,
+				ExpiresAt:   reservedUntil,
+			}
+			if err := q.ReservationRepo.Create(ctx, reservation); err != nil {
+				return fmt.Errorf("failed to reserve stock for product %d: %w", orderItem.ProductID, err)
+			}
+		}
+
+		// Record the coupons used, which now count against their limits.
+		for _, applied := range priced.Coupons {
+			redemption := &models.CouponRedemption{
+				CouponID: applied.CouponID,
+				OrderID:  order.ID,
+				UserID:   userID,
+				Amount:   applied.Amount,
+				Currency: currency,
+			}
+			if err := q.CouponRepo.Redeem(ctx, redemption); err != nil {
+				return fmt.Errorf("failed to redeem coupon %s: %w", applied.Code, err)
+			}
+		}
+
+		// 9. Clear the cart and its coupons.
 		if err := q.CartRepo.ClearCart(ctx, cartID); err != nil {
 			return fmt.Errorf("failed to clear cart: %w", err)
 		}
+		if err := q.CouponRepo.ClearCart(ctx, cartID); err != nil {
+			return fmt.Errorf("failed to clear cart coupons: %w", err)
+		}
+		// Credit any abandoned cart reminders the customer was sent with the order.
+		if err := q.CartReminderRepo.MarkConverted(ctx, cartID, order.ID); err != nil {
+			return fmt.Errorf("failed to record cart reminder conversion: %w", err)
+		}
 
-		// 8. Set the response object to be returned by the outer function.
+		// 10. Set the response object to be returned by the outer function.
 		response = &dto.CreateOrderResponse{
-			OrderID:      order.ID,
-			OrderNumber:  order.OrderNumber,
-			ClientSecret: stripePI.ClientSecret,
-			TotalAmount:  order.TotalAmount,
+			OrderID:       order.ID,
+			OrderNumber:   order.OrderNumber,
+			ClientSecret:  stripePI.ClientSecret,
+			TotalAmount:   order.TotalAmount,
+			Currency:      order.Currency,
+			ReservedUntil: reservedUntil,
 		}
 		return nil
 	})
@@ -175,11 +177,273 @@ func (s *orderService) CreateOrder(ctx context.Context, userID int64, cartID int
 	return response, err
 }
 
+// QuoteCheckout prices the cart the way CreateOrder would charge it right now, without
+// creating a payment, an order or any reservation. It runs the same calculation in a
+// read-only transaction without locking any rows, so quotes cannot block checkouts.
+func (s *orderService) QuoteCheckout(ctx context.Context, userID int64, cartID int64, req *dto.CheckoutQuoteRequest) (*dto.CheckoutQuoteResponse, error) {
+	currency, rate, err := s.resolveCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var priced *checkout
+	err = s.store.ExecReadOnlyTx(ctx, func(q *domain.Queries) error {
+		var txErr error
+		priced, txErr = s.priceCheckout(ctx, q, userID, cartID, req.ShippingAddressID, req.BillingAddressID, currency, rate, false)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]dto.CheckoutQuoteLine, len(priced.Items))
+	for i, item := range priced.Items {
+		lines[i] = dto.CheckoutQuoteLine{
+			ProductID:      item.ProductID,
+			ProductName:    item.ProductName,
+			ProductSKU:     item.ProductSKU,
+			VariantID:      item.VariantID,
+			VariantOptions: item.VariantOptions,
+			UnitPrice:      item.UnitPrice,
+			CompareAtPrice: item.CompareAtPrice,
+			PriceRule:      item.PriceRule,
+			Quantity:       item.Quantity,
+			TotalPrice:     item.TotalPrice,
+		}
+	}
+	return &dto.CheckoutQuoteResponse{
+		Items:          lines,
+		Coupons:        priced.Coupons,
+		Subtotal:       priced.Subtotal,
+		TaxAmount:      priced.TaxAmount,
+		ShippingCost:   priced.ShippingCost,
+		DiscountAmount: priced.DiscountAmount,
+		TotalAmount:    priced.TotalAmount,
+		Currency:       priced.Currency,
+		PricedAt:       priced.PricedAt,
+	}, nil
+}
+
+
+// priceOrderItem checks availability for a cart line and returns the order line
+// snapshot, locking the stock rows behind it when forUpdate is set. Variant lines take
+// SKU, price and stock from the variant; the product row is still read first so lock
+// order is always product, variant.
+// Stock reserved by other unpaid orders is not available. The unit price is the one in
+// effect at pricedAt, and the line records whether a sale set it.
+func (s *orderService) priceOrderItem(ctx context.Context, q *domain.Queries, item models.CartItem, pricedAt time.Time, forUpdate bool) (*models.OrderItem, error) {
+	getProduct, getVariant := q.ProductRepo.GetByID, q.VariantRepo.GetByID
+	if forUpdate {
+		getProduct, getVariant = q.ProductRepo.GetByIDForUpdate, q.VariantRepo.GetByIDForUpdate
+	}
+
+	product, err := getProduct(ctx, item.Product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("product with ID %d not found: %w", item.Product.ID, err)
+	}
+	if !product.IsPurchasable() {
+		return nil, fmt.Errorf("%s is no longer available: %w", product.Name, apperrors.ErrProductUnavailable)
+	}
+
+	orderItem := &models.OrderItem{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		ProductSKU:  product.SKU,
+		Quantity:    item.Quantity,
+	}
+	basePrice := product.BasePrice
+	available := product.StockQuantity
+
+	if item.Variant != nil {
+		variant, err := getVariant(ctx, item.Variant.ID)
+		if err != nil {
+			return nil, fmt.Errorf("variant with ID %d not found: %w", item.Variant.ID, err)
+		}
+		orderItem.VariantID = &variant.ID
+		orderItem.VariantOptions = variant.Options
+		orderItem.ProductSKU = variant.SKU
+		basePrice = variant.BasePrice
+		available = variant.StockQuantity
+	}
+
+	price, err := pricing.Resolve(ctx, q, product.ID, orderItem.VariantID, basePrice, pricedAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not price product %d: %w", product.ID, err)
+	}
+	orderItem.UnitPrice = price.Price
+	orderItem.CompareAtPrice = price.CompareAtPrice
+	orderItem.PriceRule = price.Rule
+	orderItem.PriceScheduleID = price.PriceScheduleID
+
+	reserved, err := q.ReservationRepo.ReservedStock(ctx, product.ID, orderItem.VariantID)
+	if err != nil {
+		return nil, err
+	}
+	available -= reserved
+
+	if available < item.Quantity {
+		return nil, fmt.Errorf("insufficient stock for %s. available: %d, requested: %d: %w", product.Name, available, item.Quantity, apperrors.ErrInsufficientStock)
+	}
+
+	orderItem.TotalPrice = orderItem.UnitPrice * float64(item.Quantity)
+	return orderItem, nil
+}
+
+// convertOrderItem restates a line priced in the base currency in the order currency,
+// rounding the unit price first so the line total is an exact multiple of it.
+func convertOrderItem(item *models.OrderItem, currency string, rate float64) {
+	item.UnitPrice = money.Round(item.UnitPrice*rate, currency)
+	if item.CompareAtPrice != nil {
+		compareAt := money.Round(*item.CompareAtPrice*rate, currency)
+		item.CompareAtPrice = &compareAt
+	}
+	item.TotalPrice = money.Round(item.UnitPrice*float64(item.Quantity), currency)
+}
+
+// adjustItemStock applies a stock change for an order line to the variant it was
+// bought as, or to the product when it had no variant, at the given warehouse (the
+// default one when nil), and records it against the order.
+func adjustItemStock(ctx context.Context, q *domain.Queries, item *models.OrderItem, warehouseID *int64, quantityChange int, reason models.MovementReason, actorID *int64) error {
+	return inventory.AdjustStock(ctx, q, &models.InventoryMovement{
+		ProductID:   item.ProductID,
+		VariantID:   item.VariantID,
+		WarehouseID: warehouseID,
+		Delta:       quantityChange,
+		Reason:      reason,
+		ReferenceID: &item.OrderID,
+		ActorID:     actorID,
+	})
+}
+
+// commitOrderStock turns an order's reservations into real stock decrements at the
+// reserving warehouse once it is paid. A reservation that lapsed before payment arrived
+// no longer holds stock, so its stock is taken now instead, provided the warehouse still
+// has it. If any lapsed line can no longer be filled nothing is taken, the reservations
+// are released and false is returned. Orders placed before reservations existed already
+// had their stock decremented at checkout and are left alone.
+func (s *orderService) commitOrderStock(ctx context.Context, q *domain.Queries, order *models.Order, items []*models.OrderItem) (bool, error) {
+	orderID := order.ID
+	reservations, err := q.ReservationRepo.ListByOrderIDForUpdate(ctx, orderID)
+	if err != nil {
+		return false, err
+	}
+	byItem := make(map[int64]models.InventoryReservation, len(reservations))
+	for _, r := range reservations {
+		byItem[r.OrderItemID] = r
+	}
+
+	// Check every lapsed line before taking anything, so an order that cannot be
+	// filled leaves stock untouched.
+	for _, item := range items {
+		reservation, ok := byItem[item.ID]
+		if !ok || reservation.Status == models.ReservationStatusCommitted {
+			continue
+		}
+		if reservation.Status != models.ReservationStatusReleased && reservation.ExpiresAt.After(time.Now()) {
+			continue
+		}
+		s.logger.Warn("payment arrived after stock reservation lapsed", "order_id", orderID, "order_item_id", item.ID)
+		available, err := availableForItem(ctx, q, item, stockLocation(reservation.WarehouseID, order))
+		if err != nil {
+			return false, err
+		}
+		if available < item.Quantity {
+			s.logger.Warn("lapsed order line can no longer be filled", "order_id", orderID, "order_item_id", item.ID, "available", available, "quantity", item.Quantity)
+			_, err := q.ReservationRepo.ReleaseByOrderID(ctx, orderID)
+			return false, err
+		}
+	}
+
+	for _, item := range items {
+		reservation, ok := byItem[item.ID]
+		if !ok || reservation.Status == models.ReservationStatusCommitted {
+			continue
+		}
+		if err := adjustItemStock(ctx, q, item, stockLocation(reservation.WarehouseID, order), -item.Quantity, models.MovementReasonOrder, nil); err != nil {
+			return false, fmt.Errorf("failed to take stock for product %d: %w", item.ProductID, err)
+		}
+	}
+
+	return true, q.ReservationRepo.CommitByOrderID(ctx, orderID)
+}
+
+// availableForItem locks the rows behind an order line, in the same product-then-variant
+// order checkout uses, and returns how much of it the warehouse (the default one when
+// nil) can still sell once other orders' reservations are set aside.
+func availableForItem(ctx context.Context, q *domain.Queries, item *models.OrderItem, warehouseID *int64) (int, error) {
+	if _, err := q.ProductRepo.GetByIDForUpdate(ctx, item.ProductID); err != nil {
+		return 0, fmt.Errorf("product with ID %d not found: %w", item.ProductID, err)
+	}
+	if item.VariantID != nil {
+		if _, err := q.VariantRepo.GetByIDForUpdate(ctx, *item.VariantID); err != nil {
+			return 0, fmt.Errorf("variant with ID %d not found: %w", *item.VariantID, err)
+		}
+	}
+	if warehouseID == nil {
+		warehouse, err := q.WarehouseRepo.GetDefault(ctx)
+		if err != nil {
+			return 0, err
+		}
+		warehouseID = &warehouse.ID
+	}
+
+	stock, err := q.WarehouseStockRepo.ListAvailable(ctx, item.ProductID, item.VariantID)
+	if err != nil {
+		return 0, err
+	}
+	for _, st := range stock {
+		if st.WarehouseID == *warehouseID {
+			return st.Available, nil
+		}
+	}
+	return 0, nil
+}
+
+// restoreOrderStock undoes an order's hold on stock when it is cancelled. Active
+// reservations are simply released; stock that was actually taken, either by a
+// committed reservation or by a checkout from before reservations existed, is put back
+// and recorded with the given reason and actor at the warehouse it was taken from.
+func (s *orderService) restoreOrderStock(ctx context.Context, q *domain.Queries, order *models.Order, items []*models.OrderItem, reason models.MovementReason, actorID *int64) error {
+	orderID := order.ID
+	reservations, err := q.ReservationRepo.ListByOrderIDForUpdate(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	byItem := make(map[int64]models.InventoryReservation, len(reservations))
+	for _, r := range reservations {
+		byItem[r.OrderItemID] = r
+	}
+
+	for _, item := range items {
+		reservation, ok := byItem[item.ID]
+		if ok && reservation.Status != models.ReservationStatusCommitted {
+			continue
+		}
+		if err := adjustItemStock(ctx, q, item, stockLocation(reservation.WarehouseID, order), +item.Quantity, reason, actorID); err != nil {
+			return fmt.Errorf("failed to restock product %d: %w", item.ProductID, err)
+		}
+	}
+
+	_, err = q.ReservationRepo.ReleaseByOrderID(ctx, orderID)
+	return err
+}
+
+// stockLocation is the warehouse an order line's stock moves at: the reservation's,
+// else the order's. Orders from before warehouses have neither and use the default.
+func stockLocation(reservationWarehouseID *int64, order *models.Order) *int64 {
+	if reservationWarehouseID != nil {
+		return reservationWarehouseID
+	}
+	return order.WarehouseID
+}
 
 func (s *orderService) HandlePaymentSucceeded(ctx context.Context, paymentIntentID string) error {
 	var order *models.Order
 	var user *models.User
 	var orderItems []*models.OrderItem
+	var location *models.Warehouse
+	var onHold bool
+	var stockChanged bool
 
 	// The transaction ensures we only create the task if the DB update succeeds.
 	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
@@ -209,6 +473,31 @@ func (s *orderService) HandlePaymentSucceeded(ctx context.Context, paymentIntent
 			return txErr
 		}
 
+		filled, txErr := s.commitOrderStock(ctx, q, order, orderItems)
+		if txErr != nil {
+			s.logger.Error("CRITICAL: failed to commit reserved stock for paid order", "order_id", order.ID, "error", txErr)
+			return txErr
+		}
+		// Committing takes stock, and holding releases reservations; both change what
+		// the catalog shows as available.
+		stockChanged = true
+		if !filled {
+			// The payment is kept so the webhook is acknowledged and not retried; the
+			// order waits for a refund or for staff to fill it.
+			s.logger.Error("CRITICAL: paid order cannot be filled, held for refund or review", "order_id", order.ID, "pi_id", paymentIntentID)
+			onHold = true
+			return q.OrderRepo.UpdateStatus(ctx, order.ID, models.OrderStatusOnHold, models.PaymentStatusPaid, nil, nil)
+		}
+
+		// Tell the warehouse worker where to pick the order from.
+		if order.WarehouseID != nil {
+			location, txErr = q.WarehouseRepo.GetByID(ctx, *order.WarehouseID)
+			if txErr != nil {
+				s.logger.Error("failed to get allocated warehouse for event", "order_id", order.ID, "warehouse_id", *order.WarehouseID)
+				return txErr
+			}
+		}
+
 		s.logger.Info("updating order status to confirmed/paid", "order_id", order.ID, "pi_id", paymentIntentID)
 		// We pass nil for tracking and EDD as they are not available yet.
 		return q.OrderRepo.UpdateStatus(ctx, order.ID, models.OrderStatusConfirmed, models.PaymentStatusPaid, nil, nil)
@@ -217,9 +506,12 @@ func (s *orderService) HandlePaymentSucceeded(ctx context.Context, paymentIntent
 	if err != nil {
 		return err
 	}
+	if stockChanged && s.catalogCache != nil {
+		s.catalogCache.Flush()
+	}
 
 	// This happens *after* the transaction has successfully committed.
-	if order != nil && user != nil {
+	if order != nil && user != nil && !onHold {
 
 		// Map database order items to the event's OrderItemInfo struct.
 		// This now works because 'orderItems' was populated inside the transaction.
@@ -239,9 +531,13 @@ func (s *orderService) HandlePaymentSucceeded(ctx context.Context, paymentIntent
 			UserID:          order.UserID,
 			UserEmail:       user.Email,
 			TotalAmount:     order.TotalAmount,
+			Currency:        order.Currency,
 			OrderDate:       order.CreatedAt,
 			Items:           eventItems,
 		}
+		if location != nil {
+			fulfillmentEvent.Warehouse = &events.WarehouseInfo{ID: location.ID, Code: location.Code, Name: location.Name}
+		}
 
 		if err := s.taskCreator.CreateFulfillmentTask(ctx, "/handle/order-created", fulfillmentEvent); err != nil {
 			s.logger.Error("CRITICAL: failed to enqueue order fulfillment task", "order_id", order.ID, "error", err)
@@ -285,6 +581,7 @@ func (s *orderService) ListUserOrders(ctx context.Context, userID int64) ([]*dto
 			Status:        order.Status,
 			PaymentStatus: order.PaymentStatus,
 			TotalAmount:   order.TotalAmount,
+			Currency:      order.Currency,
 			CreatedAt:     order.CreatedAt,
 		})
 	}
@@ -329,7 +626,7 @@ func (s *orderService) CancelOrder(ctx context.Context, userID, orderID int64) e
 		}
 
 		// 2. Business Rule: Check if the order is in a cancellable state.
-		if order.Status != models.OrderStatusConfirmed && order.Status != models.OrderStatusProcessing && order.Status != models.OrderStatusPendingPayment{
+		if order.Status != models.OrderStatusConfirmed && order.Status != models.OrderStatusProcessing && order.Status != models.OrderStatusPendingPayment && order.Status != models.OrderStatusOnHold {
 			s.logger.Warn("attempt to cancel order with non-cancellable status", "order_id", order.ID, "status", order.Status)
 			return fmt.Errorf("order cannot be cancelled. status: %s", order.Status)
 		}
@@ -346,14 +643,17 @@ func (s *orderService) CancelOrder(ctx context.Context, userID, orderID int64) e
 			return fmt.Errorf("failed to get order items for restocking: %w", err)
 		}
 
-		// 5. Restock each product.
-		for _, item := range items {
-			if err := q.ProductRepo.UpdateStock(ctx, item.ProductID, +item.Quantity); err != nil {
-				return fmt.Errorf("failed to restock product %d: %w", item.ProductID, err)
-			}
+		// 5. Release reservations and restock anything already taken.
+		if err := s.restoreOrderStock(ctx, q, order, items, models.MovementReasonCancellation, &userID); err != nil {
+			return err
 		}
 
-		// 6. Update the order status to cancelled and payment status to refunded.
+		// 6. Give back the coupons it used.
+		if err := q.CouponRepo.ReleaseByOrderID(ctx, order.ID); err != nil {
+			return err
+		}
+
+		// 7. Update the order status to cancelled and payment status to refunded.
 		s.logger.Info("order cancelled and refunded successfully", "order_id", order.ID)
 		return q.OrderRepo.UpdateStatus(ctx, order.ID, models.OrderStatusCancelled, models.PaymentStatusRefunded, nil, nil)
 	})
@@ -384,6 +684,26 @@ func (s *orderService) UpdateOrderStatus(
 	})
 }
 
+// ReleaseExpiredReservations marks lapsed checkout reservations as released. Expired
+// reservations already stopped counting against available stock; this keeps the
+// reservation table accurate. The unpaid orders themselves are cancelled later by
+// CleanupPendingOrders, and can still be paid in the meantime if stock allows.
+func (s *orderService) ReleaseExpiredReservations(ctx context.Context) (int64, error) {
+	var released int64
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		var txErr error
+		released, txErr = q.ReservationRepo.ReleaseExpired(ctx)
+		return txErr
+	})
+	if err != nil {
+		s.logger.Error("failed to release expired reservations", "error", err)
+		return 0, fmt.Errorf("could not release expired reservations: %w", err)
+	}
+
+	s.logger.Info("released expired stock reservations", "count", released)
+	return released, nil
+}
+
 // CleanupPendingOrders finds and cancels 'pending_payment' orders older than 'olderThan',
 // and reverts their stock.
 func (s *orderService) CleanupPendingOrders(ctx context.Context, olderThan time.Duration) (int, error) {
@@ -422,15 +742,17 @@ func (s *orderService) CleanupPendingOrders(ctx context.Context, olderThan time.
 				return fmt.Errorf("failed to get items for order %d: %w", order.ID, itemErr)
 			}
 
-			// 2. Revert the stock for each item in the order.
-			for _, item := range orderItems {
-				// We add the quantity back to the product's stock.
-				if err := q.ProductRepo.UpdateStock(ctx, item.ProductID, item.Quantity); err != nil {
-					return fmt.Errorf("failed to revert stock for product %d in order %d: %w", item.ProductID, order.ID, err)
-				}
+			// 2. Release the order's reservations, restocking anything already taken.
+			if err := s.restoreOrderStock(ctx, q, order, orderItems, models.MovementReasonCleanup, nil); err != nil {
+				return fmt.Errorf("failed to revert stock for order %d: %w", order.ID, err)
+			}
+
+			// 3. Give back the coupons it used.
+			if err := q.CouponRepo.ReleaseByOrderID(ctx, order.ID); err != nil {
+				return fmt.Errorf("failed to release coupons for order %d: %w", order.ID, err)
 			}
 
-			// 3. Update the order's status to Cancelled and payment to Failed.
+			// 4. Update the order's status to Cancelled and payment to Failed.
 			cancelledPaymentStatus := models.PaymentStatusFailed
 			if err := q.OrderRepo.UpdateStatus(ctx, order.ID, models.OrderStatusCancelled, cancelledPaymentStatus, nil, nil); err != nil {
 				return fmt.Errorf("failed to update status for order %d: %w", order.ID, err)
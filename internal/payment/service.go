@@ -3,10 +3,12 @@ package payment
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/purushothdl/ecommerce-api/configs"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/money"
 	"github.com/stripe/stripe-go/v82"
 	"github.com/stripe/stripe-go/v82/paymentintent"
 	"github.com/stripe/stripe-go/v82/refund"
@@ -22,14 +24,13 @@ func NewStripeService(cfg configs.StripeConfig) domain.PaymentService {
 	return &stripeService{}
 }
 
-// CreatePaymentIntent creates a payment intent on Stripe.
-func (s *stripeService) CreatePaymentIntent(ctx context.Context, amount float64) (*dto.PaymentIntent, error) {
-	// Stripe expects the amount in the smallest currency unit (e.g., cents).
-	amountInPaise := int64(amount * 100)
-
+// CreatePaymentIntent creates a payment intent on Stripe for amount in the given currency.
+func (s *stripeService) CreatePaymentIntent(ctx context.Context, amount float64, currency string) (*dto.PaymentIntent, error) {
+	// Stripe expects the amount in the smallest currency unit (e.g., cents), and in
+	// whole units for zero-decimal currencies such as JPY.
 	params := &stripe.PaymentIntentParams{
-		Amount:   stripe.Int64(amountInPaise),
-		Currency: stripe.String(string(stripe.CurrencyINR)), 
+		Amount:   stripe.Int64(money.ToMinorUnits(amount, currency)),
+		Currency: stripe.String(strings.ToLower(money.Normalize(currency))),
 		AutomaticPaymentMethods: &stripe.PaymentIntentAutomaticPaymentMethodsParams{
 			Enabled: stripe.Bool(true),
 		},
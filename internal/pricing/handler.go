@@ -0,0 +1,140 @@
+// internal/pricing/handler.go
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	pricingSvc domain.PricingService
+	logger     *slog.Logger
+}
+
+func NewHandler(pricingSvc domain.PricingService, logger *slog.Logger) *Handler {
+	return &Handler{pricingSvc: pricingSvc, logger: logger}
+}
+
+func (h *Handler) HandleListSchedules(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	schedules, err := h.pricingSvc.ListSchedules(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "product not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "could not retrieve price schedules")
+		return
+	}
+	response.JSON(w, http.StatusOK, schedules)
+}
+
+func (h *Handler) HandleCreateSchedule(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.CreatePriceScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreatePriceScheduleRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	schedule, err := h.pricingSvc.CreateSchedule(r.Context(), adminID, productID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "product or variant not found")
+		case errors.Is(err, apperrors.ErrVariantRequired):
+			response.JSON(w, http.StatusUnprocessableEntity, map[string]string{"variant_id": "this product has variants; schedule the sale for a variant"})
+		case errors.Is(err, apperrors.ErrSalePriceTooHigh):
+			response.JSON(w, http.StatusUnprocessableEntity, map[string]string{"sale_price": "must be below the regular price"})
+		case errors.Is(err, apperrors.ErrPriceScheduleOverlap):
+			response.Error(w, http.StatusConflict, "another sale is already scheduled for this period")
+		default:
+			h.logger.Error("failed to create price schedule", "product_id", productID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not create price schedule")
+		}
+		return
+	}
+	response.JSON(w, http.StatusCreated, schedule)
+}
+
+// HandleCancelSchedule cancels a pending or running sale.
+func (h *Handler) HandleCancelSchedule(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+	scheduleID, err := strconv.ParseInt(chi.URLParam(r, "scheduleId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid schedule ID")
+		return
+	}
+
+	schedule, err := h.pricingSvc.CancelSchedule(r.Context(), productID, scheduleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "price schedule not found")
+		case errors.Is(err, apperrors.ErrPriceScheduleEnded):
+			response.Error(w, http.StatusConflict, "price schedule has already ended")
+		default:
+			h.logger.Error("failed to cancel price schedule", "schedule_id", scheduleID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not cancel price schedule")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, schedule)
+}
+
+func (h *Handler) HandleListPriceHistory(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	changes, err := h.pricingSvc.ListPriceHistory(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "product not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "could not retrieve price history")
+		return
+	}
+	response.JSON(w, http.StatusOK, changes)
+}
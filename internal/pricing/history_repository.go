@@ -0,0 +1,56 @@
+// internal/pricing/history_repository.go
+package pricing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+type historyRepository struct {
+	db domain.DBTX
+}
+
+func NewPriceHistoryRepository(db domain.DBTX) domain.PriceHistoryRepository {
+	return &historyRepository{db: db}
+}
+
+func (r *historyRepository) Record(ctx context.Context, c *models.PriceChange) error {
+	query := `
+        INSERT INTO price_history (product_id, variant_id, old_price, new_price, actor_id, note)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, changed_at`
+	err := r.db.QueryRowContext(ctx, query, c.ProductID, c.VariantID, c.OldPrice, c.NewPrice, c.ActorID, c.Note).
+		Scan(&c.ID, &c.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("price history repository: failed to record price change: %w", err)
+	}
+	return nil
+}
+
+// ListByProduct returns the regular price changes of a product and its variants, newest first.
+func (r *historyRepository) ListByProduct(ctx context.Context, productID int64) ([]*models.PriceChange, error) {
+	query := `
+        SELECT id, product_id, variant_id, old_price, new_price, actor_id, note, changed_at
+        FROM price_history
+        WHERE product_id = $1
+        ORDER BY changed_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("price history repository: failed to list price history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*models.PriceChange{}
+	for rows.Next() {
+		var c models.PriceChange
+		if err := rows.Scan(&c.ID, &c.ProductID, &c.VariantID, &c.OldPrice, &c.NewPrice, &c.ActorID, &c.Note, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("price history repository: failed to scan price change: %w", err)
+		}
+		changes = append(changes, &c)
+	}
+	return changes, rows.Err()
+}
@@ -0,0 +1,34 @@
+// internal/pricing/prices.go
+package pricing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+// RecordPriceChange writes change to the price history unless the regular price stayed
+// the same. q must come from Store.ExecTx so the change and its record commit together.
+func RecordPriceChange(ctx context.Context, q *domain.Queries, change *models.PriceChange) error {
+	if change.OldPrice != nil && *change.OldPrice == change.NewPrice {
+		return nil
+	}
+	return q.PriceHistoryRepo.Record(ctx, change)
+}
+
+// Resolve returns the price of a product, or one of its variants, at the given moment
+// from its regular price. It reads through q so checkout sees the same snapshot it locks.
+func Resolve(ctx context.Context, q *domain.Queries, productID int64, variantID *int64, basePrice float64, at time.Time) (models.EffectivePrice, error) {
+	schedule, err := q.PriceScheduleRepo.GetActive(ctx, productID, variantID, at)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return models.ResolvePrice(basePrice, nil, nil), nil
+		}
+		return models.EffectivePrice{}, err
+	}
+	return models.ResolvePrice(basePrice, &schedule.SalePrice, &schedule.ID), nil
+}
@@ -0,0 +1,133 @@
+// internal/pricing/repository.go
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type scheduleRepository struct {
+	db domain.DBTX
+}
+
+func NewPriceScheduleRepository(db domain.DBTX) domain.PriceScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+const scheduleColumns = `id, product_id, variant_id, sale_price, starts_at, ends_at, label, created_by, created_at, cancelled_at`
+
+func scanSchedule(row interface{ Scan(dest ...any) error }, ps *models.PriceSchedule) error {
+	return row.Scan(
+		&ps.ID, &ps.ProductID, &ps.VariantID, &ps.SalePrice, &ps.StartsAt, &ps.EndsAt,
+		&ps.Label, &ps.CreatedBy, &ps.CreatedAt, &ps.CancelledAt,
+	)
+}
+
+func (r *scheduleRepository) Create(ctx context.Context, ps *models.PriceSchedule) error {
+	query := `
+        INSERT INTO price_schedules (product_id, variant_id, sale_price, starts_at, ends_at, label, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at`
+	err := r.db.QueryRowContext(ctx, query,
+		ps.ProductID, ps.VariantID, ps.SalePrice, ps.StartsAt, ps.EndsAt, ps.Label, ps.CreatedBy,
+	).Scan(&ps.ID, &ps.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return apperrors.ErrNotFound
+		}
+		return fmt.Errorf("price schedule repository: failed to create schedule: %w", err)
+	}
+	return nil
+}
+
+func (r *scheduleRepository) GetByID(ctx context.Context, id int64) (*models.PriceSchedule, error) {
+	var ps models.PriceSchedule
+	err := scanSchedule(r.db.QueryRowContext(ctx, `SELECT `+scheduleColumns+` FROM price_schedules WHERE id = $1`, id), &ps)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("price schedule repository: failed to get schedule: %w", err)
+	}
+	return &ps, nil
+}
+
+// ListByProduct returns every schedule of a product and its variants, cancelled ones
+// included, latest start first.
+func (r *scheduleRepository) ListByProduct(ctx context.Context, productID int64) ([]*models.PriceSchedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM price_schedules WHERE product_id = $1 ORDER BY starts_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("price schedule repository: failed to list schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []*models.PriceSchedule{}
+	for rows.Next() {
+		var ps models.PriceSchedule
+		if err := scanSchedule(rows, &ps); err != nil {
+			return nil, fmt.Errorf("price schedule repository: failed to scan schedule: %w", err)
+		}
+		schedules = append(schedules, &ps)
+	}
+	return schedules, rows.Err()
+}
+
+// HasOverlap reports whether a schedule that has not been cancelled covers any part of
+// [startsAt, endsAt) for the same product or variant.
+func (r *scheduleRepository) HasOverlap(ctx context.Context, productID int64, variantID *int64, startsAt, endsAt time.Time) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM price_schedules
+            WHERE product_id = $1 AND variant_id IS NOT DISTINCT FROM $2
+              AND cancelled_at IS NULL
+              AND starts_at < $4 AND ends_at > $3
+        )`
+	var overlaps bool
+	if err := r.db.QueryRowContext(ctx, query, productID, variantID, startsAt, endsAt).Scan(&overlaps); err != nil {
+		return false, fmt.Errorf("price schedule repository: failed to check overlap: %w", err)
+	}
+	return overlaps, nil
+}
+
+// Cancel stops a schedule from applying. Cancelling it again keeps the original timestamp.
+func (r *scheduleRepository) Cancel(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE price_schedules SET cancelled_at = COALESCE(cancelled_at, NOW()) WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("price schedule repository: failed to cancel schedule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("price schedule repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// GetActive returns the sale running for the product, or one of its variants, at the
+// given moment. It returns ErrNotFound when none is.
+func (r *scheduleRepository) GetActive(ctx context.Context, productID int64, variantID *int64, at time.Time) (*models.PriceSchedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM price_schedules WHERE id = active_price_schedule($1, $2, $3)`
+
+	var ps models.PriceSchedule
+	if err := scanSchedule(r.db.QueryRowContext(ctx, query, productID, variantID, at), &ps); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("price schedule repository: failed to get active schedule: %w", err)
+	}
+	return &ps, nil
+}
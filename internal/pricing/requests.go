@@ -0,0 +1,25 @@
+// internal/pricing/requests.go
+package pricing
+
+import (
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+// ValidateCreatePriceScheduleRequest validates a new sale. Whether the sale is below
+// the regular price is checked by the service, which reads the price under lock.
+func ValidateCreatePriceScheduleRequest(r dto.CreatePriceScheduleRequest, v *validator.Validator) {
+	v.Check(r.SalePrice > 0, "sale_price", "must be greater than zero")
+	v.Check(!r.StartsAt.IsZero(), "starts_at", "must be provided")
+	v.Check(!r.EndsAt.IsZero(), "ends_at", "must be provided")
+	if !r.StartsAt.IsZero() && !r.EndsAt.IsZero() {
+		v.Check(r.EndsAt.After(r.StartsAt), "ends_at", "must be after starts_at")
+		v.Check(r.EndsAt.After(time.Now()), "ends_at", "must be in the future")
+	}
+	if r.VariantID != nil {
+		v.Check(*r.VariantID > 0, "variant_id", "must be a positive integer")
+	}
+	v.Check(len(r.Label) <= 100, "label", "must not exceed 100 characters")
+}
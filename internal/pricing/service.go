@@ -0,0 +1,150 @@
+// internal/pricing/service.go
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type pricingService struct {
+	scheduleRepo domain.PriceScheduleRepository
+	historyRepo  domain.PriceHistoryRepository
+	productRepo  domain.ProductRepository
+	store        domain.Store
+	logger       *slog.Logger
+}
+
+func NewPricingService(scheduleRepo domain.PriceScheduleRepository, historyRepo domain.PriceHistoryRepository, productRepo domain.ProductRepository, store domain.Store, logger *slog.Logger) domain.PricingService {
+	return &pricingService{
+		scheduleRepo: scheduleRepo,
+		historyRepo:  historyRepo,
+		productRepo:  productRepo,
+		store:        store,
+		logger:       logger,
+	}
+}
+
+// ListSchedules returns every sale scheduled for a product and its variants, past and cancelled ones included.
+func (s *pricingService) ListSchedules(ctx context.Context, productID int64) ([]*models.PriceSchedule, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, fmt.Errorf("pricing service: could not retrieve product: %w", err)
+	}
+
+	schedules, err := s.scheduleRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to list price schedules", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("pricing service: could not list price schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// CreateSchedule schedules a sale price for a product, or for one of its variants.
+// Products with variants are priced per variant, so their sales name a variant too.
+// The sale must be below the current regular price and must not overlap another sale
+// for the same target. The product row is locked so two admins cannot schedule
+// overlapping sales at once.
+func (s *pricingService) CreateSchedule(ctx context.Context, actorID, productID int64, req *dto.CreatePriceScheduleRequest) (*models.PriceSchedule, error) {
+	schedule := &models.PriceSchedule{
+		ProductID: productID,
+		VariantID: req.VariantID,
+		SalePrice: req.SalePrice,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Label:     strings.TrimSpace(req.Label),
+		CreatedBy: &actorID,
+	}
+
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		product, err := q.ProductRepo.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		basePrice := product.BasePrice
+		if req.VariantID == nil {
+			variants, err := q.VariantRepo.ListByProductID(ctx, productID)
+			if err != nil {
+				return err
+			}
+			if len(variants) > 0 {
+				return apperrors.ErrVariantRequired
+			}
+		} else {
+			variant, err := q.VariantRepo.GetByID(ctx, *req.VariantID)
+			if err != nil {
+				return err
+			}
+			if variant.ProductID != productID {
+				return apperrors.ErrNotFound
+			}
+			basePrice = variant.BasePrice
+		}
+		if schedule.SalePrice >= basePrice {
+			return apperrors.ErrSalePriceTooHigh
+		}
+
+		overlaps, err := q.PriceScheduleRepo.HasOverlap(ctx, productID, req.VariantID, schedule.StartsAt, schedule.EndsAt)
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return apperrors.ErrPriceScheduleOverlap
+		}
+		return q.PriceScheduleRepo.Create(ctx, schedule)
+	})
+	if err != nil {
+		s.logger.Warn("failed to create price schedule", "product_id", productID, "variant_id", req.VariantID, "error", err)
+		return nil, fmt.Errorf("pricing service: could not create price schedule: %w", err)
+	}
+
+	s.logger.Info("price schedule created", "schedule_id", schedule.ID, "product_id", productID, "starts_at", schedule.StartsAt, "ends_at", schedule.EndsAt)
+	return schedule, nil
+}
+
+// CancelSchedule stops a sale that is pending or running. Cancelling a cancelled sale
+// is a no-op; a sale that already ended is left as it is, since orders may refer to it.
+func (s *pricingService) CancelSchedule(ctx context.Context, productID, scheduleID int64) (*models.PriceSchedule, error) {
+	schedule, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("pricing service: could not retrieve price schedule: %w", err)
+	}
+	if schedule.ProductID != productID {
+		return nil, fmt.Errorf("pricing service: could not retrieve price schedule: %w", apperrors.ErrNotFound)
+	}
+	if schedule.CancelledAt != nil {
+		return schedule, nil
+	}
+	if !schedule.EndsAt.After(time.Now()) {
+		return nil, apperrors.ErrPriceScheduleEnded
+	}
+
+	if err := s.scheduleRepo.Cancel(ctx, scheduleID); err != nil {
+		s.logger.Warn("failed to cancel price schedule", "schedule_id", scheduleID, "error", err)
+		return nil, fmt.Errorf("pricing service: could not cancel price schedule: %w", err)
+	}
+
+	s.logger.Info("price schedule cancelled", "schedule_id", scheduleID, "product_id", productID)
+	return s.scheduleRepo.GetByID(ctx, scheduleID)
+}
+
+// ListPriceHistory returns the regular price changes of a product and its variants, newest first.
+func (s *pricingService) ListPriceHistory(ctx context.Context, productID int64) ([]*models.PriceChange, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, fmt.Errorf("pricing service: could not retrieve product: %w", err)
+	}
+
+	changes, err := s.historyRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to list price history", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("pricing service: could not list price history: %w", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,33 @@
+// internal/product/attributes.go
+package product
+
+import (
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+// matchProductAttributes checks the submitted values, keyed by attribute code, against
+// the category's definitions and returns them in canonical form. Errors name the
+// offending attribute so the admin can fix it.
+func matchProductAttributes(definitions []models.CategoryAttribute, submitted map[string]string) ([]models.ProductAttribute, error) {
+	byCode := make(map[string]*models.CategoryAttribute, len(definitions))
+	for i := range definitions {
+		byCode[definitions[i].Code] = &definitions[i]
+	}
+
+	values := make([]models.ProductAttribute, 0, len(submitted))
+	for code, raw := range submitted {
+		definition, ok := byCode[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not an attribute of this product's category", apperrors.ErrInvalidAttributeValue, code)
+		}
+		value, err := definition.NormalizeValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %v", apperrors.ErrInvalidAttributeValue, code, err)
+		}
+		values = append(values, models.ProductAttribute{AttributeID: definition.ID, Code: code, Value: value})
+	}
+	return values, nil
+}
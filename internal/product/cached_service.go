@@ -0,0 +1,142 @@
+// internal/product/cached_service.go
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/cache"
+)
+
+// cachedProductService serves catalog reads from an in-process cache and flushes it
+// after every write, failed or not, since a failed write may have changed some rows.
+// Writes made elsewhere (orders, imports, the worker) must flush the same cache, or
+// wait out its TTL.
+type cachedProductService struct {
+	next  domain.ProductService
+	cache *cache.Cache
+}
+
+// NewCachedProductService wraps next so listings, facets and product pages are cached.
+// Cached products are shared between requests and must be treated as read-only.
+func NewCachedProductService(next domain.ProductService, c *cache.Cache) domain.ProductService {
+	return &cachedProductService{next: next, cache: c}
+}
+
+func (s *cachedProductService) ListProducts(ctx context.Context, filters domain.ProductFilters) ([]*models.Product, error) {
+	key, err := filtersKey("products", filters)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.cache.GetOrLoad(key, func() (any, error) {
+		return s.next.ListProducts(ctx, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*models.Product), nil
+}
+
+func (s *cachedProductService) GetProductFacets(ctx context.Context, filters domain.ProductFilters) (*domain.ProductFacets, error) {
+	key, err := filtersKey("facets", filters)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.cache.GetOrLoad(key, func() (any, error) {
+		return s.next.GetProductFacets(ctx, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*domain.ProductFacets), nil
+}
+
+func (s *cachedProductService) GetProduct(ctx context.Context, id int64) (*models.Product, error) {
+	value, err := s.cache.GetOrLoad(fmt.Sprintf("product:%d", id), func() (any, error) {
+		return s.next.GetProduct(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*models.Product), nil
+}
+
+func (s *cachedProductService) CreateProduct(ctx context.Context, actorID int64, req *dto.CreateProductRequest) (*models.Product, error) {
+	p, err := s.next.CreateProduct(ctx, actorID, req)
+	s.cache.Flush()
+	return p, err
+}
+
+func (s *cachedProductService) UpdateProduct(ctx context.Context, actorID int64, id int64, req *dto.UpdateProductRequest) (*models.Product, error) {
+	p, err := s.next.UpdateProduct(ctx, actorID, id, req)
+	s.cache.Flush()
+	return p, err
+}
+
+func (s *cachedProductService) SetProductStatus(ctx context.Context, id int64, status models.ProductStatus) (*models.Product, error) {
+	p, err := s.next.SetProductStatus(ctx, id, status)
+	s.cache.Flush()
+	return p, err
+}
+
+func (s *cachedProductService) ArchiveProduct(ctx context.Context, id int64) (*models.Product, error) {
+	p, err := s.next.ArchiveProduct(ctx, id)
+	s.cache.Flush()
+	return p, err
+}
+
+func (s *cachedProductService) DeleteProduct(ctx context.Context, id int64) (bool, error) {
+	archived, err := s.next.DeleteProduct(ctx, id)
+	s.cache.Flush()
+	return archived, err
+}
+
+func (s *cachedProductService) SetProductAttributes(ctx context.Context, productID int64, req *dto.SetProductAttributesRequest) ([]models.ProductAttribute, error) {
+	attributes, err := s.next.SetProductAttributes(ctx, productID, req)
+	s.cache.Flush()
+	return attributes, err
+}
+
+func (s *cachedProductService) SetProductOptions(ctx context.Context, productID int64, req *dto.SetProductOptionsRequest) ([]models.ProductOption, error) {
+	options, err := s.next.SetProductOptions(ctx, productID, req)
+	s.cache.Flush()
+	return options, err
+}
+
+func (s *cachedProductService) CreateVariant(ctx context.Context, actorID int64, productID int64, req *dto.CreateVariantRequest) (*models.ProductVariant, error) {
+	v, err := s.next.CreateVariant(ctx, actorID, productID, req)
+	s.cache.Flush()
+	return v, err
+}
+
+func (s *cachedProductService) UpdateVariant(ctx context.Context, actorID int64, productID, variantID int64, req *dto.UpdateVariantRequest) (*models.ProductVariant, error) {
+	v, err := s.next.UpdateVariant(ctx, actorID, productID, variantID, req)
+	s.cache.Flush()
+	return v, err
+}
+
+func (s *cachedProductService) DeleteVariant(ctx context.Context, productID, variantID int64) error {
+	err := s.next.DeleteVariant(ctx, productID, variantID)
+	s.cache.Flush()
+	return err
+}
+
+func (s *cachedProductService) UploadProductImage(ctx context.Context, productID int64, data []byte, makePrimary bool) (*models.Product, error) {
+	p, err := s.next.UploadProductImage(ctx, productID, data, makePrimary)
+	s.cache.Flush()
+	return p, err
+}
+
+// filtersKey builds a cache key from a listing's filters. encoding/json sorts map keys,
+// so the same filters always produce the same key.
+func filtersKey(prefix string, filters domain.ProductFilters) (string, error) {
+	encoded, err := json.Marshal(filters)
+	if err != nil {
+		return "", fmt.Errorf("product service: could not build cache key: %w", err)
+	}
+	return prefix + ":" + string(encoded), nil
+}
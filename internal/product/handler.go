@@ -2,48 +2,48 @@
 package product
 
 import (
+	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
 	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
 )
 
 type Handler struct {
-	productSvc  domain.ProductService
-	categorySvc domain.CategoryService
-	logger      *slog.Logger
+	productSvc     domain.ProductService
+	categorySvc    domain.CategoryService
+	maxUploadBytes int64
+	cacheMaxAge    time.Duration // Cache-Control max-age for public catalog reads
+	logger         *slog.Logger
 }
 
-func NewHandler(productSvc domain.ProductService, categorySvc domain.CategoryService, logger *slog.Logger) *Handler {
+func NewHandler(productSvc domain.ProductService, categorySvc domain.CategoryService, maxUploadBytes int64, cacheMaxAge time.Duration, logger *slog.Logger) *Handler {
 	return &Handler{
-		productSvc:  productSvc,
-		categorySvc: categorySvc,
-		logger:      logger,
+		productSvc:     productSvc,
+		categorySvc:    categorySvc,
+		maxUploadBytes: maxUploadBytes,
+		cacheMaxAge:    cacheMaxAge,
+		logger:         logger,
 	}
 }
 
 func (h *Handler) HandleListProducts(w http.ResponseWriter, r *http.Request) {
-	filters := domain.ProductFilters{
-		Category: r.URL.Query().Get("category"),
-		SearchQuery: r.URL.Query().Get("q"),
-		Page:     1,
-		PageSize: 10, 
-	}
-
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
-			filters.Page = page
-		}
-	}
-	if pageSizeStr := r.URL.Query().Get("limit"); pageSizeStr != "" {
-		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 {
-			filters.PageSize = pageSize
-		}
+	v := validator.New()
+	filters := ParseProductFilters(r.URL.Query(), v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
 	}
 
 	products, err := h.productSvc.ListProducts(r.Context(), filters)
@@ -53,7 +53,20 @@ func (h *Handler) HandleListProducts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, products)
+	facets, err := h.productSvc.GetProductFacets(r.Context(), filters)
+	if err != nil {
+		h.logger.Error("failed to compute product facets", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not retrieve products")
+		return
+	}
+
+	var lastModified time.Time
+	for _, p := range products {
+		if p.UpdatedAt.After(lastModified) {
+			lastModified = p.UpdatedAt
+		}
+	}
+	response.CachedJSON(w, r, NewProductListResponse(products, facets, filters), lastModified, h.cacheMaxAge)
 }
 
 func (h *Handler) HandleGetProduct(w http.ResponseWriter, r *http.Request) {
@@ -75,7 +88,13 @@ func (h *Handler) HandleGetProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, product)
+	// Drafts are unpublished. Archived products still resolve, for links from past orders.
+	if product.IsDraft() {
+		response.Error(w, http.StatusNotFound, "product not found")
+		return
+	}
+
+	response.CachedJSON(w, r, product, product.LastModified(), h.cacheMaxAge)
 }
 
 func (h *Handler) HandleListCategories(w http.ResponseWriter, r *http.Request) {
@@ -85,5 +104,398 @@ func (h *Handler) HandleListCategories(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusInternalServerError, "could not retrieve categories")
 		return
 	}
-	response.JSON(w, http.StatusOK, categories)
-}
\ No newline at end of file
+	response.CachedJSON(w, r, categories, models.LatestCategoryUpdate(categories), h.cacheMaxAge)
+}
+
+// HandleCreateProduct lets an admin add a new product to the catalog.
+func (h *Handler) HandleCreateProduct(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.CreateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid create product payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreateProductRequest(req, v)
+	if !v.Valid() {
+		h.logger.Warn("create product validation failed", "errors", v.Errors)
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	product, err := h.productSvc.CreateProduct(r.Context(), adminID, &req)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not create product")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, product)
+}
+
+// HandleUpdateProduct applies a partial update to a product using optimistic concurrency.
+func (h *Handler) HandleUpdateProduct(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.UpdateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid update product payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateUpdateProductRequest(req, v)
+	if !v.Valid() {
+		h.logger.Warn("update product validation failed", "errors", v.Errors)
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	product, err := h.productSvc.UpdateProduct(r.Context(), adminID, id, &req)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not update product")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, product)
+}
+
+// HandleSetProductStatus publishes, unpublishes, archives or restores a product.
+func (h *Handler) HandleSetProductStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.SetProductStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid product status payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateSetProductStatusRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	product, err := h.productSvc.SetProductStatus(r.Context(), id, req.Status)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not update product status")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, product)
+}
+
+// HandleArchiveProduct removes a product from the public catalog while keeping it for order history.
+func (h *Handler) HandleArchiveProduct(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	product, err := h.productSvc.ArchiveProduct(r.Context(), id)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not archive product")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, product)
+}
+
+// HandleDeleteProduct permanently deletes a draft that has never been ordered and
+// archives any other product, so existing orders and carts keep resolving it.
+func (h *Handler) HandleDeleteProduct(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	archived, err := h.productSvc.DeleteProduct(r.Context(), id)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not delete product")
+		return
+	}
+
+	if archived {
+		response.JSON(w, http.StatusOK, response.MessageResponse{Message: "product archived instead of deleted, so existing orders keep resolving it"})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "product deleted successfully"})
+}
+
+// HandleSetProductAttributes replaces the typed attribute values of a product.
+func (h *Handler) HandleSetProductAttributes(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.SetProductAttributesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid product attributes payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateSetProductAttributesRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	attributes, err := h.productSvc.SetProductAttributes(r.Context(), productID, &req)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not update product attributes")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, attributes)
+}
+
+// HandleSetProductOptions replaces the option types (e.g. Size, Colour) a product varies by.
+func (h *Handler) HandleSetProductOptions(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.SetProductOptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid product options payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateSetProductOptionsRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	options, err := h.productSvc.SetProductOptions(r.Context(), productID, &req)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not update product options")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, options)
+}
+
+// HandleCreateVariant adds a purchasable variant to a product.
+func (h *Handler) HandleCreateVariant(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.CreateVariantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid create variant payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreateVariantRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	variant, err := h.productSvc.CreateVariant(r.Context(), adminID, productID, &req)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not create variant")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, variant)
+}
+
+// HandleUpdateVariant applies a partial update to a variant using optimistic concurrency.
+func (h *Handler) HandleUpdateVariant(w http.ResponseWriter, r *http.Request) {
+	adminID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, variantID, ok := parseVariantPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateVariantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid update variant payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateUpdateVariantRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	variant, err := h.productSvc.UpdateVariant(r.Context(), adminID, productID, variantID, &req)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not update variant")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, variant)
+}
+
+// HandleDeleteVariant removes a variant. Past orders keep their snapshot of it.
+func (h *Handler) HandleDeleteVariant(w http.ResponseWriter, r *http.Request) {
+	productID, variantID, ok := parseVariantPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.productSvc.DeleteVariant(r.Context(), productID, variantID); err != nil {
+		h.writeProductWriteError(w, err, "could not delete variant")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "variant deleted successfully"})
+}
+
+// HandleUploadProductImage accepts a multipart upload with the file in the "image" field.
+// Sending primary=true makes the new image's thumbnail the product's main thumbnail.
+func (h *Handler) HandleUploadProductImage(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	// Leave some room above the file limit for the multipart headers and other fields.
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadBytes+1<<20)
+	if err := r.ParseMultipartForm(h.maxUploadBytes); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.writeProductWriteError(w, apperrors.ErrFileTooLarge, "")
+			return
+		}
+		h.logger.Warn("invalid image upload payload", "error", err)
+		response.Error(w, http.StatusBadRequest, "invalid multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, map[string]string{"image": "must be provided"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxUploadBytes+1))
+	if err != nil {
+		h.logger.Error("failed to read uploaded image", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not read uploaded file")
+		return
+	}
+	if int64(len(data)) > h.maxUploadBytes {
+		h.writeProductWriteError(w, apperrors.ErrFileTooLarge, "")
+		return
+	}
+
+	makePrimary, _ := strconv.ParseBool(r.FormValue("primary"))
+	product, err := h.productSvc.UploadProductImage(r.Context(), productID, data, makePrimary)
+	if err != nil {
+		h.writeProductWriteError(w, err, "could not upload image")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, product)
+}
+
+// parseVariantPath reads the product and variant IDs from the URL, writing a 400 if either is invalid.
+func parseVariantPath(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return 0, 0, false
+	}
+	variantID, err := strconv.ParseInt(chi.URLParam(r, "variantId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid variant ID")
+		return 0, 0, false
+	}
+	return productID, variantID, true
+}
+
+// writeProductWriteError maps service errors from admin product writes to HTTP responses.
+func (h *Handler) writeProductWriteError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "product or variant not found")
+	case errors.Is(err, apperrors.ErrEditConflict):
+		response.Error(w, http.StatusConflict, "resource was modified by another request, please reload and retry")
+	case errors.Is(err, apperrors.ErrDuplicateSKU):
+		response.Error(w, http.StatusConflict, "a product with this SKU already exists")
+	case errors.Is(err, apperrors.ErrCategoryNotFound):
+		response.Error(w, http.StatusUnprocessableEntity, "category does not exist")
+	case errors.Is(err, apperrors.ErrProductHasOrders):
+		response.Error(w, http.StatusConflict, "product has existing orders and cannot be deleted, archive it instead")
+	case errors.Is(err, apperrors.ErrDuplicateVariant):
+		response.Error(w, http.StatusConflict, "a variant with these options already exists")
+	case errors.Is(err, apperrors.ErrInvalidVariantOptions):
+		response.Error(w, http.StatusUnprocessableEntity, "options must pick exactly one allowed value for each of the product's option types")
+	case errors.Is(err, apperrors.ErrInvalidAttributeValue):
+		response.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, apperrors.ErrOptionsInUse):
+		response.Error(w, http.StatusConflict, "existing variants use option values that would be removed")
+	case errors.Is(err, apperrors.ErrVariantRequired):
+		response.JSON(w, http.StatusUnprocessableEntity, map[string]string{"stock_quantity": "must be set per variant for a product with variants"})
+	case errors.Is(err, apperrors.ErrInsufficientStock):
+		response.Error(w, http.StatusConflict, "the default warehouse does not hold enough stock for this reduction, adjust stock per warehouse instead")
+	case errors.Is(err, apperrors.ErrUnsupportedMediaType):
+		response.Error(w, http.StatusUnsupportedMediaType, "image must be a JPEG, PNG or GIF file")
+	case errors.Is(err, apperrors.ErrFileTooLarge):
+		response.Error(w, http.StatusRequestEntityTooLarge, "image exceeds the maximum upload size")
+	case errors.Is(err, apperrors.ErrImageTooLarge):
+		response.Error(w, http.StatusRequestEntityTooLarge, "image dimensions exceed the allowed maximum")
+	default:
+		h.logger.Error("admin product operation failed", "error", err)
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
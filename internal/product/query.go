@@ -0,0 +1,136 @@
+// internal/product/query.go
+package product
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+)
+
+// facet identifies a filter that a facet query leaves out, so that the counts
+// for a multi-select facet still show the values the user has not picked yet.
+type facet int
+
+const (
+	facetNone facet = iota
+	facetBrand
+	facetCategory
+)
+
+// effectivePriceExpr is the price a buyer pays right now: the running sale when it
+// is below the regular price, the regular price otherwise. Price filters, sorting and
+// facets use it so listings agree with what product pages show.
+const effectivePriceExpr = "LEAST(p.price, COALESCE(sale_price_at(p.id, NULL, NOW()), p.price))"
+
+// productQuery accumulates the WHERE clause and positional args for product listings.
+type productQuery struct {
+	conditions []string
+	args       []any
+	rankExpr   string
+}
+
+// arg registers a query argument and returns its placeholder.
+func (q *productQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// where renders the accumulated conditions.
+func (q *productQuery) where() string {
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// newProductQuery translates filters into SQL conditions. The filter named by
+// skip is ignored, which is how facet counts are computed.
+func newProductQuery(filters domain.ProductFilters, skip facet) *productQuery {
+	// Only active products show up in the public listing; drafts and archived ones never do.
+	q := &productQuery{conditions: []string{"p.status = 'active'"}}
+
+	// A category matches by name or slug and includes everything in its subtree,
+	// so filtering by a parent also lists products filed under its descendants.
+	if filters.Category != "" && skip != facetCategory {
+		q.conditions = append(q.conditions, fmt.Sprintf(`p.category_id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT id FROM categories WHERE name = %[1]s OR slug = %[1]s
+                UNION ALL
+                SELECT child.id FROM categories child JOIN subtree ON child.parent_id = subtree.id
+            )
+            SELECT id FROM subtree)`, q.arg(filters.Category)))
+	}
+
+	if len(filters.Brands) > 0 && skip != facetBrand {
+		q.conditions = append(q.conditions, "p.brand = ANY("+q.arg(pq.StringArray(filters.Brands))+")")
+	}
+
+	// Every attribute filter must match; within one attribute any listed value does.
+	// Codes are visited in order so the generated SQL is stable.
+	for _, code := range slices.Sorted(maps.Keys(filters.Attributes)) {
+		q.conditions = append(q.conditions, fmt.Sprintf(`EXISTS (
+            SELECT 1 FROM product_attribute_values pav
+            JOIN category_attributes ca ON ca.id = pav.attribute_id
+            WHERE pav.product_id = p.id AND ca.code = %s AND pav.value = ANY(%s))`,
+			q.arg(code), q.arg(pq.StringArray(filters.Attributes[code]))))
+	}
+
+	if filters.MinPrice != nil {
+		q.conditions = append(q.conditions, effectivePriceExpr+" >= "+q.arg(*filters.MinPrice))
+	}
+	if filters.MaxPrice != nil {
+		q.conditions = append(q.conditions, effectivePriceExpr+" <= "+q.arg(*filters.MaxPrice))
+	}
+
+	if filters.InStockOnly {
+		// Stock held by unpaid checkouts does not count as in stock.
+		q.conditions = append(q.conditions, "p.stock_quantity - reserved_stock(p.id, NULL) > 0")
+	}
+
+	// Full-text match on the weighted search_vector with prefix matching, plus a
+	// trigram fallback on name and brand to tolerate typos.
+	if filters.SearchQuery != "" {
+		raw := q.arg(filters.SearchQuery)
+		matches := []string{raw + " <% p.name", raw + " <% p.brand"}
+		q.rankExpr = fmt.Sprintf("word_similarity(%s, p.name)", raw)
+
+		if tsQuery := buildPrefixTSQuery(filters.SearchQuery); tsQuery != "" {
+			ts := q.arg(tsQuery)
+			matches = append([]string{fmt.Sprintf("p.search_vector @@ to_tsquery('english', %s)", ts)}, matches...)
+			q.rankExpr = fmt.Sprintf("ts_rank_cd(p.search_vector, to_tsquery('english', %s), 32) + %s", ts, q.rankExpr)
+		}
+		q.conditions = append(q.conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+
+	return q
+}
+
+// orderBy returns the ORDER BY expression for the requested sort. Searches are
+// ranked by relevance unless the caller asked for something else, and p.id is
+// always the final tie-breaker so pagination is stable.
+func (q *productQuery) orderBy(sort domain.ProductSort) string {
+	if sort == domain.ProductSortDefault && q.rankExpr != "" {
+		sort = domain.ProductSortRelevance
+	}
+
+	switch sort {
+	case domain.ProductSortRelevance:
+		if q.rankExpr != "" {
+			return q.rankExpr + " DESC, p.id ASC"
+		}
+	case domain.ProductSortPriceAsc:
+		return effectivePriceExpr + " ASC, p.id ASC"
+	case domain.ProductSortPriceDesc:
+		return effectivePriceExpr + " DESC, p.id ASC"
+	case domain.ProductSortNewest:
+		return "p.created_at DESC, p.id DESC"
+	case domain.ProductSortNameAsc:
+		return "p.name ASC, p.id ASC"
+	case domain.ProductSortNameDesc:
+		return "p.name DESC, p.id ASC"
+	case domain.ProductSortRating:
+		return "p.rating_average DESC, p.review_count DESC, p.id ASC"
+	}
+	return "p.id ASC"
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/models"
 	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
@@ -22,46 +23,197 @@ func NewProductRepository(db domain.DBTX) domain.ProductRepository {
 }
 
 func (r *productRepository) Create(ctx context.Context, p *models.Product) error {
-	query := `INSERT INTO products (name, description, price, stock_quantity, category_id, brand, sku, images, thumbnail, dimensions, warranty_information)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-              RETURNING id, created_at, updated_at, version`
+	// Stock starts at zero; it is held per warehouse and added through the stock ledger.
+	// Products created without a status are published straight away.
+	if p.Status == "" {
+		p.Status = models.ProductStatusActive
+	}
+	query := `INSERT INTO products (name, description, price, stock_quantity, category_id, brand, sku, images, thumbnail, dimensions, warranty_information, low_stock_threshold, status)
+              VALUES ($1, $2, $3, 0, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+              RETURNING id, stock_quantity, created_at, updated_at, version`
 	args := []any{
-		p.Name, p.Description, p.Price, p.StockQuantity, p.CategoryID,
-		p.Brand, p.SKU, p.Images, p.Thumbnail, p.Dimensions, p.WarrantyInformation,
+		p.Name, p.Description, p.Price, p.CategoryID,
+		p.Brand, p.SKU, p.Images, p.Thumbnail, p.Dimensions, p.WarrantyInformation, p.LowStockThreshold, p.Status,
 	}
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt, &p.Version)
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&p.ID, &p.StockQuantity, &p.CreatedAt, &p.UpdatedAt, &p.Version)
 	if err != nil {
+		if mapped := mapWriteError(err); mapped != nil {
+			return mapped
+		}
 		return fmt.Errorf("product repository: failed to create product: %w", err)
 	}
 	return nil
 }
 
-func (r *productRepository) GetByID(ctx context.Context, id int64) (*models.Product, error) {
+// Update writes all editable product fields, guarded by the version the caller read.
+// A stale version means someone else changed the product in between. Stock is not
+// written here; it changes only through warehouse stock.
+func (r *productRepository) Update(ctx context.Context, p *models.Product) error {
+	query := `
+        UPDATE products
+        SET name = $1, description = $2, price = $3, category_id = $4, brand = $5,
+            sku = $6, images = $7, thumbnail = $8, dimensions = $9, warranty_information = $10,
+            low_stock_threshold = $11, updated_at = NOW(), version = version + 1
+        WHERE id = $12 AND version = $13
+        RETURNING updated_at, version`
+	args := []any{
+		p.Name, p.Description, p.Price, p.CategoryID, p.Brand,
+		p.SKU, p.Images, p.Thumbnail, p.Dimensions, p.WarrantyInformation, p.LowStockThreshold,
+		p.ID, p.Version,
+	}
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&p.UpdatedAt, &p.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.ErrEditConflict
+		}
+		if mapped := mapWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("product repository: failed to update product: %w", err)
+	}
+	return nil
+}
+
+// SetStatus moves a product to another lifecycle status. archived_at is stamped when
+// the product is archived, kept if it already was, and cleared when it is brought back.
+func (r *productRepository) SetStatus(ctx context.Context, id int64, status models.ProductStatus) error {
 	query := `
+        UPDATE products
+        SET status = $1,
+            archived_at = CASE WHEN $1 = 'archived' THEN COALESCE(archived_at, NOW()) END,
+            updated_at = NOW(), version = version + 1
+        WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, status, id)
+	if err != nil {
+		return fmt.Errorf("product repository: failed to set product status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("product repository: failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// Delete permanently removes a product. Products that appear in orders cannot be
+// deleted because order_items keeps a foreign key to them; archive those instead.
+func (r *productRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM products WHERE id = $1", id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return apperrors.ErrProductHasOrders
+		}
+		return fmt.Errorf("product repository: failed to delete product: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("product repository: failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// mapWriteError translates constraint violations on insert/update into domain errors.
+func mapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+	switch pgErr.Code {
+	case "23505":
+		return apperrors.ErrDuplicateSKU
+	case "23503":
+		return apperrors.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// productDetailSelect loads every product column plus the category name, for single-product reads.
+const productDetailSelect = `
         SELECT p.id, p.name, p.description, p.price, p.stock_quantity, p.category_id, p.brand, p.sku, 
                p.images, p.thumbnail, p.dimensions, p.warranty_information, p.created_at, p.updated_at, p.version,
-               c.name as category_name
+               p.status, p.archived_at, p.rating_average, p.review_count, c.name as category_name,
+               p.stock_quantity - reserved_stock(p.id, NULL) AS available_stock, p.low_stock_threshold,
+               sale_price_at(p.id, NULL, NOW()), active_price_schedule(p.id, NULL, NOW())
         FROM products p
-        LEFT JOIN categories c ON p.category_id = c.id
-        WHERE p.id = $1`
+        LEFT JOIN categories c ON p.category_id = c.id`
 
+func scanProductDetail(row interface{ Scan(dest ...any) error }) (*models.Product, error) {
 	var p models.Product
 	var cat models.Category
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&p.ID, &p.Name, &p.Description, &p.Price, &p.StockQuantity, &p.CategoryID, &p.Brand, &p.SKU,
+	var basePrice float64
+	var salePrice *float64
+	var scheduleID *int64
+	err := row.Scan(
+		&p.ID, &p.Name, &p.Description, &basePrice, &p.StockQuantity, &p.CategoryID, &p.Brand, &p.SKU,
 		&p.Images, &p.Thumbnail, &p.Dimensions, &p.WarrantyInformation, &p.CreatedAt, &p.UpdatedAt, &p.Version,
-		&cat.Name,
+		&p.Status, &p.ArchivedAt, &p.RatingAverage, &p.ReviewCount, &cat.Name, &p.AvailableStock, &p.LowStockThreshold,
+		&salePrice, &scheduleID,
 	)
+	if err != nil {
+		return nil, err
+	}
+	p.SetPrice(basePrice, salePrice, scheduleID)
+
+	cat.ID = p.CategoryID
+	p.Category = &cat
+	return &p, nil
+}
+
+func (r *productRepository) GetByID(ctx context.Context, id int64) (*models.Product, error) {
+	p, err := scanProductDetail(r.db.QueryRowContext(ctx, productDetailSelect+` WHERE p.id = $1`, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("product repository: failed to get product by ID: %w", err)
 	}
+	return p, nil
+}
 
-	cat.ID = p.CategoryID
-	p.Category = &cat
-	return &p, nil
+func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	p, err := scanProductDetail(r.db.QueryRowContext(ctx, productDetailSelect+` WHERE p.sku = $1`, sku))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("product repository: failed to get product by SKU: %w", err)
+	}
+	return p, nil
+}
+
+// ListAll returns every product, archived ones included, ordered by ID. It is meant
+// for full-catalog exports and does not paginate.
+func (r *productRepository) ListAll(ctx context.Context) ([]*models.Product, error) {
+	rows, err := r.db.QueryContext(ctx, productDetailSelect+` ORDER BY p.id`)
+	if err != nil {
+		return nil, fmt.Errorf("product repository: failed to list all products: %w", err)
+	}
+	defer rows.Close()
+
+	var products []*models.Product
+	for rows.Next() {
+		p, err := scanProductDetail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("product repository: failed to scan row: %w", err)
+		}
+		products = append(products, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("product repository: error iterating rows: %w", err)
+	}
+	return products, nil
 }
 
 func (r *productRepository) GetAll(ctx context.Context, filters domain.ProductFilters) ([]*models.Product, error) {
@@ -69,38 +221,20 @@ func (r *productRepository) GetAll(ctx context.Context, filters domain.ProductFi
 	var queryBuilder strings.Builder
 	queryBuilder.WriteString(`
         SELECT p.id, p.name, p.description, p.price, p.stock_quantity, p.category_id, p.brand,
-               p.images, p.thumbnail, p.created_at, p.updated_at, p.version,
-               c.name as category_name, c.created_at as category_created_at, c.updated_at as category_updated_at
+               p.images, p.thumbnail, p.created_at, p.updated_at, p.version, p.status,
+               p.rating_average, p.review_count, p.stock_quantity - reserved_stock(p.id, NULL) AS available_stock,
+               c.name as category_name, c.created_at as category_created_at, c.updated_at as category_updated_at,
+               sale_price_at(p.id, NULL, NOW()), active_price_schedule(p.id, NULL, NOW())
         FROM products p
         LEFT JOIN categories c ON p.category_id = c.id
     `)
 
-	var args []any
-	var conditions []string
-	argCount := 1
+	q := newProductQuery(filters, facetNone)
+	queryBuilder.WriteString(q.where())
+	queryBuilder.WriteString(fmt.Sprintf(" ORDER BY %s LIMIT %s OFFSET %s",
+		q.orderBy(filters.Sort), q.arg(filters.PageSize), q.arg((filters.Page-1)*filters.PageSize)))
 
-	if filters.Category != "" {
-		conditions = append(conditions, fmt.Sprintf("c.name = $%d", argCount))
-		args = append(args, filters.Category)
-		argCount++
-	}
-
-	// Add search query condition
-	if filters.SearchQuery != "" {
-		conditions = append(conditions, fmt.Sprintf("(p.name ILIKE $%d OR p.description ILIKE $%d OR p.brand ILIKE $%d)", argCount, argCount+1, argCount+2))
-		args = append(args, "%"+filters.SearchQuery+"%", "%"+filters.SearchQuery+"%", "%"+filters.SearchQuery+"%")
-		argCount += 3 
-	}
-	
-	if len(conditions) > 0 {
-		queryBuilder.WriteString(" WHERE ")
-		queryBuilder.WriteString(strings.Join(conditions, " AND "))
-	}
-
-	queryBuilder.WriteString(fmt.Sprintf(" ORDER BY p.id ASC LIMIT $%d OFFSET $%d", argCount, argCount+1))
-	args = append(args, filters.PageSize, (filters.Page-1)*filters.PageSize)
-
-	rows, err := r.db.QueryContext(ctx, queryBuilder.String(), args...)
+	rows, err := r.db.QueryContext(ctx, queryBuilder.String(), q.args...)
 	if err != nil {
 		return nil, fmt.Errorf("product repository: query failed: %w", err)
 	}
@@ -110,14 +244,19 @@ func (r *productRepository) GetAll(ctx context.Context, filters domain.ProductFi
 	for rows.Next() {
 		var p models.Product
 		var cat models.Category
+		var basePrice float64
+		var salePrice *float64
+		var scheduleID *int64
 		err := rows.Scan(
-			&p.ID, &p.Name, &p.Description, &p.Price, &p.StockQuantity, &p.CategoryID, &p.Brand,
-			&p.Images, &p.Thumbnail, &p.CreatedAt, &p.UpdatedAt, &p.Version,
-			&cat.Name, &cat.CreatedAt, &cat.UpdatedAt,
+			&p.ID, &p.Name, &p.Description, &basePrice, &p.StockQuantity, &p.CategoryID, &p.Brand,
+			&p.Images, &p.Thumbnail, &p.CreatedAt, &p.UpdatedAt, &p.Version, &p.Status,
+			&p.RatingAverage, &p.ReviewCount, &p.AvailableStock,
+			&cat.Name, &cat.CreatedAt, &cat.UpdatedAt, &salePrice, &scheduleID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("product repository: failed to scan row: %w", err)
 		}
+		p.SetPrice(basePrice, salePrice, scheduleID)
 		cat.ID = p.CategoryID
 		p.Category = &cat
 		products = append(products, &p)
@@ -130,19 +269,80 @@ func (r *productRepository) GetAll(ctx context.Context, filters domain.ProductFi
 	return products, nil
 }
 
+// GetFacets computes brand and category counts plus price bounds for a listing.
+// Each facet ignores its own filter so the client can offer the other values.
+func (r *productRepository) GetFacets(ctx context.Context, filters domain.ProductFilters) (*domain.ProductFacets, error) {
+	const from = `
+        FROM products p
+        LEFT JOIN categories c ON p.category_id = c.id`
+
+	facets := &domain.ProductFacets{
+		Brands:     []domain.FacetCount{},
+		Categories: []domain.FacetCount{},
+	}
+
+	q := newProductQuery(filters, facetNone)
+	statsQuery := `SELECT COUNT(*), COALESCE(MIN(` + effectivePriceExpr + `), 0), COALESCE(MAX(` + effectivePriceExpr + `), 0)` + from + q.where()
+	if err := r.db.QueryRowContext(ctx, statsQuery, q.args...).Scan(&facets.Total, &facets.MinPrice, &facets.MaxPrice); err != nil {
+		return nil, fmt.Errorf("product repository: failed to compute price facets: %w", err)
+	}
+
+	bq := newProductQuery(filters, facetBrand)
+	bq.conditions = append(bq.conditions, "COALESCE(p.brand, '') <> ''")
+	brandQuery := `SELECT p.brand, COUNT(*)` + from + bq.where() + ` GROUP BY p.brand ORDER BY COUNT(*) DESC, p.brand ASC`
+	brands, err := r.queryFacetCounts(ctx, brandQuery, bq.args)
+	if err != nil {
+		return nil, fmt.Errorf("product repository: failed to compute brand facets: %w", err)
+	}
+	facets.Brands = brands
+
+	cq := newProductQuery(filters, facetCategory)
+	categoryQuery := `SELECT c.name, COUNT(*)` + from + cq.where() + ` GROUP BY c.name ORDER BY COUNT(*) DESC, c.name ASC`
+	categories, err := r.queryFacetCounts(ctx, categoryQuery, cq.args)
+	if err != nil {
+		return nil, fmt.Errorf("product repository: failed to compute category facets: %w", err)
+	}
+	facets.Categories = categories
+
+	return facets, nil
+}
+
+func (r *productRepository) queryFacetCounts(ctx context.Context, query string, args []any) ([]domain.FacetCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []domain.FacetCount{}
+	for rows.Next() {
+		var fc domain.FacetCount
+		if err := rows.Scan(&fc.Value, &fc.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, fc)
+	}
+	return counts, rows.Err()
+}
+
 
 func (r *productRepository) GetByIDForUpdate(ctx context.Context, id int64) (*models.Product, error) {
     // Note the "FOR UPDATE" clause which locks the selected row until the transaction is committed.
 	query := `
         SELECT id, name, description, price, stock_quantity, category_id, brand, sku,
-               images, thumbnail, dimensions, warranty_information, created_at, updated_at, version
+               images, thumbnail, dimensions, warranty_information, created_at, updated_at, version,
+               status, archived_at, sale_price_at(id, NULL, NOW()), active_price_schedule(id, NULL, NOW())
         FROM products
         WHERE id = $1 FOR UPDATE`
 
 	var p models.Product
+	var basePrice float64
+	var salePrice *float64
+	var scheduleID *int64
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&p.ID, &p.Name, &p.Description, &p.Price, &p.StockQuantity, &p.CategoryID, &p.Brand, &p.SKU,
+		&p.ID, &p.Name, &p.Description, &basePrice, &p.StockQuantity, &p.CategoryID, &p.Brand, &p.SKU,
 		&p.Images, &p.Thumbnail, &p.Dimensions, &p.WarrantyInformation, &p.CreatedAt, &p.UpdatedAt, &p.Version,
+		&p.Status, &p.ArchivedAt, &salePrice, &scheduleID,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -150,30 +350,7 @@ func (r *productRepository) GetByIDForUpdate(ctx context.Context, id int64) (*mo
 		}
 		return nil, fmt.Errorf("product repository: failed to get product for update: %w", err)
 	}
+	p.SetPrice(basePrice, salePrice, scheduleID)
 
 	return &p, nil
 }
-
-func (r *productRepository) UpdateStock(ctx context.Context, productID int64, quantityChange int) error {
-    // This query atomically updates the stock quantity.
-    query := `
-        UPDATE products
-        SET stock_quantity = stock_quantity + $1, updated_at = NOW()
-        WHERE id = $2`
-
-    result, err := r.db.ExecContext(ctx, query, quantityChange, productID)
-    if err != nil {
-        return fmt.Errorf("product repository: failed to update stock: %w", err)
-    }
-
-    rowsAffected, err := result.RowsAffected()
-    if err != nil {
-        return fmt.Errorf("product repository: failed to get rows affected: %w", err)
-    }
-
-    if rowsAffected == 0 {
-        return apperrors.ErrNotFound 
-    }
-
-    return nil
-}
\ No newline at end of file
@@ -0,0 +1,212 @@
+// internal/product/requests.go
+package product
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+// ParseProductFilters reads listing filters from the query string.
+// Brands can be repeated (?brand=a&brand=b) or comma-separated (?brand=a,b).
+func ParseProductFilters(query url.Values, v *validator.Validator) domain.ProductFilters {
+	filters := domain.ProductFilters{
+		Category:    query.Get("category"),
+		SearchQuery: query.Get("q"),
+		Sort:        domain.ProductSort(query.Get("sort")),
+		Page:        1,
+		PageSize:    10,
+	}
+
+	for _, raw := range query["brand"] {
+		for _, brand := range strings.Split(raw, ",") {
+			if brand = strings.TrimSpace(brand); brand != "" {
+				filters.Brands = append(filters.Brands, brand)
+			}
+		}
+	}
+
+	// Attribute filters look like ?attr.ram=16GB. Several values for one attribute,
+	// repeated or comma-separated, match any of them.
+	for key, raws := range query {
+		code, ok := strings.CutPrefix(key, "attr.")
+		if !ok {
+			continue
+		}
+		if !validator.Matches(code, validator.AttributeCodeRX) {
+			v.AddError(key, "is not a valid attribute code")
+			continue
+		}
+		for _, raw := range raws {
+			for _, value := range strings.Split(raw, ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					if filters.Attributes == nil {
+						filters.Attributes = make(map[string][]string)
+					}
+					filters.Attributes[code] = append(filters.Attributes[code], value)
+				}
+			}
+		}
+	}
+
+	if minStr := query.Get("min_price"); minStr != "" {
+		minPrice, err := strconv.ParseFloat(minStr, 64)
+		v.Check(err == nil && minPrice >= 0, "min_price", "must be a non-negative number")
+		filters.MinPrice = &minPrice
+	}
+	if maxStr := query.Get("max_price"); maxStr != "" {
+		maxPrice, err := strconv.ParseFloat(maxStr, 64)
+		v.Check(err == nil && maxPrice >= 0, "max_price", "must be a non-negative number")
+		filters.MaxPrice = &maxPrice
+	}
+	if filters.MinPrice != nil && filters.MaxPrice != nil {
+		v.Check(*filters.MinPrice <= *filters.MaxPrice, "max_price", "must be greater than or equal to min_price")
+	}
+
+	if inStockStr := query.Get("in_stock"); inStockStr != "" {
+		inStock, err := strconv.ParseBool(inStockStr)
+		v.Check(err == nil, "in_stock", "must be true or false")
+		filters.InStockOnly = inStock
+	}
+
+	v.Check(filters.Sort.IsValid(), "sort", "is not a supported sort option")
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filters.Page = page
+		}
+	}
+	if pageSizeStr := query.Get("limit"); pageSizeStr != "" {
+		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 {
+			filters.PageSize = pageSize
+		}
+	}
+
+	return filters
+}
+
+// ValidateCreateProductRequest validates an admin's new product payload
+func ValidateCreateProductRequest(r dto.CreateProductRequest, v *validator.Validator) {
+	v.Check(validator.NotBlank(r.Name), "name", "must be provided")
+	v.Check(len(r.Name) <= 255, "name", "must not exceed 255 characters")
+	v.Check(validator.NotBlank(r.Description), "description", "must be provided")
+	v.Check(r.Price > 0, "price", "must be greater than zero")
+	v.Check(r.StockQuantity >= 0, "stock_quantity", "must not be negative")
+	v.Check(r.CategoryID > 0, "category_id", "must be a valid category ID")
+	v.Check(validator.NotBlank(r.SKU), "sku", "must be provided")
+	v.Check(len(r.SKU) <= 100, "sku", "must not exceed 100 characters")
+	if r.LowStockThreshold != nil {
+		v.Check(*r.LowStockThreshold >= 0, "low_stock_threshold", "must not be negative")
+	}
+	if r.Status != "" {
+		v.Check(r.Status == models.ProductStatusDraft || r.Status == models.ProductStatusActive, "status", "must be draft or active")
+	}
+}
+
+// ValidateSetProductAttributesRequest validates the shape of an attribute replacement;
+// values are checked against the category's definitions by the service.
+func ValidateSetProductAttributesRequest(r dto.SetProductAttributesRequest, v *validator.Validator) {
+	v.Check(r.Attributes != nil, "attributes", "must be provided")
+	v.Check(len(r.Attributes) <= 100, "attributes", "must not contain more than 100 entries")
+}
+
+// ValidateSetProductStatusRequest validates a lifecycle status change
+func ValidateSetProductStatusRequest(r dto.SetProductStatusRequest, v *validator.Validator) {
+	v.Check(r.Status.IsValid(), "status", "must be one of draft, active or archived")
+}
+
+// ValidateUpdateProductRequest validates an admin's partial product update
+func ValidateUpdateProductRequest(r dto.UpdateProductRequest, v *validator.Validator) {
+	v.Check(
+		r.Name != nil || r.Description != nil || r.Price != nil || r.StockQuantity != nil ||
+			r.CategoryID != nil || r.Brand != nil || r.SKU != nil || r.Images != nil ||
+			r.Thumbnail != nil || r.Dimensions != nil || r.WarrantyInformation != nil || r.LowStockThreshold != nil,
+		"request", "at least one field must be provided for an update",
+	)
+
+	if r.Name != nil {
+		v.Check(validator.NotBlank(*r.Name), "name", "must not be empty if provided")
+		v.Check(len(*r.Name) <= 255, "name", "must not exceed 255 characters")
+	}
+	if r.Description != nil {
+		v.Check(validator.NotBlank(*r.Description), "description", "must not be empty if provided")
+	}
+	if r.Price != nil {
+		v.Check(*r.Price > 0, "price", "must be greater than zero")
+	}
+	if r.StockQuantity != nil {
+		v.Check(*r.StockQuantity >= 0, "stock_quantity", "must not be negative")
+	}
+	if r.CategoryID != nil {
+		v.Check(*r.CategoryID > 0, "category_id", "must be a valid category ID")
+	}
+	if r.SKU != nil {
+		v.Check(validator.NotBlank(*r.SKU), "sku", "must not be empty if provided")
+		v.Check(len(*r.SKU) <= 100, "sku", "must not exceed 100 characters")
+	}
+	if r.LowStockThreshold != nil {
+		v.Check(*r.LowStockThreshold >= 0, "low_stock_threshold", "must not be negative")
+	}
+	if r.Version != nil {
+		v.Check(*r.Version > 0, "version", "must be a positive integer")
+	}
+}
+
+// ValidateSetProductOptionsRequest validates the option types for a product.
+// Names must be unique and every option needs at least one distinct value.
+func ValidateSetProductOptionsRequest(r dto.SetProductOptionsRequest, v *validator.Validator) {
+	v.Check(len(r.Options) <= 5, "options", "must not contain more than 5 option types")
+
+	names := make(map[string]bool, len(r.Options))
+	for _, o := range r.Options {
+		name := strings.TrimSpace(o.Name)
+		v.Check(name != "", "options", "every option must have a name")
+		v.Check(len(name) <= 50, "options", "option names must not exceed 50 characters")
+		v.Check(!names[name], "options", "option names must be unique")
+		names[name] = true
+
+		v.Check(len(o.Values) > 0, "options", "every option must have at least one value")
+		values := make(map[string]bool, len(o.Values))
+		for _, value := range o.Values {
+			value = strings.TrimSpace(value)
+			v.Check(value != "", "options", "option values must not be empty")
+			v.Check(!values[value], "options", "option values must be unique within an option")
+			values[value] = true
+		}
+	}
+}
+
+// ValidateCreateVariantRequest validates a new variant payload
+func ValidateCreateVariantRequest(r dto.CreateVariantRequest, v *validator.Validator) {
+	v.Check(validator.NotBlank(r.SKU), "sku", "must be provided")
+	v.Check(len(r.SKU) <= 100, "sku", "must not exceed 100 characters")
+	v.Check(r.Price > 0, "price", "must be greater than zero")
+	v.Check(r.StockQuantity >= 0, "stock_quantity", "must not be negative")
+}
+
+// ValidateUpdateVariantRequest validates a partial variant update
+func ValidateUpdateVariantRequest(r dto.UpdateVariantRequest, v *validator.Validator) {
+	v.Check(
+		r.SKU != nil || r.Price != nil || r.StockQuantity != nil || r.Images != nil || r.Options != nil,
+		"request", "at least one field must be provided for an update",
+	)
+
+	if r.SKU != nil {
+		v.Check(validator.NotBlank(*r.SKU), "sku", "must not be empty if provided")
+		v.Check(len(*r.SKU) <= 100, "sku", "must not exceed 100 characters")
+	}
+	if r.Price != nil {
+		v.Check(*r.Price > 0, "price", "must be greater than zero")
+	}
+	if r.StockQuantity != nil {
+		v.Check(*r.StockQuantity >= 0, "stock_quantity", "must not be negative")
+	}
+	if r.Version != nil {
+		v.Check(*r.Version > 0, "version", "must be a positive integer")
+	}
+}
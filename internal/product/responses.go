@@ -0,0 +1,38 @@
+// internal/product/responses.go
+package product
+
+import (
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+// ProductListResponse is a page of products together with facets for the whole result set
+type ProductListResponse struct {
+	Products   []*models.Product     `json:"products"`
+	Facets     *domain.ProductFacets `json:"facets"`
+	Page       int                   `json:"page"`
+	Limit      int                   `json:"limit"`
+	Total      int                   `json:"total"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+// NewProductListResponse assembles the listing response
+func NewProductListResponse(products []*models.Product, facets *domain.ProductFacets, filters domain.ProductFilters) *ProductListResponse {
+	if products == nil {
+		products = []*models.Product{}
+	}
+
+	totalPages := 0
+	if filters.PageSize > 0 {
+		totalPages = (facets.Total + filters.PageSize - 1) / filters.PageSize
+	}
+
+	return &ProductListResponse{
+		Products:   products,
+		Facets:     facets,
+		Page:       filters.Page,
+		Limit:      filters.PageSize,
+		Total:      facets.Total,
+		TotalPages: totalPages,
+	}
+}
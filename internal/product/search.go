@@ -0,0 +1,23 @@
+// internal/product/search.go
+package product
+
+import (
+	"strings"
+	"unicode"
+)
+
+// buildPrefixTSQuery turns free text into a to_tsquery expression where every
+// word must match as a prefix, e.g. "wireless head" -> "wireless:* & head:*".
+// Everything except letters and digits is dropped so user input can never
+// produce a tsquery syntax error. It returns "" when nothing searchable remains.
+func buildPrefixTSQuery(q string) string {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, strings.ToLower(w)+":*")
+	}
+	return strings.Join(terms, " & ")
+}
@@ -2,21 +2,50 @@
 package product
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
+	"github.com/lib/pq"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/inventory"
+	"github.com/purushothdl/ecommerce-api/internal/pricing"
 	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/imageutil"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/jsonutil"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/ptr"
 )
 
 type productService struct {
-	repo   domain.ProductRepository
-	logger *slog.Logger
+	repo          domain.ProductRepository
+	variantRepo   domain.VariantRepository
+	attributeRepo domain.AttributeRepository
+	store         domain.Store
+	blobs         domain.BlobStore
+	thumbnailSize int
+	maxPixels     int
+	logger        *slog.Logger
 }
 
-func NewProductService(repo domain.ProductRepository, logger *slog.Logger) domain.ProductService {
-	return &productService{repo: repo, logger: logger}
+func NewProductService(repo domain.ProductRepository, variantRepo domain.VariantRepository, attributeRepo domain.AttributeRepository, store domain.Store, blobs domain.BlobStore, thumbnailSize, maxPixels int, logger *slog.Logger) domain.ProductService {
+	return &productService{
+		repo:          repo,
+		variantRepo:   variantRepo,
+		attributeRepo: attributeRepo,
+		store:         store,
+		blobs:         blobs,
+		thumbnailSize: thumbnailSize,
+		maxPixels:     maxPixels,
+		logger:        logger,
+	}
 }
 
 func (s *productService) ListProducts(ctx context.Context, filters domain.ProductFilters) ([]*models.Product, error) {
@@ -33,14 +62,580 @@ func (s *productService) ListProducts(ctx context.Context, filters domain.Produc
 		s.logger.Error("failed to list products from repository", "error", err)
 		return nil, fmt.Errorf("product service: could not retrieve products: %w", err)
 	}
+
+	// Attributes for the whole page come from one query.
+	ids := make([]int64, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	attributes, err := s.attributeRepo.ListValues(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to list product attributes", "error", err)
+		return nil, fmt.Errorf("product service: could not retrieve product attributes: %w", err)
+	}
+	for _, p := range products {
+		p.Attributes = attributes[p.ID]
+	}
 	return products, nil
 }
 
+func (s *productService) GetProductFacets(ctx context.Context, filters domain.ProductFilters) (*domain.ProductFacets, error) {
+	facets, err := s.repo.GetFacets(ctx, filters)
+	if err != nil {
+		s.logger.Error("failed to compute product facets", "error", err)
+		return nil, fmt.Errorf("product service: could not compute facets: %w", err)
+	}
+	return facets, nil
+}
+
 func (s *productService) GetProduct(ctx context.Context, id int64) (*models.Product, error) {
 	product, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		s.logger.Warn("failed to get product from repository", "product_id", id, "error", err)
 		return nil, fmt.Errorf("product service: could not retrieve product: %w", err)
 	}
+
+	if product.Options, err = s.variantRepo.ListOptions(ctx, id); err != nil {
+		s.logger.Error("failed to get product options", "product_id", id, "error", err)
+		return nil, fmt.Errorf("product service: could not retrieve product options: %w", err)
+	}
+	if product.Variants, err = s.variantRepo.ListByProductID(ctx, id); err != nil {
+		s.logger.Error("failed to get product variants", "product_id", id, "error", err)
+		return nil, fmt.Errorf("product service: could not retrieve product variants: %w", err)
+	}
+	attributes, err := s.attributeRepo.ListValues(ctx, []int64{id})
+	if err != nil {
+		s.logger.Error("failed to get product attributes", "product_id", id, "error", err)
+		return nil, fmt.Errorf("product service: could not retrieve product attributes: %w", err)
+	}
+	product.Attributes = attributes[id]
 	return product, nil
-}
\ No newline at end of file
+}
+
+func (s *productService) CreateProduct(ctx context.Context, actorID int64, req *dto.CreateProductRequest) (*models.Product, error) {
+	product := &models.Product{
+		Name:                req.Name,
+		Description:         req.Description,
+		Price:               req.Price,
+		CategoryID:          req.CategoryID,
+		Brand:               req.Brand,
+		SKU:                 req.SKU,
+		Images:              pq.StringArray(req.Images),
+		Thumbnail:           req.Thumbnail,
+		WarrantyInformation: req.WarrantyInformation,
+		LowStockThreshold:   req.LowStockThreshold,
+		Status:              req.Status,
+	}
+	// images is NOT NULL in the schema, so never send a nil array.
+	if product.Images == nil {
+		product.Images = pq.StringArray{}
+	}
+	if req.Dimensions != nil {
+		product.Dimensions = jsonutil.MustMarshal(req.Dimensions)
+	}
+
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		if err := q.ProductRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		err := pricing.RecordPriceChange(ctx, q, &models.PriceChange{
+			ProductID: product.ID,
+			NewPrice:  product.Price,
+			ActorID:   &actorID,
+		})
+		if err != nil {
+			return err
+		}
+		return inventory.AdjustStock(ctx, q, &models.InventoryMovement{
+			ProductID: product.ID,
+			Delta:     req.StockQuantity,
+			Reason:    models.MovementReasonManualAdjustment,
+			ActorID:   &actorID,
+			Note:      "initial stock",
+		})
+	})
+	if err != nil {
+		s.logger.Error("failed to create product", "sku", req.SKU, "error", err)
+		return nil, fmt.Errorf("product service: could not create product: %w", err)
+	}
+
+	s.logger.Info("product created", "product_id", product.ID, "sku", product.SKU, "status", product.Status)
+	return s.GetProduct(ctx, product.ID)
+}
+
+// UpdateProduct applies a partial update. A new stock_quantity is reached by adjusting
+// the default warehouse's stock and is recorded in the stock ledger as a manual
+// adjustment; without one, the current stock is left untouched. Products with variants
+// have their stock set per variant. A new price replaces the regular price and is
+// recorded in the price history; a running sale keeps applying while it is lower.
+func (s *productService) UpdateProduct(ctx context.Context, actorID int64, id int64, req *dto.UpdateProductRequest) (*models.Product, error) {
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Warn("failed to get product for update", "product_id", id, "error", err)
+		return nil, fmt.Errorf("product service: could not retrieve product: %w", err)
+	}
+
+	// The client tells us which version it edited; reject early if it is already stale.
+	if req.Version != nil && *req.Version != product.Version {
+		s.logger.Warn("stale product version in update", "product_id", id, "expected", *req.Version, "actual", product.Version)
+		return nil, apperrors.ErrEditConflict
+	}
+
+	// Edits start from the regular price, not the sale price the read resolved.
+	product.Price = product.BasePrice
+	ptr.UpdateStringIfProvided(&product.Name, req.Name)
+	ptr.UpdateStringIfProvided(&product.Description, req.Description)
+	ptr.UpdateStringIfProvided(&product.Brand, req.Brand)
+	ptr.UpdateStringIfProvided(&product.SKU, req.SKU)
+	ptr.UpdateStringIfProvided(&product.Thumbnail, req.Thumbnail)
+	ptr.UpdateStringIfProvided(&product.WarrantyInformation, req.WarrantyInformation)
+	if req.Price != nil {
+		product.Price = *req.Price
+	}
+	if req.CategoryID != nil {
+		product.CategoryID = *req.CategoryID
+	}
+	if req.LowStockThreshold != nil {
+		product.LowStockThreshold = req.LowStockThreshold
+	}
+	if req.Images != nil {
+		product.Images = pq.StringArray(req.Images)
+	}
+	if req.Dimensions != nil {
+		product.Dimensions = jsonutil.MustMarshal(req.Dimensions)
+	}
+
+	err = s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		// Read stock under lock so orders placed since the read above are not overwritten.
+		current, err := q.ProductRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.StockQuantity != nil {
+			variants, err := q.VariantRepo.ListByProductID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if len(variants) > 0 {
+				return apperrors.ErrVariantRequired
+			}
+			err = inventory.AdjustStock(ctx, q, &models.InventoryMovement{
+				ProductID: id,
+				Delta:     *req.StockQuantity - current.StockQuantity,
+				Reason:    models.MovementReasonManualAdjustment,
+				ActorID:   &actorID,
+				Note:      "product update",
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = pricing.RecordPriceChange(ctx, q, &models.PriceChange{
+			ProductID: id,
+			OldPrice:  &current.BasePrice,
+			NewPrice:  product.Price,
+			ActorID:   &actorID,
+		})
+		if err != nil {
+			return err
+		}
+		// Attributes are defined per category, so values for the old one no longer apply.
+		if product.CategoryID != current.CategoryID {
+			if err := q.AttributeRepo.DeleteValuesOutsideCategory(ctx, id, product.CategoryID); err != nil {
+				return err
+			}
+		}
+		return q.ProductRepo.Update(ctx, product)
+	})
+	if err != nil {
+		s.logger.Warn("failed to update product", "product_id", id, "error", err)
+		return nil, fmt.Errorf("product service: could not update product: %w", err)
+	}
+
+	s.logger.Info("product updated", "product_id", id, "version", product.Version)
+	return s.GetProduct(ctx, id)
+}
+
+// SetProductStatus publishes, unpublishes, archives or restores a product. Only active
+// products are listed and sellable; archived ones still resolve for order history.
+func (s *productService) SetProductStatus(ctx context.Context, id int64, status models.ProductStatus) (*models.Product, error) {
+	if err := s.repo.SetStatus(ctx, id, status); err != nil {
+		s.logger.Warn("failed to set product status", "product_id", id, "status", status, "error", err)
+		return nil, fmt.Errorf("product service: could not set product status: %w", err)
+	}
+
+	s.logger.Info("product status changed", "product_id", id, "status", status)
+	return s.GetProduct(ctx, id)
+}
+
+func (s *productService) ArchiveProduct(ctx context.Context, id int64) (*models.Product, error) {
+	return s.SetProductStatus(ctx, id, models.ProductStatusArchived)
+}
+
+// DeleteProduct removes a draft that was never ordered for good. Any other product is
+// archived instead, because carts, wishlists and order history still refer to it.
+// It reports whether the product was archived rather than removed.
+func (s *productService) DeleteProduct(ctx context.Context, id int64) (bool, error) {
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Warn("failed to get product for delete", "product_id", id, "error", err)
+		return false, fmt.Errorf("product service: could not retrieve product: %w", err)
+	}
+
+	if product.IsDraft() {
+		err := s.repo.Delete(ctx, id)
+		if err == nil {
+			s.logger.Info("product deleted", "product_id", id)
+			return false, nil
+		}
+		if !errors.Is(err, apperrors.ErrProductHasOrders) {
+			s.logger.Warn("failed to delete product", "product_id", id, "error", err)
+			return false, fmt.Errorf("product service: could not delete product: %w", err)
+		}
+	}
+
+	if err := s.repo.SetStatus(ctx, id, models.ProductStatusArchived); err != nil {
+		s.logger.Warn("failed to archive deleted product", "product_id", id, "error", err)
+		return false, fmt.Errorf("product service: could not archive product: %w", err)
+	}
+
+	s.logger.Info("product archived on delete", "product_id", id)
+	return true, nil
+}
+
+// SetProductAttributes replaces a product's attribute values. Every key must be the code
+// of an attribute defined for the product's category, and every value must suit it.
+func (s *productService) SetProductAttributes(ctx context.Context, productID int64, req *dto.SetProductAttributesRequest) ([]models.ProductAttribute, error) {
+	var attributes []models.ProductAttribute
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		product, err := q.ProductRepo.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		definitions, err := q.AttributeRepo.ListDefinitions(ctx, product.CategoryID)
+		if err != nil {
+			return err
+		}
+		values, err := matchProductAttributes(definitions, req.Attributes)
+		if err != nil {
+			return err
+		}
+		if err := q.AttributeRepo.ReplaceValues(ctx, productID, values); err != nil {
+			return err
+		}
+
+		byProduct, err := q.AttributeRepo.ListValues(ctx, []int64{productID})
+		attributes = byProduct[productID]
+		return err
+	})
+	if err != nil {
+		s.logger.Warn("failed to set product attributes", "product_id", productID, "error", err)
+		if errors.Is(err, apperrors.ErrInvalidAttributeValue) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("product service: could not set product attributes: %w", err)
+	}
+
+	if attributes == nil {
+		attributes = []models.ProductAttribute{}
+	}
+	s.logger.Info("product attributes updated", "product_id", productID, "count", len(attributes))
+	return attributes, nil
+}
+
+// SetProductOptions replaces the option types of a product. Existing variants must
+// still be describable by the new set, otherwise the change is refused.
+func (s *productService) SetProductOptions(ctx context.Context, productID int64, req *dto.SetProductOptionsRequest) ([]models.ProductOption, error) {
+	options := make([]models.ProductOption, len(req.Options))
+	for i, o := range req.Options {
+		values := make(pq.StringArray, len(o.Values))
+		for j, value := range o.Values {
+			values[j] = strings.TrimSpace(value)
+		}
+		options[i] = models.ProductOption{
+			ProductID: productID,
+			Name:      strings.TrimSpace(o.Name),
+			Values:    values,
+			Position:  i,
+		}
+	}
+
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		if _, err := q.ProductRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+
+		variants, err := q.VariantRepo.ListByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		for _, variant := range variants {
+			var chosen map[string]string
+			if err := json.Unmarshal(variant.Options, &chosen); err != nil {
+				return fmt.Errorf("decode options of variant %d: %w", variant.ID, err)
+			}
+			if err := matchVariantOptions(options, chosen); err != nil {
+				s.logger.Warn("option change would orphan variant", "product_id", productID, "variant_id", variant.ID)
+				return apperrors.ErrOptionsInUse
+			}
+		}
+
+		return q.VariantRepo.ReplaceOptions(ctx, productID, options)
+	})
+	if err != nil {
+		s.logger.Warn("failed to set product options", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("product service: could not set product options: %w", err)
+	}
+
+	s.logger.Info("product options updated", "product_id", productID, "count", len(options))
+	return options, nil
+}
+
+func (s *productService) CreateVariant(ctx context.Context, actorID int64, productID int64, req *dto.CreateVariantRequest) (*models.ProductVariant, error) {
+	if _, err := s.repo.GetByID(ctx, productID); err != nil {
+		s.logger.Warn("failed to get product for new variant", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("product service: could not retrieve product: %w", err)
+	}
+
+	options, err := s.variantRepo.ListOptions(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("product service: could not retrieve product options: %w", err)
+	}
+	chosen := normalizeVariantOptions(req.Options)
+	if err := matchVariantOptions(options, chosen); err != nil {
+		return nil, err
+	}
+
+	variant := &models.ProductVariant{
+		ProductID:     productID,
+		SKU:           req.SKU,
+		Price:         req.Price,
+		Images:        pq.StringArray(req.Images),
+		Options:       jsonutil.MustMarshal(chosen),
+	}
+	if variant.Images == nil {
+		variant.Images = pq.StringArray{}
+	}
+
+	err = s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		if err := q.VariantRepo.Create(ctx, variant); err != nil {
+			return err
+		}
+		err := pricing.RecordPriceChange(ctx, q, &models.PriceChange{
+			ProductID: productID,
+			VariantID: &variant.ID,
+			NewPrice:  variant.Price,
+			ActorID:   &actorID,
+		})
+		if err != nil {
+			return err
+		}
+		err = inventory.AdjustStock(ctx, q, &models.InventoryMovement{
+			ProductID: productID,
+			VariantID: &variant.ID,
+			Delta:     req.StockQuantity,
+			Reason:    models.MovementReasonManualAdjustment,
+			ActorID:   &actorID,
+			Note:      "initial stock",
+		})
+		if err != nil {
+			return err
+		}
+		// Re-read so the returned stock includes what was just added.
+		variant, err = q.VariantRepo.GetByID(ctx, variant.ID)
+		return err
+	})
+	if err != nil {
+		s.logger.Warn("failed to create variant", "product_id", productID, "sku", req.SKU, "error", err)
+		return nil, fmt.Errorf("product service: could not create variant: %w", err)
+	}
+
+	s.logger.Info("variant created", "product_id", productID, "variant_id", variant.ID, "sku", variant.SKU)
+	return variant, nil
+}
+
+// UpdateVariant applies a partial update; stock and price changes are handled like UpdateProduct's.
+func (s *productService) UpdateVariant(ctx context.Context, actorID int64, productID, variantID int64, req *dto.UpdateVariantRequest) (*models.ProductVariant, error) {
+	variant, err := s.getProductVariant(ctx, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Version != nil && *req.Version != variant.Version {
+		s.logger.Warn("stale variant version in update", "variant_id", variantID, "expected", *req.Version, "actual", variant.Version)
+		return nil, apperrors.ErrEditConflict
+	}
+
+	variant.Price = variant.BasePrice
+	ptr.UpdateStringIfProvided(&variant.SKU, req.SKU)
+	if req.Price != nil {
+		variant.Price = *req.Price
+	}
+	if req.Images != nil {
+		variant.Images = pq.StringArray(req.Images)
+	}
+	if req.Options != nil {
+		options, err := s.variantRepo.ListOptions(ctx, productID)
+		if err != nil {
+			return nil, fmt.Errorf("product service: could not retrieve product options: %w", err)
+		}
+		chosen := normalizeVariantOptions(req.Options)
+		if err := matchVariantOptions(options, chosen); err != nil {
+			return nil, err
+		}
+		variant.Options = jsonutil.MustMarshal(chosen)
+	}
+
+	err = s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		current, err := q.VariantRepo.GetByIDForUpdate(ctx, variantID)
+		if err != nil {
+			return err
+		}
+
+		if req.StockQuantity != nil {
+			err = inventory.AdjustStock(ctx, q, &models.InventoryMovement{
+				ProductID: productID,
+				VariantID: &variantID,
+				Delta:     *req.StockQuantity - current.StockQuantity,
+				Reason:    models.MovementReasonManualAdjustment,
+				ActorID:   &actorID,
+				Note:      "variant update",
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = pricing.RecordPriceChange(ctx, q, &models.PriceChange{
+			ProductID: productID,
+			VariantID: &variantID,
+			OldPrice:  &current.BasePrice,
+			NewPrice:  variant.Price,
+			ActorID:   &actorID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.VariantRepo.Update(ctx, variant); err != nil {
+			return err
+		}
+		// Re-read so the returned price reflects any sale running.
+		variant, err = q.VariantRepo.GetByID(ctx, variantID)
+		return err
+	})
+	if err != nil {
+		s.logger.Warn("failed to update variant", "variant_id", variantID, "error", err)
+		return nil, fmt.Errorf("product service: could not update variant: %w", err)
+	}
+
+	s.logger.Info("variant updated", "variant_id", variantID, "version", variant.Version)
+	return variant, nil
+}
+
+func (s *productService) DeleteVariant(ctx context.Context, productID, variantID int64) error {
+	if _, err := s.getProductVariant(ctx, productID, variantID); err != nil {
+		return err
+	}
+
+	if err := s.variantRepo.Delete(ctx, variantID); err != nil {
+		s.logger.Warn("failed to delete variant", "variant_id", variantID, "error", err)
+		return fmt.Errorf("product service: could not delete variant: %w", err)
+	}
+
+	s.logger.Info("variant deleted", "product_id", productID, "variant_id", variantID)
+	return nil
+}
+
+// UploadProductImage stores an image and a generated thumbnail in blob storage and adds
+// the image to the product's gallery. The thumbnail becomes the product's main thumbnail
+// when it has none yet or when makePrimary is set.
+func (s *productService) UploadProductImage(ctx context.Context, productID int64, data []byte, makePrimary bool) (*models.Product, error) {
+	contentType, ext, err := imageutil.DetectType(data)
+	if err != nil {
+		return nil, apperrors.ErrUnsupportedMediaType
+	}
+
+	thumbnail, err := imageutil.Thumbnail(data, s.thumbnailSize, s.maxPixels)
+	if err != nil {
+		s.logger.Warn("failed to generate thumbnail", "product_id", productID, "error", err)
+		if errors.Is(err, imageutil.ErrUnsupportedFormat) {
+			return nil, apperrors.ErrUnsupportedMediaType
+		}
+		if errors.Is(err, imageutil.ErrTooManyPixels) {
+			return nil, apperrors.ErrImageTooLarge
+		}
+		return nil, fmt.Errorf("product service: could not generate thumbnail: %w", err)
+	}
+
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Warn("failed to get product for image upload", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("product service: could not retrieve product: %w", err)
+	}
+
+	name, err := randomBlobName()
+	if err != nil {
+		return nil, fmt.Errorf("product service: could not name image: %w", err)
+	}
+	imageKey := fmt.Sprintf("products/%d/%s%s", productID, name, ext)
+	thumbKey := fmt.Sprintf("products/%d/%s_thumb.jpg", productID, name)
+
+	if err := s.blobs.Put(ctx, imageKey, bytes.NewReader(data), contentType); err != nil {
+		s.logger.Error("failed to store product image", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("product service: could not store image: %w", err)
+	}
+	if err := s.blobs.Put(ctx, thumbKey, bytes.NewReader(thumbnail), "image/jpeg"); err != nil {
+		s.logger.Error("failed to store product thumbnail", "product_id", productID, "error", err)
+		s.deleteBlobs(ctx, imageKey)
+		return nil, fmt.Errorf("product service: could not store thumbnail: %w", err)
+	}
+
+	// Update writes the price too; keep the regular one, not the sale price the read resolved.
+	product.Price = product.BasePrice
+	product.Images = append(product.Images, s.blobs.URL(imageKey))
+	if makePrimary || product.Thumbnail == "" {
+		product.Thumbnail = s.blobs.URL(thumbKey)
+	}
+
+	if err := s.repo.Update(ctx, product); err != nil {
+		s.logger.Warn("failed to attach image to product", "product_id", productID, "error", err)
+		s.deleteBlobs(ctx, imageKey, thumbKey)
+		return nil, fmt.Errorf("product service: could not update product: %w", err)
+	}
+
+	s.logger.Info("product image uploaded", "product_id", productID, "key", imageKey, "bytes", len(data))
+	return s.GetProduct(ctx, productID)
+}
+
+// deleteBlobs removes blobs written for an upload that could not be completed.
+func (s *productService) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			s.logger.Error("failed to clean up orphaned blob", "key", key, "error", err)
+		}
+	}
+}
+
+// randomBlobName returns an unguessable file name so uploads never overwrite each other.
+func randomBlobName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// getProductVariant loads a variant and makes sure it belongs to the product in the URL.
+func (s *productService) getProductVariant(ctx context.Context, productID, variantID int64) (*models.ProductVariant, error) {
+	variant, err := s.variantRepo.GetByID(ctx, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("product service: could not retrieve variant: %w", err)
+	}
+	if variant.ProductID != productID {
+		return nil, apperrors.ErrNotFound
+	}
+	return variant, nil
+}
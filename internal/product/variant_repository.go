@@ -0,0 +1,211 @@
+// internal/product/variant_repository.go
+package product
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type variantRepository struct {
+	db domain.DBTX
+}
+
+func NewVariantRepository(db domain.DBTX) domain.VariantRepository {
+	return &variantRepository{db: db}
+}
+
+const variantColumns = `id, product_id, sku, price, stock_quantity, stock_quantity - reserved_stock(product_id, id),
+    images, options, created_at, updated_at, version,
+    sale_price_at(product_id, id, NOW()), active_price_schedule(product_id, id, NOW())`
+
+func scanVariant(row interface{ Scan(dest ...any) error }, v *models.ProductVariant) error {
+	var basePrice float64
+	var salePrice *float64
+	var scheduleID *int64
+	err := row.Scan(
+		&v.ID, &v.ProductID, &v.SKU, &basePrice, &v.StockQuantity, &v.AvailableStock, &v.Images, &v.Options,
+		&v.CreatedAt, &v.UpdatedAt, &v.Version, &salePrice, &scheduleID,
+	)
+	if err != nil {
+		return err
+	}
+	v.SetPrice(basePrice, salePrice, scheduleID)
+	return nil
+}
+
+func (r *variantRepository) ListOptions(ctx context.Context, productID int64) ([]models.ProductOption, error) {
+	query := `
+        SELECT id, product_id, name, "values", position
+        FROM product_options
+        WHERE product_id = $1
+        ORDER BY position, id`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("variant repository: failed to list options: %w", err)
+	}
+	defer rows.Close()
+
+	options := []models.ProductOption{}
+	for rows.Next() {
+		var o models.ProductOption
+		if err := rows.Scan(&o.ID, &o.ProductID, &o.Name, &o.Values, &o.Position); err != nil {
+			return nil, fmt.Errorf("variant repository: failed to scan option: %w", err)
+		}
+		options = append(options, o)
+	}
+	return options, rows.Err()
+}
+
+// ReplaceOptions swaps the product's option types for the given set.
+// Callers should run it inside a transaction so readers never see a partial set.
+func (r *variantRepository) ReplaceOptions(ctx context.Context, productID int64, options []models.ProductOption) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM product_options WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("variant repository: failed to clear options: %w", err)
+	}
+
+	query := `
+        INSERT INTO product_options (product_id, name, "values", position)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id`
+	for i := range options {
+		o := &options[i]
+		o.ProductID = productID
+		if err := r.db.QueryRowContext(ctx, query, productID, o.Name, o.Values, o.Position).Scan(&o.ID); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return apperrors.ErrNotFound
+			}
+			return fmt.Errorf("variant repository: failed to insert option: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *variantRepository) ListByProductID(ctx context.Context, productID int64) ([]models.ProductVariant, error) {
+	query := `SELECT ` + variantColumns + ` FROM product_variants WHERE product_id = $1 ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("variant repository: failed to list variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := []models.ProductVariant{}
+	for rows.Next() {
+		var v models.ProductVariant
+		if err := scanVariant(rows, &v); err != nil {
+			return nil, fmt.Errorf("variant repository: failed to scan variant: %w", err)
+		}
+		variants = append(variants, v)
+	}
+	return variants, rows.Err()
+}
+
+func (r *variantRepository) GetByID(ctx context.Context, id int64) (*models.ProductVariant, error) {
+	query := `SELECT ` + variantColumns + ` FROM product_variants WHERE id = $1`
+
+	var v models.ProductVariant
+	if err := scanVariant(r.db.QueryRowContext(ctx, query, id), &v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("variant repository: failed to get variant: %w", err)
+	}
+	return &v, nil
+}
+
+// GetByIDForUpdate locks the variant row until the surrounding transaction ends,
+// so concurrent checkouts of the same variant are serialised.
+func (r *variantRepository) GetByIDForUpdate(ctx context.Context, id int64) (*models.ProductVariant, error) {
+	query := `SELECT ` + variantColumns + ` FROM product_variants WHERE id = $1 FOR UPDATE`
+
+	var v models.ProductVariant
+	if err := scanVariant(r.db.QueryRowContext(ctx, query, id), &v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("variant repository: failed to get variant for update: %w", err)
+	}
+	return &v, nil
+}
+
+// Create inserts a variant with no stock; stock is added through warehouse stock.
+func (r *variantRepository) Create(ctx context.Context, v *models.ProductVariant) error {
+	query := `
+        INSERT INTO product_variants (product_id, sku, price, stock_quantity, images, options)
+        VALUES ($1, $2, $3, 0, $4, $5)
+        RETURNING id, stock_quantity, stock_quantity - reserved_stock(product_id, id), created_at, updated_at, version`
+	err := r.db.QueryRowContext(ctx, query, v.ProductID, v.SKU, v.Price, v.Images, v.Options).
+		Scan(&v.ID, &v.StockQuantity, &v.AvailableStock, &v.CreatedAt, &v.UpdatedAt, &v.Version)
+	if err != nil {
+		if mapped := mapVariantWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("variant repository: failed to create variant: %w", err)
+	}
+	return nil
+}
+
+// Update writes all editable variant fields except stock, guarded by the version the caller read.
+func (r *variantRepository) Update(ctx context.Context, v *models.ProductVariant) error {
+	query := `
+        UPDATE product_variants
+        SET sku = $1, price = $2, images = $3, options = $4,
+            updated_at = NOW(), version = version + 1
+        WHERE id = $5 AND version = $6
+        RETURNING stock_quantity, stock_quantity - reserved_stock(product_id, id), updated_at, version`
+	err := r.db.QueryRowContext(ctx, query, v.SKU, v.Price, v.Images, v.Options, v.ID, v.Version).
+		Scan(&v.StockQuantity, &v.AvailableStock, &v.UpdatedAt, &v.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.ErrEditConflict
+		}
+		if mapped := mapVariantWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("variant repository: failed to update variant: %w", err)
+	}
+	return nil
+}
+
+func (r *variantRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM product_variants WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("variant repository: failed to delete variant: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("variant repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// mapVariantWriteError translates constraint violations on variant writes into domain errors.
+func mapVariantWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+	switch pgErr.Code {
+	case "23505":
+		if pgErr.ConstraintName == "product_variants_sku_key" {
+			return apperrors.ErrDuplicateSKU
+		}
+		return apperrors.ErrDuplicateVariant
+	case "23503":
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// internal/product/variants.go
+package product
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+// matchVariantOptions checks that chosen picks exactly one allowed value for every
+// option type of the product, and nothing else.
+func matchVariantOptions(options []models.ProductOption, chosen map[string]string) error {
+	if len(chosen) != len(options) {
+		return apperrors.ErrInvalidVariantOptions
+	}
+	for _, option := range options {
+		value, ok := chosen[option.Name]
+		if !ok || !slices.Contains(option.Values, value) {
+			return apperrors.ErrInvalidVariantOptions
+		}
+	}
+	return nil
+}
+
+// normalizeVariantOptions trims option names and values so equal choices compare equal
+// under the (product_id, options) uniqueness constraint.
+func normalizeVariantOptions(chosen map[string]string) map[string]string {
+	normalized := make(map[string]string, len(chosen))
+	for name, value := range chosen {
+		normalized[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return normalized
+}
@@ -0,0 +1,51 @@
+// internal/recommendation/handler.go
+package recommendation
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	recommendationSvc domain.RecommendationService
+	logger            *slog.Logger
+}
+
+func NewHandler(recommendationSvc domain.RecommendationService, logger *slog.Logger) *Handler {
+	return &Handler{recommendationSvc: recommendationSvc, logger: logger}
+}
+
+// HandleGetRecommendations returns products bought together with, or similar to, a product.
+func (h *Handler) HandleGetRecommendations(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	v := validator.New()
+	limit := ParseLimit(r.URL.Query(), v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	recommendations, err := h.recommendationSvc.GetRecommendations(r.Context(), productID, limit)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "product not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "could not retrieve recommendations")
+		return
+	}
+	response.JSON(w, http.StatusOK, recommendations)
+}
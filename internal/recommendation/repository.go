@@ -0,0 +1,130 @@
+// internal/recommendation/repository.go
+package recommendation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+type recommendationRepository struct {
+	db domain.DBTX
+}
+
+func NewRecommendationRepository(db domain.DBTX) domain.RecommendationRepository {
+	return &recommendationRepository{db: db}
+}
+
+// refreshQuery rebuilds every product's list in one statement. Bought-together products
+// always rank ahead of similar ones; a product that is both is kept as bought together.
+// Similar products share the category or brand and rank by how many of the two match,
+// then by rating. Archived products neither get nor appear in recommendations.
+const refreshQuery = `
+    WITH purchases AS (
+        SELECT DISTINCT oi.order_id, oi.product_id
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE o.payment_status = 'paid'
+    ),
+    bought_together AS (
+        SELECT a.product_id, b.product_id AS recommended_product_id, COUNT(*)::double precision AS score
+        FROM purchases a
+        JOIN purchases b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+        GROUP BY a.product_id, b.product_id
+    ),
+    similar AS (
+        SELECT p.id AS product_id, s.id AS recommended_product_id, s.score
+        FROM products p
+        CROSS JOIN LATERAL (
+            SELECT o.id,
+                   ((o.category_id = p.category_id)::int
+                    + (COALESCE(p.brand, '') <> '' AND COALESCE(o.brand, '') = p.brand)::int)::double precision AS score
+            FROM products o
+            WHERE o.id <> p.id AND o.status = 'active'
+              AND (o.category_id = p.category_id OR (COALESCE(p.brand, '') <> '' AND o.brand = p.brand))
+            ORDER BY score DESC, o.rating_average DESC, o.review_count DESC, o.id
+            LIMIT $1
+        ) s
+        WHERE p.status = 'active'
+    ),
+    candidates AS (
+        SELECT product_id, recommended_product_id, 'bought_together' AS kind, score, 0 AS tier FROM bought_together
+        UNION ALL
+        SELECT product_id, recommended_product_id, 'similar', score, 1 FROM similar
+    ),
+    deduped AS (
+        SELECT DISTINCT ON (c.product_id, c.recommended_product_id) c.*
+        FROM candidates c
+        JOIN products p ON p.id = c.product_id AND p.status = 'active'
+        JOIN products rp ON rp.id = c.recommended_product_id AND rp.status = 'active'
+        ORDER BY c.product_id, c.recommended_product_id, c.tier
+    ),
+    ranked AS (
+        SELECT product_id, recommended_product_id, kind, score,
+               ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY tier, score DESC, recommended_product_id) AS position
+        FROM deduped
+    )
+    INSERT INTO product_recommendations (product_id, recommended_product_id, kind, score, position)
+    SELECT product_id, recommended_product_id, kind, score, position
+    FROM ranked
+    WHERE position <= $1`
+
+// Replace throws away all stored recommendations and computes them again, keeping up
+// to perProduct for each product. Run it inside a transaction so readers never see an
+// empty table. It returns the number of rows written.
+func (r *recommendationRepository) Replace(ctx context.Context, perProduct int) (int, error) {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM product_recommendations`); err != nil {
+		return 0, fmt.Errorf("recommendation repository: failed to clear recommendations: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, refreshQuery, perProduct)
+	if err != nil {
+		return 0, fmt.Errorf("recommendation repository: failed to compute recommendations: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("recommendation repository: failed to get rows affected: %w", err)
+	}
+	return int(rowsAffected), nil
+}
+
+// ListByProduct returns a product's recommendations in order, skipping products
+// unpublished or archived since the last refresh.
+func (r *recommendationRepository) ListByProduct(ctx context.Context, productID int64, limit int) ([]*models.Recommendation, error) {
+	query := `
+        SELECT r.kind, r.score, p.id, p.name, p.price, p.thumbnail, p.brand, p.rating_average, p.review_count,
+               p.stock_quantity - reserved_stock(p.id, NULL),
+               sale_price_at(p.id, NULL, NOW()), active_price_schedule(p.id, NULL, NOW())
+        FROM product_recommendations r
+        JOIN products p ON p.id = r.recommended_product_id
+        WHERE r.product_id = $1 AND p.status = 'active'
+        ORDER BY r.position
+        LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("recommendation repository: failed to list recommendations: %w", err)
+	}
+	defer rows.Close()
+
+	recommendations := []*models.Recommendation{}
+	for rows.Next() {
+		var rec models.Recommendation
+		var p models.Product
+		var basePrice float64
+		var salePrice *float64
+		var scheduleID *int64
+		if err := rows.Scan(
+			&rec.Kind, &rec.Score, &p.ID, &p.Name, &basePrice, &p.Thumbnail, &p.Brand, &p.RatingAverage, &p.ReviewCount,
+			&p.AvailableStock, &salePrice, &scheduleID,
+		); err != nil {
+			return nil, fmt.Errorf("recommendation repository: failed to scan recommendation: %w", err)
+		}
+		p.SetPrice(basePrice, salePrice, scheduleID)
+		rec.Product = &p
+		recommendations = append(recommendations, &rec)
+	}
+	return recommendations, rows.Err()
+}
@@ -0,0 +1,23 @@
+// internal/recommendation/requests.go
+package recommendation
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+// defaultLimit is how many recommendations a product page gets unless it asks for more.
+const defaultLimit = 8
+
+// ParseLimit reads the optional ?limit= of a recommendations request.
+func ParseLimit(query url.Values, v *validator.Validator) int {
+	limit := defaultLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		v.Check(err == nil && limit > 0 && limit <= MaxRecommendations, "limit", "must be between 1 and "+strconv.Itoa(MaxRecommendations))
+	}
+	return limit
+}
@@ -0,0 +1,67 @@
+// internal/recommendation/service.go
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+// MaxRecommendations is how many recommendations are stored, and can be asked for, per product.
+const MaxRecommendations = 20
+
+type recommendationService struct {
+	repo        domain.RecommendationRepository
+	productRepo domain.ProductRepository
+	store       domain.Store
+	logger      *slog.Logger
+}
+
+func NewRecommendationService(repo domain.RecommendationRepository, productRepo domain.ProductRepository, store domain.Store, logger *slog.Logger) domain.RecommendationService {
+	return &recommendationService{
+		repo:        repo,
+		productRepo: productRepo,
+		store:       store,
+		logger:      logger,
+	}
+}
+
+// GetRecommendations returns up to limit products to show alongside a product, as of
+// the last refresh. Drafts and archived products have none.
+func (s *recommendationService) GetRecommendations(ctx context.Context, productID int64, limit int) ([]*models.Recommendation, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("recommendation service: could not retrieve product: %w", err)
+	}
+	if !product.IsPurchasable() {
+		return []*models.Recommendation{}, nil
+	}
+
+	recommendations, err := s.repo.ListByProduct(ctx, productID, limit)
+	if err != nil {
+		s.logger.Error("failed to list recommendations", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("recommendation service: could not retrieve recommendations: %w", err)
+	}
+	return recommendations, nil
+}
+
+// RefreshRecommendations recomputes every product's recommendations from paid orders
+// and the catalog. The old set stays visible until the new one commits.
+func (s *recommendationService) RefreshRecommendations(ctx context.Context) (int, error) {
+	var written int
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		var err error
+		written, err = q.RecommendationRepo.Replace(ctx, MaxRecommendations)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("failed to refresh recommendations", "error", err)
+		return 0, fmt.Errorf("recommendation service: could not refresh recommendations: %w", err)
+	}
+
+	s.logger.Info("recommendations refreshed", "rows", written)
+	return written, nil
+}
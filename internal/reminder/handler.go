@@ -0,0 +1,114 @@
+// internal/reminder/handler.go
+package reminder
+
+import (
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+	"github.com/purushothdl/ecommerce-api/pkg/web"
+)
+
+type Handler struct {
+	reminderSvc domain.CartReminderService
+	logger      *slog.Logger
+}
+
+func NewHandler(reminderSvc domain.CartReminderService, logger *slog.Logger) *Handler {
+	return &Handler{reminderSvc: reminderSvc, logger: logger}
+}
+
+// unsubscribePage is shown for the link in reminder emails. Opening the link changes
+// nothing, since mail scanners and link prefetchers open links too; the button posts
+// the token back to unsubscribe.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>Cart reminders</title></head>
+<body style="font-family: Arial, sans-serif; max-width: 480px; margin: 40px auto;">
+{{if .Done}}
+    <p>You will no longer receive cart reminder emails.</p>
+{{else}}
+    <p>Stop receiving emails about items left in your cart?</p>
+    <form method="post">
+        <input type="hidden" name="token" value="{{.Token}}">
+        <button type="submit">Unsubscribe</button>
+    </form>
+{{end}}
+</body>
+</html>`))
+
+// HandleUnsubscribePage shows the confirmation page for an unsubscribe link. It does
+// not unsubscribe anyone.
+func (h *Handler) HandleUnsubscribePage(w http.ResponseWriter, r *http.Request) {
+	h.renderUnsubscribePage(w, r.URL.Query().Get("token"), false)
+}
+
+// HandleUnsubscribe turns off abandoned cart reminders for the customer a signed token
+// belongs to. It needs no sign-in, so it works from any mail client. The token comes
+// from the form on the confirmation page or from the ?token= of the link itself, which
+// is where RFC 8058 one-click requests (a POST with List-Unsubscribe=One-Click) carry it.
+// Browsers get the page back; other clients get JSON.
+func (h *Handler) HandleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	err := h.reminderSvc.Unsubscribe(r.Context(), r.FormValue("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, web.ErrInvalidUnsubscribeToken):
+			response.Error(w, http.StatusBadRequest, "invalid unsubscribe link")
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "user not found")
+		default:
+			h.logger.Error("failed to unsubscribe from cart reminders", "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not unsubscribe")
+		}
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		h.renderUnsubscribePage(w, "", true)
+		return
+	}
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "you will no longer receive cart reminder emails"})
+}
+
+func (h *Handler) renderUnsubscribePage(w http.ResponseWriter, token string, done bool) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	if err := unsubscribePage.Execute(w, struct {
+		Token string
+		Done  bool
+	}{token, done}); err != nil {
+		h.logger.Error("failed to render unsubscribe page", "error", err)
+	}
+}
+
+// HandleGetStats reports reminders sent over the last ?days= (30 by default) and how
+// many of the reminded carts were ordered afterwards.
+func (h *Handler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	v := validator.New()
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		v.Check(err == nil && days > 0 && days <= 365, "days", "must be between 1 and 365")
+	}
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	stats, err := h.reminderSvc.GetStats(r.Context(), time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		h.logger.Error("failed to get cart reminder stats", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not get cart reminder stats")
+		return
+	}
+	response.JSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,111 @@
+// internal/reminder/repository.go
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type cartReminderRepository struct {
+	db domain.DBTX
+}
+
+func NewCartReminderRepository(db domain.DBTX) domain.CartReminderRepository {
+	return &cartReminderRepository{db: db}
+}
+
+// ClaimNextAbandoned locks the longest idle cart that is due a reminder: it belongs to a
+// customer who has not opted out, has items to check out, has not changed since
+// idleSince, has had fewer than maxReminders since its last order, and was not reminded
+// since idleSince either, which spaces reminders out by the same idle time. SKIP LOCKED
+// lets several workers run at once without emailing the same cart twice.
+func (r *cartReminderRepository) ClaimNextAbandoned(ctx context.Context, idleSince time.Time, maxReminders int) (*models.AbandonedCart, error) {
+	query := `
+        SELECT c.id, c.name, u.id, u.name, u.email,
+               (SELECT COUNT(*) FROM cart_reminders cr WHERE cr.cart_id = c.id AND cr.converted_at IS NULL)
+        FROM carts c
+        JOIN users u ON u.id = c.user_id
+        WHERE u.cart_reminders_enabled
+          AND c.updated_at < $1
+          AND EXISTS (SELECT 1 FROM cart_items ci WHERE ci.cart_id = c.id AND NOT ci.saved_for_later)
+          AND (SELECT COUNT(*) FROM cart_reminders cr WHERE cr.cart_id = c.id AND cr.converted_at IS NULL) < $2
+          AND NOT EXISTS (SELECT 1 FROM cart_reminders cr WHERE cr.cart_id = c.id AND cr.sent_at >= $1)
+        ORDER BY c.updated_at, c.id
+        LIMIT 1
+        FOR UPDATE OF c SKIP LOCKED`
+
+	var a models.AbandonedCart
+	err := r.db.QueryRowContext(ctx, query, idleSince, maxReminders).
+		Scan(&a.CartID, &a.CartName, &a.UserID, &a.Name, &a.Email, &a.RemindersSent)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("cart reminder repository: failed to claim abandoned cart: %w", err)
+	}
+	return &a, nil
+}
+
+func (r *cartReminderRepository) Create(ctx context.Context, reminder *models.CartReminder) error {
+	query := `
+        INSERT INTO cart_reminders (cart_id, user_id, item_count, cart_total)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, sent_at`
+	err := r.db.QueryRowContext(ctx, query, reminder.CartID, reminder.UserID, reminder.ItemCount, reminder.CartTotal).
+		Scan(&reminder.ID, &reminder.SentAt)
+	if err != nil {
+		return fmt.Errorf("cart reminder repository: failed to create reminder: %w", err)
+	}
+	return nil
+}
+
+// MarkConverted attributes an order to the reminders the cart was sent since its
+// previous order, if any.
+func (r *cartReminderRepository) MarkConverted(ctx context.Context, cartID, orderID int64) error {
+	query := `
+        UPDATE cart_reminders SET order_id = $2, converted_at = NOW()
+        WHERE cart_id = $1 AND converted_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, cartID, orderID); err != nil {
+		return fmt.Errorf("cart reminder repository: failed to mark reminders converted: %w", err)
+	}
+	return nil
+}
+
+func (r *cartReminderRepository) DisableForUser(ctx context.Context, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET cart_reminders_enabled = false WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("cart reminder repository: failed to disable reminders: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cart reminder repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *cartReminderRepository) Stats(ctx context.Context, since time.Time) (*models.CartReminderStats, error) {
+	query := `
+        SELECT COUNT(*), COUNT(DISTINCT cart_id), COUNT(DISTINCT cart_id) FILTER (WHERE converted_at IS NOT NULL)
+        FROM cart_reminders
+        WHERE sent_at >= $1`
+	stats := models.CartReminderStats{Since: since}
+	err := r.db.QueryRowContext(ctx, query, since).Scan(&stats.RemindersSent, &stats.CartsReminded, &stats.CartsConverted)
+	if err != nil {
+		return nil, fmt.Errorf("cart reminder repository: failed to get stats: %w", err)
+	}
+	if stats.CartsReminded > 0 {
+		stats.ConversionRate = float64(stats.CartsConverted) / float64(stats.CartsReminded)
+	}
+	return &stats, nil
+}
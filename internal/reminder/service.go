@@ -0,0 +1,154 @@
+// internal/reminder/service.go
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/events"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/tasks"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/jsonutil"
+	"github.com/purushothdl/ecommerce-api/pkg/web"
+)
+
+type cartReminderService struct {
+	reminderRepo   domain.CartReminderRepository
+	store          domain.Store
+	taskCreator    *tasks.TaskCreator
+	tokens         *web.UnsubscribeTokens
+	unsubscribeURL string // Link in the email that the signed token is appended to
+	currency       string // Currency catalog prices are in, quoted in the email
+	logger         *slog.Logger
+}
+
+// NewCartReminderService creates a new CartReminderService. taskCreator and
+// unsubscribeURL are only needed by callers that send reminders.
+func NewCartReminderService(reminderRepo domain.CartReminderRepository, store domain.Store, taskCreator *tasks.TaskCreator, tokens *web.UnsubscribeTokens, unsubscribeURL, currency string, logger *slog.Logger) domain.CartReminderService {
+	return &cartReminderService{
+		reminderRepo:   reminderRepo,
+		store:          store,
+		taskCreator:    taskCreator,
+		tokens:         tokens,
+		unsubscribeURL: unsubscribeURL,
+		currency:       currency,
+		logger:         logger,
+	}
+}
+
+// SendAbandonedCartReminders emails customers whose carts have sat idle for idleFor, up
+// to maxReminders times per cart between orders. Each cart is handled in its own
+// transaction, so a failure leaves only that cart for the next run; processing stops
+// there and reports how many reminders were sent.
+func (s *cartReminderService) SendAbandonedCartReminders(ctx context.Context, idleFor time.Duration, maxReminders int) (int, error) {
+	if maxReminders <= 0 {
+		return 0, nil
+	}
+	idleSince := time.Now().Add(-idleFor)
+
+	sent := 0
+	for {
+		claimed := false
+		err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+			cart, err := q.CartReminderRepo.ClaimNextAbandoned(ctx, idleSince, maxReminders)
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			claimed = true
+
+			if err := s.sendReminder(ctx, q, cart); err != nil {
+				return fmt.Errorf("cart %d: %w", cart.CartID, err)
+			}
+			return nil
+		})
+		if err != nil {
+			s.logger.Error("failed to send abandoned cart reminder", "sent", sent, "error", err)
+			return sent, fmt.Errorf("cart reminder service: could not send reminders: %w", err)
+		}
+		if !claimed {
+			return sent, nil
+		}
+		sent++
+	}
+}
+
+// sendReminder enqueues the email for one cart and records that it was sent.
+func (s *cartReminderService) sendReminder(ctx context.Context, q *domain.Queries, cart *models.AbandonedCart) error {
+	items, err := q.CartRepo.GetItemsByCartID(ctx, cart.CartID)
+	if err != nil {
+		return err
+	}
+
+	event := events.AbandonedCartEvent{
+		UserName:       cart.Name,
+		Items:          make([]events.CartItemInfo, len(items)),
+		Currency:       s.currency,
+		UnsubscribeURL: s.unsubscribeURL + "?token=" + url.QueryEscape(s.tokens.Sign(cart.UserID)),
+	}
+	if cart.CartName != nil {
+		event.CartName = *cart.CartName
+	}
+	for i := range items {
+		item := &items[i]
+		event.Items[i] = events.CartItemInfo{
+			ProductName: item.Product.Name,
+			Thumbnail:   item.Product.Thumbnail,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice(),
+		}
+		event.Total += item.UnitPrice() * float64(item.Quantity)
+	}
+
+	notificationEvent := events.NotificationRequestEvent{
+		Type:      "ABANDONED_CART",
+		UserEmail: cart.Email,
+		Payload:   jsonutil.MustMarshal(event),
+	}
+	if err := s.taskCreator.CreateFulfillmentTask(ctx, "/handle/notification-request", notificationEvent); err != nil {
+		return err
+	}
+
+	reminder := &models.CartReminder{
+		CartID:    cart.CartID,
+		UserID:    cart.UserID,
+		ItemCount: len(items),
+		CartTotal: event.Total,
+	}
+	if err := q.CartReminderRepo.Create(ctx, reminder); err != nil {
+		return err
+	}
+	s.logger.Info("abandoned cart reminder sent", "cart_id", cart.CartID, "user_id", cart.UserID, "reminder", cart.RemindersSent+1)
+	return nil
+}
+
+// Unsubscribe turns off abandoned cart reminders for the user named by a signed token.
+func (s *cartReminderService) Unsubscribe(ctx context.Context, token string) error {
+	userID, err := s.tokens.Verify(token)
+	if err != nil {
+		return err
+	}
+	if err := s.reminderRepo.DisableForUser(ctx, userID); err != nil {
+		return fmt.Errorf("cart reminder service: could not unsubscribe user: %w", err)
+	}
+	s.logger.Info("user unsubscribed from cart reminders", "user_id", userID)
+	return nil
+}
+
+// GetStats reports how many reminders were sent since a point in time and how many of
+// the reminded carts were then ordered.
+func (s *cartReminderService) GetStats(ctx context.Context, since time.Time) (*models.CartReminderStats, error) {
+	stats, err := s.reminderRepo.Stats(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("cart reminder service: could not get stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,182 @@
+// internal/review/handler.go
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	reviewSvc domain.ReviewService
+	logger    *slog.Logger
+}
+
+func NewHandler(reviewSvc domain.ReviewService, logger *slog.Logger) *Handler {
+	return &Handler{reviewSvc: reviewSvc, logger: logger}
+}
+
+// HandleListProductReviews returns the published reviews of a product, newest first.
+func (h *Handler) HandleListProductReviews(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	v := validator.New()
+	filters := ParseReviewFilters(r.URL.Query(), v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+	// Customers only ever see published reviews.
+	filters.ProductID = productID
+	filters.Status = models.ReviewStatusPublished
+
+	reviews, total, err := h.reviewSvc.ListReviews(r.Context(), filters)
+	if err != nil {
+		h.logger.Error("failed to list product reviews", "product_id", productID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not retrieve reviews")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, NewReviewListResponse(reviews, total, filters))
+}
+
+// HandleCreateReview lets a customer review a product from one of their delivered orders.
+func (h *Handler) HandleCreateReview(w http.ResponseWriter, r *http.Request) {
+	userID, err := context.GetUserID(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreateReviewRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	review, err := h.reviewSvc.CreateReview(r.Context(), userID, productID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, apperrors.ErrReviewNotAllowed):
+			response.Error(w, http.StatusForbidden, "you can only review products from your delivered orders")
+		case errors.Is(err, apperrors.ErrDuplicateReview):
+			response.Error(w, http.StatusConflict, "you have already reviewed this product")
+		default:
+			h.logger.Error("failed to create review", "user_id", userID, "product_id", productID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not create review")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, review)
+}
+
+// HandleAdminListReviews lists reviews across all products, optionally filtered by ?status.
+func (h *Handler) HandleAdminListReviews(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	filters := ParseReviewFilters(r.URL.Query(), v)
+	if productIDStr := r.URL.Query().Get("product_id"); productIDStr != "" {
+		productID, err := strconv.ParseInt(productIDStr, 10, 64)
+		v.Check(err == nil && productID > 0, "product_id", "must be a positive integer")
+		filters.ProductID = productID
+	}
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	reviews, total, err := h.reviewSvc.ListReviews(r.Context(), filters)
+	if err != nil {
+		h.logger.Error("failed to list reviews for moderation", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not retrieve reviews")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, NewReviewListResponse(reviews, total, filters))
+}
+
+// HandleModerateReview publishes or hides a review.
+func (h *Handler) HandleModerateReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "reviewId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid review ID")
+		return
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateModerateReviewRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	review, err := h.reviewSvc.ModerateReview(r.Context(), id, &req)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "review not found")
+			return
+		}
+		h.logger.Error("failed to moderate review", "review_id", id, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not moderate review")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, review)
+}
+
+// HandleDeleteReview permanently removes a review.
+func (h *Handler) HandleDeleteReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "reviewId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid review ID")
+		return
+	}
+
+	if err := h.reviewSvc.DeleteReview(r.Context(), id); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "review not found")
+			return
+		}
+		h.logger.Error("failed to delete review", "review_id", id, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not delete review")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.MessageResponse{Message: "review deleted successfully"})
+}
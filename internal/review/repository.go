@@ -0,0 +1,165 @@
+// internal/review/repository.go
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type reviewRepository struct {
+	db domain.DBTX
+}
+
+func NewReviewRepository(db domain.DBTX) domain.ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+const reviewSelect = `
+        SELECT r.id, r.product_id, r.user_id, u.name, r.rating, r.title, r.body, r.status,
+               COALESCE(r.moderation_note, ''), r.created_at, r.updated_at
+        FROM product_reviews r
+        JOIN users u ON u.id = r.user_id`
+
+func scanReview(row interface{ Scan(dest ...any) error }, rv *models.Review) error {
+	return row.Scan(
+		&rv.ID, &rv.ProductID, &rv.UserID, &rv.UserName, &rv.Rating, &rv.Title, &rv.Body, &rv.Status,
+		&rv.ModerationNote, &rv.CreatedAt, &rv.UpdatedAt,
+	)
+}
+
+func (r *reviewRepository) Create(ctx context.Context, rv *models.Review) error {
+	query := `
+        INSERT INTO product_reviews (product_id, user_id, rating, title, body)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, status, created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query, rv.ProductID, rv.UserID, rv.Rating, rv.Title, rv.Body).
+		Scan(&rv.ID, &rv.Status, &rv.CreatedAt, &rv.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return apperrors.ErrDuplicateReview
+			case "23503":
+				return apperrors.ErrNotFound
+			}
+		}
+		return fmt.Errorf("review repository: failed to create review: %w", err)
+	}
+	return nil
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, id int64) (*models.Review, error) {
+	var rv models.Review
+	if err := scanReview(r.db.QueryRowContext(ctx, reviewSelect+` WHERE r.id = $1`, id), &rv); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("review repository: failed to get review: %w", err)
+	}
+	return &rv, nil
+}
+
+// List returns one page of reviews, newest first, plus the total number of matches.
+func (r *reviewRepository) List(ctx context.Context, filters domain.ReviewFilters) ([]*models.Review, int, error) {
+	var conditions []string
+	var args []any
+	if filters.ProductID != 0 {
+		args = append(args, filters.ProductID)
+		conditions = append(conditions, fmt.Sprintf("r.product_id = $%d", len(args)))
+	}
+	if filters.Status != "" {
+		args = append(args, filters.Status)
+		conditions = append(conditions, fmt.Sprintf("r.status = $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM product_reviews r` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("review repository: failed to count reviews: %w", err)
+	}
+
+	args = append(args, filters.PageSize, (filters.Page-1)*filters.PageSize)
+	query := reviewSelect + where + fmt.Sprintf(" ORDER BY r.created_at DESC, r.id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("review repository: failed to list reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*models.Review{}
+	for rows.Next() {
+		var rv models.Review
+		if err := scanReview(rows, &rv); err != nil {
+			return nil, 0, fmt.Errorf("review repository: failed to scan review: %w", err)
+		}
+		reviews = append(reviews, &rv)
+	}
+	return reviews, total, rows.Err()
+}
+
+// HasDeliveredPurchase reports whether the user has a delivered order that contains the product.
+func (r *reviewRepository) HasDeliveredPurchase(ctx context.Context, userID, productID int64) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM order_items oi
+            JOIN orders o ON o.id = oi.order_id
+            WHERE o.user_id = $1 AND oi.product_id = $2 AND o.status = $3
+        )`
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, query, userID, productID, models.OrderStatusDelivered).Scan(&ok); err != nil {
+		return false, fmt.Errorf("review repository: failed to check purchase: %w", err)
+	}
+	return ok, nil
+}
+
+func (r *reviewRepository) UpdateStatus(ctx context.Context, id int64, status models.ReviewStatus, note string) error {
+	query := `
+        UPDATE product_reviews
+        SET status = $1, moderation_note = NULLIF($2, ''), updated_at = NOW()
+        WHERE id = $3`
+	result, err := r.db.ExecContext(ctx, query, status, note, id)
+	if err != nil {
+		return fmt.Errorf("review repository: failed to update review status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("review repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM product_reviews WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("review repository: failed to delete review: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("review repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// internal/review/requests.go
+package review
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+// ValidateCreateReviewRequest validates a customer's review
+func ValidateCreateReviewRequest(r dto.CreateReviewRequest, v *validator.Validator) {
+	v.Check(r.Rating >= 1 && r.Rating <= 5, "rating", "must be between 1 and 5")
+	v.Check(validator.NotBlank(r.Title), "title", "must be provided")
+	v.Check(len(r.Title) <= 150, "title", "must not exceed 150 characters")
+	v.Check(validator.NotBlank(r.Body), "body", "must be provided")
+	v.Check(len(r.Body) <= 5000, "body", "must not exceed 5000 characters")
+}
+
+// ValidateModerateReviewRequest validates an admin moderation decision
+func ValidateModerateReviewRequest(r dto.ModerateReviewRequest, v *validator.Validator) {
+	v.Check(r.Status == models.ReviewStatusPublished || r.Status == models.ReviewStatusHidden, "status", "must be published or hidden")
+	v.Check(len(r.ModerationNote) <= 1000, "moderation_note", "must not exceed 1000 characters")
+}
+
+// ParseReviewFilters reads pagination (?page, ?limit) and, for admins, ?status from the query string.
+func ParseReviewFilters(query url.Values, v *validator.Validator) domain.ReviewFilters {
+	filters := domain.ReviewFilters{
+		Status:   models.ReviewStatus(query.Get("status")),
+		Page:     1,
+		PageSize: 10,
+	}
+
+	if filters.Status != "" {
+		v.Check(filters.Status == models.ReviewStatusPublished || filters.Status == models.ReviewStatusHidden, "status", "must be published or hidden")
+	}
+	if pageStr := query.Get("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filters.Page = page
+		}
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		v.Check(err == nil && limit > 0 && limit <= 100, "limit", "must be between 1 and 100")
+		filters.PageSize = limit
+	}
+
+	return filters
+}
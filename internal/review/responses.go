@@ -0,0 +1,32 @@
+// internal/review/responses.go
+package review
+
+import (
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+// ReviewListResponse is a page of reviews
+type ReviewListResponse struct {
+	Reviews    []*models.Review `json:"reviews"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	Total      int              `json:"total"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// NewReviewListResponse assembles the listing response
+func NewReviewListResponse(reviews []*models.Review, total int, filters domain.ReviewFilters) *ReviewListResponse {
+	totalPages := 0
+	if filters.PageSize > 0 {
+		totalPages = (total + filters.PageSize - 1) / filters.PageSize
+	}
+
+	return &ReviewListResponse{
+		Reviews:    reviews,
+		Page:       filters.Page,
+		Limit:      filters.PageSize,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
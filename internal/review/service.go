@@ -0,0 +1,94 @@
+// internal/review/service.go
+package review
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type reviewService struct {
+	repo        domain.ReviewRepository
+	productRepo domain.ProductRepository
+	logger      *slog.Logger
+}
+
+// NewReviewService creates a new ReviewService
+func NewReviewService(repo domain.ReviewRepository, productRepo domain.ProductRepository, logger *slog.Logger) domain.ReviewService {
+	return &reviewService{repo: repo, productRepo: productRepo, logger: logger}
+}
+
+// CreateReview records a review from a customer who has received the product.
+func (s *reviewService) CreateReview(ctx context.Context, userID, productID int64, req *dto.CreateReviewRequest) (*models.Review, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, fmt.Errorf("review service: could not retrieve product: %w", err)
+	}
+
+	eligible, err := s.repo.HasDeliveredPurchase(ctx, userID, productID)
+	if err != nil {
+		s.logger.Error("failed to check review eligibility", "user_id", userID, "product_id", productID, "error", err)
+		return nil, fmt.Errorf("review service: could not check eligibility: %w", err)
+	}
+	if !eligible {
+		s.logger.Warn("review rejected, no delivered purchase", "user_id", userID, "product_id", productID)
+		return nil, apperrors.ErrReviewNotAllowed
+	}
+
+	review := &models.Review{
+		ProductID: productID,
+		UserID:    userID,
+		Rating:    req.Rating,
+		Title:     strings.TrimSpace(req.Title),
+		Body:      strings.TrimSpace(req.Body),
+	}
+	if err := s.repo.Create(ctx, review); err != nil {
+		s.logger.Warn("failed to create review", "user_id", userID, "product_id", productID, "error", err)
+		return nil, fmt.Errorf("review service: could not create review: %w", err)
+	}
+
+	s.logger.Info("review created", "review_id", review.ID, "product_id", productID, "rating", review.Rating)
+	return s.repo.GetByID(ctx, review.ID)
+}
+
+func (s *reviewService) ListReviews(ctx context.Context, filters domain.ReviewFilters) ([]*models.Review, int, error) {
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.PageSize <= 0 {
+		filters.PageSize = 10
+	}
+
+	reviews, total, err := s.repo.List(ctx, filters)
+	if err != nil {
+		s.logger.Error("failed to list reviews", "product_id", filters.ProductID, "error", err)
+		return nil, 0, fmt.Errorf("review service: could not list reviews: %w", err)
+	}
+	return reviews, total, nil
+}
+
+// ModerateReview publishes or hides a review; the product rating follows automatically.
+func (s *reviewService) ModerateReview(ctx context.Context, id int64, req *dto.ModerateReviewRequest) (*models.Review, error) {
+	if err := s.repo.UpdateStatus(ctx, id, req.Status, strings.TrimSpace(req.ModerationNote)); err != nil {
+		s.logger.Warn("failed to moderate review", "review_id", id, "error", err)
+		return nil, fmt.Errorf("review service: could not moderate review: %w", err)
+	}
+
+	s.logger.Info("review moderated", "review_id", id, "status", req.Status)
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, id int64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Warn("failed to delete review", "review_id", id, "error", err)
+		return fmt.Errorf("review service: could not delete review: %w", err)
+	}
+
+	s.logger.Info("review deleted", "review_id", id)
+	return nil
+}
@@ -2,34 +2,61 @@
 package server
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/purushothdl/ecommerce-api/internal/address"
 	"github.com/purushothdl/ecommerce-api/internal/admin"
 	"github.com/purushothdl/ecommerce-api/internal/auth"
 	"github.com/purushothdl/ecommerce-api/internal/cart"
+	"github.com/purushothdl/ecommerce-api/internal/catalog"
+	"github.com/purushothdl/ecommerce-api/internal/category"
+	"github.com/purushothdl/ecommerce-api/internal/coupon"
+	"github.com/purushothdl/ecommerce-api/internal/inventory"
 	"github.com/purushothdl/ecommerce-api/internal/order"
+	"github.com/purushothdl/ecommerce-api/internal/pricing"
 	"github.com/purushothdl/ecommerce-api/internal/product"
+	"github.com/purushothdl/ecommerce-api/internal/recommendation"
+	"github.com/purushothdl/ecommerce-api/internal/reminder"
+	"github.com/purushothdl/ecommerce-api/internal/review"
 	"github.com/purushothdl/ecommerce-api/internal/shared/middleware"
+	"github.com/purushothdl/ecommerce-api/internal/storage"
 	"github.com/purushothdl/ecommerce-api/internal/user"
+	"github.com/purushothdl/ecommerce-api/internal/warehouse"
+	"github.com/purushothdl/ecommerce-api/internal/wishlist"
 )
 
 func (s *Server) registerRoutes() {
-	userHandler := user.NewHandler(s.userService, s.authService, s.cartService, s.store, s.config.JWT.Secret, s.isProduction, s.logger)
-	authHandler := auth.NewHandler(s.authService, s.store, s.cartService, s.config.JWT.Secret, s.isProduction, s.logger)
+	userHandler := user.NewHandler(s.userService, s.authService, s.cartService, s.store, s.config.JWT.Secret, s.cartTokens, s.wishlistTokens, s.isProduction, s.logger)
+	authHandler := auth.NewHandler(s.authService, s.store, s.cartService, s.config.JWT.Secret, s.cartTokens, s.wishlistTokens, s.isProduction, s.logger)
 	adminHandler := admin.NewHandler(s.adminService, s.logger)
-	productHandler := product.NewHandler(s.productService, s.categoryService, s.logger)
-	cartHandler := cart.NewHandler(s.cartService, s.logger)
+	productHandler := product.NewHandler(s.productService, s.categoryService, s.config.Storage.MaxUploadBytes, s.config.Cache.MaxAge, s.logger)
+	categoryHandler := category.NewHandler(s.categoryService, s.config.Cache.MaxAge, s.logger)
+	cartHandler := cart.NewHandler(s.cartService, s.couponService, s.logger)
 	addressHandler := address.NewHandler(s.addressService, s.logger)
 	orderHandler := order.NewHandler(s.orderService, s.config.Stripe, s.logger)
+	reviewHandler := review.NewHandler(s.reviewService, s.logger)
+	catalogHandler := catalog.NewHandler(s.catalogService, s.logger)
+	inventoryHandler := inventory.NewHandler(s.inventoryService, s.logger)
+	warehouseHandler := warehouse.NewHandler(s.warehouseService, s.logger)
+	pricingHandler := pricing.NewHandler(s.pricingService, s.logger)
+	recommendationHandler := recommendation.NewHandler(s.recommendationService, s.logger)
+	wishlistHandler := wishlist.NewHandler(s.wishlistService, s.logger)
+	couponHandler := coupon.NewHandler(s.couponService, s.logger)
+	reminderHandler := reminder.NewHandler(s.cartReminderService, s.logger)
 
 	// API versioning
 	s.router.Route("/api/v1", func(r chi.Router) {
-		s.registerV1Routes(r, userHandler, authHandler, adminHandler, productHandler, cartHandler, addressHandler, orderHandler)
+		s.registerV1Routes(r, userHandler, authHandler, adminHandler, productHandler, categoryHandler, cartHandler, addressHandler, orderHandler, reviewHandler, catalogHandler, inventoryHandler, warehouseHandler, pricingHandler, recommendationHandler, wishlistHandler, couponHandler, reminderHandler)
 	})	
-	
+
+	// Uploaded files from the local blob store
+	if s.config.Storage.Backend == "local" {
+		s.router.Handle("/uploads/*", http.StripPrefix("/uploads/", storage.FileServer(s.config.Storage.LocalDir)))
+	}
 }
 
-func (s *Server) registerV1Routes(r chi.Router, userHandler *user.Handler, authHandler *auth.Handler, adminHandler *admin.Handler, productHandler *product.Handler, cartHandler *cart.Handler, addressHandler *address.Handler, orderHandler *order.Handler) {
+func (s *Server) registerV1Routes(r chi.Router, userHandler *user.Handler, authHandler *auth.Handler, adminHandler *admin.Handler, productHandler *product.Handler, categoryHandler *category.Handler, cartHandler *cart.Handler, addressHandler *address.Handler, orderHandler *order.Handler, reviewHandler *review.Handler, catalogHandler *catalog.Handler, inventoryHandler *inventory.Handler, warehouseHandler *warehouse.Handler, pricingHandler *pricing.Handler, recommendationHandler *recommendation.Handler, wishlistHandler *wishlist.Handler, couponHandler *coupon.Handler, reminderHandler *reminder.Handler) {
 	// Auth routes
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.TimeoutMiddleware(s.config.Timeouts.Auth))
@@ -45,12 +72,19 @@ func (s *Server) registerV1Routes(r chi.Router, userHandler *user.Handler, authH
 	})
 
 	// Public webhook route - it must NOT have auth middleware
+	// The order service flushes the catalog cache itself once a verified payment moves stock.
 	r.Post("/webhooks/stripe", orderHandler.HandleStripeWebhook)
 	r.Get("/", authHandler.HandleWelcome)
 
+	// Unsubscribe links in emails carry a signed token instead of a session. Opening the
+	// link only shows a confirmation page; the opt-out itself is a POST.
+	r.Get("/notifications/unsubscribe", reminderHandler.HandleUnsubscribePage)
+	r.Post("/notifications/unsubscribe", reminderHandler.HandleUnsubscribe)
+
 	// Internal-only routes
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.OIDCAuthMiddleware(s.config.ApiURL))
+		r.Use(middleware.InvalidateCacheMiddleware(s.catalogCache))
 		r.Post("/internal/orders/{orderId}/status", orderHandler.HandleUpdateOrderStatus)
 	})
 
@@ -70,6 +104,12 @@ func (s *Server) registerV1Routes(r chi.Router, userHandler *user.Handler, authH
 		r.Delete("/auth/sessions", authHandler.HandleLogoutAllDevices)
 		r.Delete("/auth/sessions/{sessionId}", authHandler.HandleLogoutSpecificDevice)
 
+		// Named carts. Cart and checkout routes act on one of them when given ?cart_id=.
+		r.Get("/carts", cartHandler.HandleListCarts)
+		r.Post("/carts", cartHandler.HandleCreateCart)
+		r.Patch("/carts/{cartId}", cartHandler.HandleRenameCart)
+		r.Delete("/carts/{cartId}", cartHandler.HandleDeleteCart)
+
 		// Address management routes
 		r.Post("/addresses", addressHandler.HandleCreate)
 		r.Get("/addresses", addressHandler.HandleList)
@@ -79,11 +119,21 @@ func (s *Server) registerV1Routes(r chi.Router, userHandler *user.Handler, authH
 		r.Put("/addresses/{id}/set-default", addressHandler.HandleSetDefault)
 
 		
-		// Order management routes
-		r.With(middleware.CartMiddleware(s.cartService, s.isProduction)).Post("/orders", orderHandler.HandleCreateOrder)
+		// Order management routes. Placing and cancelling orders moves stock, which the catalog shows.
+		r.With(middleware.CartMiddleware(s.cartService, s.cartTokens, s.isProduction), middleware.InvalidateCacheMiddleware(s.catalogCache)).
+			Post("/orders", orderHandler.HandleCreateOrder)
+		r.With(middleware.CartMiddleware(s.cartService, s.cartTokens, s.isProduction)).
+			Post("/checkout/quote", orderHandler.HandleCheckoutQuote)
 		r.Get("/orders", orderHandler.HandleListUserOrders)                     
 		r.Get("/orders/{orderId}", orderHandler.HandleGetUserOrder)             
-		r.Post("/orders/{orderId}/cancel", orderHandler.HandleCancelOrder) 
+		r.With(middleware.InvalidateCacheMiddleware(s.catalogCache)).Post("/orders/{orderId}/cancel", orderHandler.HandleCancelOrder)
+
+		// Product review routes
+		r.With(middleware.InvalidateCacheMiddleware(s.catalogCache)).Post("/products/{productId}/reviews", reviewHandler.HandleCreateReview)
+
+		// Back-in-stock subscription routes
+		r.Put("/products/{productId}/stock-subscription", inventoryHandler.HandleSubscribe)
+		r.Delete("/products/{productId}/stock-subscription", inventoryHandler.HandleUnsubscribe)
 	})
 
 	// Admin routes
@@ -91,32 +141,120 @@ func (s *Server) registerV1Routes(r chi.Router, userHandler *user.Handler, authH
 		r.Use(middleware.AuthMiddleware(s.config.JWT.Secret))
 		r.Use(middleware.AdminMiddleware)
 		r.Use(middleware.TimeoutMiddleware(s.config.Timeouts.Protected))
+		r.Use(middleware.InvalidateCacheMiddleware(s.catalogCache))
 
 		// User management routes
 		r.Get("/admin/users", adminHandler.HandleListUsers)
 		r.Post("/admin/users", adminHandler.HandleCreateUser)
 		r.Put("/admin/users/{userId}", adminHandler.HandleUpdateUser)
 		r.Delete("/admin/users/{userId}", adminHandler.HandleDeleteUser)
+
+		// Product management routes
+		r.Post("/admin/products", productHandler.HandleCreateProduct)
+		r.Patch("/admin/products/{productId}", productHandler.HandleUpdateProduct)
+		r.Put("/admin/products/{productId}/status", productHandler.HandleSetProductStatus)
+		r.Post("/admin/products/{productId}/archive", productHandler.HandleArchiveProduct)
+		r.Delete("/admin/products/{productId}", productHandler.HandleDeleteProduct)
+		r.Post("/admin/products/{productId}/images", productHandler.HandleUploadProductImage)
+
+		// Product variant routes
+		r.Put("/admin/products/{productId}/options", productHandler.HandleSetProductOptions)
+		r.Put("/admin/products/{productId}/attributes", productHandler.HandleSetProductAttributes)
+		r.Post("/admin/products/{productId}/variants", productHandler.HandleCreateVariant)
+		r.Patch("/admin/products/{productId}/variants/{variantId}", productHandler.HandleUpdateVariant)
+		r.Delete("/admin/products/{productId}/variants/{variantId}", productHandler.HandleDeleteVariant)
+
+		// Stock ledger routes
+		r.Get("/admin/products/{productId}/inventory/movements", inventoryHandler.HandleListMovements)
+		r.Post("/admin/products/{productId}/inventory/adjustments", inventoryHandler.HandleAdjustStock)
+		r.Get("/admin/products/{productId}/inventory/locations", warehouseHandler.HandleListProductStock)
+
+		// Warehouse routes
+		r.Get("/admin/warehouses", warehouseHandler.HandleListWarehouses)
+		r.Post("/admin/warehouses", warehouseHandler.HandleCreateWarehouse)
+		r.Patch("/admin/warehouses/{warehouseId}", warehouseHandler.HandleUpdateWarehouse)
+
+		// Pricing routes
+		r.Get("/admin/products/{productId}/price-schedules", pricingHandler.HandleListSchedules)
+		r.Post("/admin/products/{productId}/price-schedules", pricingHandler.HandleCreateSchedule)
+		r.Delete("/admin/products/{productId}/price-schedules/{scheduleId}", pricingHandler.HandleCancelSchedule)
+		r.Get("/admin/products/{productId}/price-history", pricingHandler.HandleListPriceHistory)
+
+		// Coupon routes
+		r.Get("/admin/coupons", couponHandler.HandleListCoupons)
+		r.Post("/admin/coupons", couponHandler.HandleCreateCoupon)
+		r.Get("/admin/coupons/{couponId}", couponHandler.HandleGetCoupon)
+		r.Patch("/admin/coupons/{couponId}", couponHandler.HandleUpdateCoupon)
+		r.Delete("/admin/coupons/{couponId}", couponHandler.HandleDeleteCoupon)
+
+		// Abandoned cart reminder reporting
+		r.Get("/admin/cart-reminders/stats", reminderHandler.HandleGetStats)
+
+		// Category management routes
+		r.Post("/admin/categories", categoryHandler.HandleCreateCategory)
+		r.Patch("/admin/categories/{categoryId}", categoryHandler.HandleUpdateCategory)
+		r.Post("/admin/categories/{categoryId}/move", categoryHandler.HandleMoveCategory)
+		r.Delete("/admin/categories/{categoryId}", categoryHandler.HandleDeleteCategory)
+		r.Post("/admin/categories/{categoryId}/attributes", categoryHandler.HandleCreateAttribute)
+		r.Patch("/admin/categories/{categoryId}/attributes/{attributeId}", categoryHandler.HandleUpdateAttribute)
+		r.Delete("/admin/categories/{categoryId}/attributes/{attributeId}", categoryHandler.HandleDeleteAttribute)
+
+		// Review moderation routes
+		r.Get("/admin/reviews", reviewHandler.HandleAdminListReviews)
+		r.Patch("/admin/reviews/{reviewId}", reviewHandler.HandleModerateReview)
+		r.Delete("/admin/reviews/{reviewId}", reviewHandler.HandleDeleteReview)
+	})
+
+	// Bulk catalog routes get a longer timeout than regular admin requests
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AuthMiddleware(s.config.JWT.Secret))
+		r.Use(middleware.AdminMiddleware)
+		r.Use(middleware.TimeoutMiddleware(s.config.Timeouts.Import))
+		r.Use(middleware.InvalidateCacheMiddleware(s.catalogCache))
+
+		r.Post("/admin/catalog/import", catalogHandler.HandleImport)
+		r.Get("/admin/catalog/export", catalogHandler.HandleExport)
 	})
 
 	// Public product and category routes (no authentication required)
 	r.Group(func(r chi.Router) {
         r.Get("/products", productHandler.HandleListProducts)
         r.Get("/products/{productId}", productHandler.HandleGetProduct)
+        r.Get("/products/{productId}/reviews", reviewHandler.HandleListProductReviews)
+        r.Get("/products/{productId}/recommendations", recommendationHandler.HandleGetRecommendations)
         r.Get("/categories", productHandler.HandleListCategories)
+        r.Get("/categories/tree", categoryHandler.HandleGetCategoryTree)
+        r.Get("/categories/{categoryId}/attributes", categoryHandler.HandleListAttributes)
     })
 
 	// Cart routes with cart middleware for session/user cart management
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.OptionalAuthMiddleware(s.config.JWT.Secret)) 
-        r.Use(middleware.CartMiddleware(s.cartService, s.isProduction))
+        r.Use(middleware.CartMiddleware(s.cartService, s.cartTokens, s.isProduction))
         
         r.Get("/cart", cartHandler.HandleGetCart)
         r.Post("/cart/items", cartHandler.HandleAddItem)
+        r.Post("/cart/fix", cartHandler.HandleFixCart)
+        r.Post("/cart/coupons", cartHandler.HandleApplyCoupon)
+        r.Delete("/cart/coupons/{code}", cartHandler.HandleRemoveCoupon)
         
         // These routes operate on a specific product within the cart
         r.Patch("/cart/items/{productId}", cartHandler.HandleUpdateItem)
         r.Delete("/cart/items/{productId}", cartHandler.HandleRemoveItem)
+        r.Post("/cart/items/{productId}/save-for-later", cartHandler.HandleSaveForLater)
+        r.Post("/cart/saved-items/{productId}/move-to-cart", cartHandler.HandleMoveSavedToCart)
     })
 
+	// Wishlist routes, for authenticated or anonymous users like the cart
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.OptionalAuthMiddleware(s.config.JWT.Secret))
+		r.Use(middleware.WishlistMiddleware(s.wishlistService, s.wishlistTokens, s.isProduction))
+
+		r.Get("/wishlist", wishlistHandler.HandleGetWishlist)
+		r.Post("/wishlist/items", wishlistHandler.HandleAddItem)
+		r.Delete("/wishlist/items/{itemId}", wishlistHandler.HandleRemoveItem)
+		r.With(middleware.CartMiddleware(s.cartService, s.cartTokens, s.isProduction)).
+			Post("/wishlist/items/{itemId}/move-to-cart", wishlistHandler.HandleMoveToCart)
+	})
+
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/purushothdl/ecommerce-api/configs"
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/shared/middleware"
+	"github.com/purushothdl/ecommerce-api/pkg/cache"
+	"github.com/purushothdl/ecommerce-api/pkg/web"
 )
 
 type Server struct {
@@ -25,6 +27,18 @@ type Server struct {
 	addressService  domain.AddressService
 	orderService    domain.OrderService
 	paymentService  domain.PaymentService
+	reviewService   domain.ReviewService
+	catalogService  domain.CatalogService
+	inventoryService domain.InventoryService
+	warehouseService domain.WarehouseService
+	pricingService  domain.PricingService
+	recommendationService domain.RecommendationService
+	wishlistService domain.WishlistService
+	couponService   domain.CouponService
+	cartReminderService domain.CartReminderService
+	catalogCache    *cache.Cache
+	cartTokens      *web.AnonymousTokens
+	wishlistTokens  *web.AnonymousTokens
 	isProduction    bool 
 }
 
@@ -42,7 +56,16 @@ func New(
 	addressService  domain.AddressService,
 	orderService    domain.OrderService,
 	paymentService  domain.PaymentService,
-
+	reviewService   domain.ReviewService,
+	catalogService  domain.CatalogService,
+	inventoryService domain.InventoryService,
+	warehouseService domain.WarehouseService,
+	pricingService  domain.PricingService,
+	recommendationService domain.RecommendationService,
+	wishlistService domain.WishlistService,
+	couponService   domain.CouponService,
+	cartReminderService domain.CartReminderService,
+	catalogCache    *cache.Cache,
 ) *Server {
 	s := &Server{
 		config:          config,
@@ -58,6 +81,18 @@ func New(
 		addressService:  addressService,
 		orderService:    orderService,
 		paymentService:  paymentService,
+		reviewService:   reviewService,
+		catalogService:  catalogService,
+		inventoryService: inventoryService,
+		warehouseService: warehouseService,
+		pricingService:  pricingService,
+		recommendationService: recommendationService,
+		wishlistService: wishlistService,
+		couponService:   couponService,
+		cartReminderService: cartReminderService,
+		catalogCache:    catalogCache,
+		cartTokens:      web.NewCartTokens(config.Cart.TokenSecret, config.Cart.LegacyCookiesUntil),
+		wishlistTokens:  web.NewWishlistTokens(config.Cart.TokenSecret),
 		isProduction:    config.Env == "production", 
 	}
 
@@ -11,8 +11,9 @@ type contextKey string
 
 // Context keys for storing and retrieving values from context
 const (
-	UserContextKey contextKey = "user"
-	CartContextKey contextKey = "cart"
+	UserContextKey     contextKey = "user"
+	CartContextKey     contextKey = "cart"
+	WishlistContextKey contextKey = "wishlist"
 )
 
 // UserContext represents the authenticated user in request context
@@ -64,3 +65,22 @@ func GetCart(ctx context.Context) (CartContext, error) {
 	}
 	return cart, nil
 }
+
+// WishlistContext represents the wishlist in request context
+type WishlistContext struct {
+	ID int64 `json:"id"`
+}
+
+// SetWishlist adds a wishlist to the context
+func SetWishlist(ctx context.Context, wishlist WishlistContext) context.Context {
+	return context.WithValue(ctx, WishlistContextKey, wishlist)
+}
+
+// GetWishlist retrieves the wishlist from the context
+func GetWishlist(ctx context.Context) (WishlistContext, error) {
+	wishlist, ok := ctx.Value(WishlistContextKey).(WishlistContext)
+	if !ok {
+		return WishlistContext{}, errors.New("wishlist not found in context")
+	}
+	return wishlist, nil
+}
@@ -0,0 +1,59 @@
+package dto
+
+import "github.com/purushothdl/ecommerce-api/internal/models"
+
+// CatalogRow is one product in a catalog import or export file. Products are matched
+// by SKU, and the category is referenced by name so files are portable between databases.
+type CatalogRow struct {
+	SKU                 string               `json:"sku" example:"TSHIRT-CLASSIC-001"`
+	Name                string               `json:"name" example:"Classic Cotton T-Shirt"`
+	Description         string               `json:"description" example:"A soft, breathable everyday tee."`
+	Price               float64              `json:"price" example:"499.00"`
+	StockQuantity       int                  `json:"stock_quantity" example:"100"`
+	Category            string               `json:"category" example:"Tops"`
+	Brand               string               `json:"brand,omitempty" example:"GoKart Basics"`
+	Images              []string             `json:"images,omitempty"`
+	Thumbnail           string               `json:"thumbnail,omitempty"`
+	Dimensions          *models.Dimensions   `json:"dimensions,omitempty"`
+	WarrantyInformation string               `json:"warranty_information,omitempty"`
+	Status              models.ProductStatus `json:"status,omitempty" example:"active"` // Left unchanged on import when empty
+	Archived            bool                 `json:"archived,omitempty"`                // Read from older exports only; true archives the product
+}
+
+// CatalogImportRow is a parsed row of an import file. Line is the CSV line or the
+// 1-based JSON array index; Errors holds problems found while parsing the row.
+type CatalogImportRow struct {
+	Line   int
+	Row    CatalogRow
+	Errors map[string]string
+}
+
+// CatalogImportAction describes what an import did, or would do, with a row
+type CatalogImportAction string
+
+const (
+	CatalogActionCreate CatalogImportAction = "create"
+	CatalogActionUpdate CatalogImportAction = "update"
+	CatalogActionError  CatalogImportAction = "error"
+)
+
+// CatalogRowResult reports the outcome for a single import row
+type CatalogRowResult struct {
+	Line     int                 `json:"line"`
+	SKU      string              `json:"sku"`
+	Action   CatalogImportAction `json:"action"`
+	Errors   map[string]string   `json:"errors,omitempty"`
+	Warnings []string            `json:"warnings,omitempty"`
+}
+
+// CatalogImportReport summarises an import. Applied is false for dry runs and for
+// imports refused because at least one row was invalid.
+type CatalogImportReport struct {
+	DryRun  bool               `json:"dry_run"`
+	Applied bool               `json:"applied"`
+	Total   int                `json:"total"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Failed  int                `json:"failed"`
+	Rows    []CatalogRowResult `json:"rows"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "github.com/purushothdl/ecommerce-api/internal/models"
+
+// CreateCategoryRequest is the input for an admin creating a category.
+// Slug is derived from the name when omitted; ParentID nil creates a top-level category.
+type CreateCategoryRequest struct {
+	Name     string `json:"name" example:"Running Shoes"`
+	Slug     string `json:"slug,omitempty" example:"running-shoes"`
+	ParentID *int64 `json:"parent_id,omitempty" example:"3"`
+	Position int    `json:"position" example:"0"`
+}
+
+// UpdateCategoryRequest is the input for renaming or reordering a category
+type UpdateCategoryRequest struct {
+	Name     *string `json:"name,omitempty"`
+	Slug     *string `json:"slug,omitempty"`
+	Position *int    `json:"position,omitempty"`
+}
+
+// MoveCategoryRequest re-parents a category. A nil ParentID moves it to the top level.
+type MoveCategoryRequest struct {
+	ParentID *int64 `json:"parent_id"`
+	Position *int   `json:"position,omitempty"`
+}
+
+// CreateCategoryAttributeRequest is the input for defining a typed attribute on a category.
+// AllowedValues restricts the values products may use; empty accepts any value of the type.
+type CreateCategoryAttributeRequest struct {
+	Code          string               `json:"code" example:"ram"`
+	Name          string               `json:"name" example:"RAM"`
+	Type          models.AttributeType `json:"type" example:"text"`
+	Unit          string               `json:"unit,omitempty" example:"GB"`
+	AllowedValues []string             `json:"allowed_values,omitempty" example:"8GB,16GB,32GB"`
+	Position      int                  `json:"position" example:"0"`
+}
+
+// UpdateCategoryAttributeRequest is the input for editing an attribute. The code and
+// type cannot change. AllowedValues replaces the whole list when present, and an empty
+// list accepts any value again.
+type UpdateCategoryAttributeRequest struct {
+	Name          *string  `json:"name,omitempty"`
+	Unit          *string  `json:"unit,omitempty"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	Position      *int     `json:"position,omitempty"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// CreateCouponRequest is the input for creating a coupon. Fixed values and amounts are
+// in the base currency.
+type CreateCouponRequest struct {
+	Code         string     `json:"code" example:"DIWALI10"`
+	Description  string     `json:"description,omitempty" example:"10% off electronics"`
+	DiscountType string     `json:"discount_type" example:"percentage"` // percentage or fixed
+	Value        float64    `json:"value" example:"10"`
+	MaxDiscount  *float64   `json:"max_discount,omitempty" example:"500"` // Only for percentage coupons
+	MinCartValue float64    `json:"min_cart_value,omitempty" example:"1000"`
+	ProductIDs   []int64    `json:"product_ids,omitempty"`
+	CategoryIDs  []int64    `json:"category_ids,omitempty"`
+	UsageLimit   *int       `json:"usage_limit,omitempty" example:"1000"`
+	PerUserLimit *int       `json:"per_user_limit,omitempty" example:"1"`
+	Stackable    bool       `json:"stackable,omitempty"`
+	StartsAt     *time.Time `json:"starts_at,omitempty" example:"2025-11-01T00:00:00Z"`
+	EndsAt       *time.Time `json:"ends_at,omitempty" example:"2025-11-15T00:00:00Z"`
+	Active       *bool      `json:"active,omitempty"` // Defaults to true
+}
+
+// UpdateCouponRequest is a partial update of a coupon. The code and discount type are
+// fixed once created.
+type UpdateCouponRequest struct {
+	Description  *string    `json:"description,omitempty"`
+	Value        *float64   `json:"value,omitempty"`
+	MaxDiscount  *float64   `json:"max_discount,omitempty"`
+	MinCartValue *float64   `json:"min_cart_value,omitempty"`
+	ProductIDs   *[]int64   `json:"product_ids,omitempty"`
+	CategoryIDs  *[]int64   `json:"category_ids,omitempty"`
+	UsageLimit   *int       `json:"usage_limit,omitempty"`
+	PerUserLimit *int       `json:"per_user_limit,omitempty"`
+	Stackable    *bool      `json:"stackable,omitempty"`
+	StartsAt     *time.Time `json:"starts_at,omitempty"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	Active       *bool      `json:"active,omitempty"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/purushothdl/ecommerce-api/internal/models"
+
+// StockAdjustmentRequest is the input for an admin correcting a product's or variant's
+// stock. Without a warehouse the default warehouse's stock is adjusted.
+type StockAdjustmentRequest struct {
+	VariantID   *int64                `json:"variant_id,omitempty" example:"12"`
+	WarehouseID *int64                `json:"warehouse_id,omitempty" example:"2"`
+	Delta       int                   `json:"delta" example:"-2"`
+	Reason      models.MovementReason `json:"reason" example:"manual_adjustment"`
+	Note        string                `json:"note" example:"Two units damaged in storage"`
+}
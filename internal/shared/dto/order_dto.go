@@ -12,14 +12,51 @@ type CreateOrderRequest struct {
     ShippingAddressID  int64  `json:"shipping_address_id"`
     BillingAddressID   int64  `json:"billing_address_id"`
     PaymentMethod      string `json:"payment_method" example:"stripe"`
+    Currency           string `json:"currency,omitempty" example:"USD"` // Defaults to the store's base currency
 }
 
 // CreateOrderResponse is the specific data returned after successfully creating an order.
 type CreateOrderResponse struct {
-	OrderID      int64   `json:"order_id"`
-	OrderNumber  string  `json:"order_number"`
-	ClientSecret string  `json:"client_secret"`
-	TotalAmount  float64 `json:"total_amount"`
+	OrderID       int64     `json:"order_id"`
+	OrderNumber   string    `json:"order_number"`
+	ClientSecret  string    `json:"client_secret"`
+	TotalAmount   float64   `json:"total_amount"`
+	Currency      string    `json:"currency"`
+	ReservedUntil time.Time `json:"reserved_until"` // Stock is held for the order until then
+}
+
+// CheckoutQuoteRequest represents the input for pricing the cart before ordering
+type CheckoutQuoteRequest struct {
+	ShippingAddressID int64  `json:"shipping_address_id"`
+	BillingAddressID  int64  `json:"billing_address_id"`
+	Currency          string `json:"currency,omitempty" example:"USD"` // Defaults to the store's base currency
+}
+
+// CheckoutQuoteResponse is the price breakdown placing an order would charge right now
+type CheckoutQuoteResponse struct {
+	Items          []CheckoutQuoteLine    `json:"items"`
+	Coupons        []models.AppliedCoupon `json:"coupons"`
+	Subtotal       float64                `json:"subtotal"`
+	TaxAmount      float64                `json:"tax_amount"`
+	ShippingCost   float64                `json:"shipping_cost"`
+	DiscountAmount float64                `json:"discount_amount"`
+	TotalAmount    float64                `json:"total_amount"`
+	Currency       string                 `json:"currency"`
+	PricedAt       time.Time              `json:"priced_at"`
+}
+
+// CheckoutQuoteLine is one priced line of a checkout quote
+type CheckoutQuoteLine struct {
+	ProductID      int64            `json:"product_id"`
+	ProductName    string           `json:"product_name"`
+	ProductSKU     string           `json:"product_sku"`
+	VariantID      *int64           `json:"variant_id,omitempty"`
+	VariantOptions json.RawMessage  `json:"variant_options,omitempty"`
+	UnitPrice      float64          `json:"unit_price"`
+	CompareAtPrice *float64         `json:"compare_at_price,omitempty"`
+	PriceRule      models.PriceRule `json:"price_rule"`
+	Quantity       int              `json:"quantity"`
+	TotalPrice     float64          `json:"total_price"`
 }
 
 // ConfirmPaymentRequest represents the input for confirming payment
@@ -47,6 +84,7 @@ type OrderResponse struct {
 	Status        models.OrderStatus   `json:"status"`
 	PaymentStatus models.PaymentStatus `json:"payment_status"`
 	TotalAmount   float64              `json:"total_amount"`
+	Currency      string               `json:"currency"`
 	CreatedAt     time.Time            `json:"created_at"`
 }
 
@@ -65,6 +103,7 @@ type OrderWithItemsResponse struct {
 	ShippingCost          float64              `json:"shipping_cost"`
 	DiscountAmount        float64              `json:"discount_amount"`
 	TotalAmount           float64              `json:"total_amount"`
+	Currency              string               `json:"currency"`
 	TrackingNumber        string               `json:"tracking_number,omitempty"`
 	EstimatedDeliveryDate time.Time            `json:"estimated_delivery_date,omitempty"`
 	CreatedAt             time.Time            `json:"created_at"`
@@ -74,13 +113,18 @@ type OrderWithItemsResponse struct {
 
 // OrderItemResponse represents a single order item output
 type OrderItemResponse struct {
-	ID           int64   `json:"id"`
-	ProductID    int64   `json:"product_id"`
-	ProductName  string  `json:"product_name"`
-	ProductImage string  `json:"product_image,omitempty"`
-	UnitPrice    float64 `json:"unit_price"`
-	Quantity     int     `json:"quantity"`
-	TotalPrice   float64 `json:"total_price"`
+	ID             int64           `json:"id"`
+	ProductID      int64           `json:"product_id"`
+	ProductName    string          `json:"product_name"`
+	ProductImage   string          `json:"product_image,omitempty"`
+	ProductSKU     string          `json:"product_sku"`
+	VariantID      *int64          `json:"variant_id,omitempty"`
+	VariantOptions json.RawMessage `json:"variant_options,omitempty"`
+	UnitPrice      float64         `json:"unit_price"`
+	CompareAtPrice *float64        `json:"compare_at_price,omitempty"`
+	PriceRule      models.PriceRule `json:"price_rule"`
+	Quantity       int             `json:"quantity"`
+	TotalPrice     float64         `json:"total_price"`
 }
 
 // MapModelsToOrderWithItemsResponse is a helper to convert DB models to a DTO
@@ -88,13 +132,18 @@ func MapModelsToOrderWithItemsResponse(order *models.Order, items []*models.Orde
 	orderItems := make([]*OrderItemResponse, len(items))
 	for i, item := range items {
 		orderItems[i] = &OrderItemResponse{
-			ID:           item.ID,
-			ProductID:    item.ProductID,
-			ProductName:  item.ProductName,
-			ProductImage: item.ProductImage,
-			UnitPrice:    item.UnitPrice,
-			Quantity:     item.Quantity,
-			TotalPrice:   item.TotalPrice,
+			ID:             item.ID,
+			ProductID:      item.ProductID,
+			ProductName:    item.ProductName,
+			ProductImage:   item.ProductImage,
+			ProductSKU:     item.ProductSKU,
+			VariantID:      item.VariantID,
+			VariantOptions: item.VariantOptions,
+			UnitPrice:      item.UnitPrice,
+			CompareAtPrice: item.CompareAtPrice,
+			PriceRule:      item.PriceRule,
+			Quantity:       item.Quantity,
+			TotalPrice:     item.TotalPrice,
 		}
 	}
 
@@ -112,6 +161,7 @@ func MapModelsToOrderWithItemsResponse(order *models.Order, items []*models.Orde
 		ShippingCost:          order.ShippingCost,
 		DiscountAmount:        order.DiscountAmount,
 		TotalAmount:           order.TotalAmount,
+		Currency:              order.Currency,
 		TrackingNumber:        order.TrackingNumber,
 		EstimatedDeliveryDate: order.EstimatedDeliveryDate,
 		CreatedAt:             order.CreatedAt,
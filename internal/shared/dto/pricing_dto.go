@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// CreatePriceScheduleRequest is the input for scheduling a sale price
+type CreatePriceScheduleRequest struct {
+	VariantID *int64    `json:"variant_id,omitempty"`
+	SalePrice float64   `json:"sale_price" example:"799.00"`
+	StartsAt  time.Time `json:"starts_at" example:"2025-11-28T00:00:00Z"`
+	EndsAt    time.Time `json:"ends_at" example:"2025-12-01T00:00:00Z"`
+	Label     string    `json:"label,omitempty" example:"Black Friday"`
+}
@@ -0,0 +1,82 @@
+package dto
+
+import "github.com/purushothdl/ecommerce-api/internal/models"
+
+// CreateProductRequest is the input for an admin creating a new product
+type CreateProductRequest struct {
+	Name                string             `json:"name" example:"Classic Cotton T-Shirt"`
+	Description         string             `json:"description" example:"A soft, breathable everyday tee."`
+	Price               float64            `json:"price" example:"499.00"`
+	StockQuantity       int                `json:"stock_quantity" example:"100"`
+	CategoryID          int64              `json:"category_id" example:"1"`
+	Brand               string             `json:"brand" example:"GoKart Basics"`
+	SKU                 string             `json:"sku" example:"TSHIRT-CLASSIC-001"`
+	Images              []string           `json:"images"`
+	Thumbnail           string             `json:"thumbnail"`
+	Dimensions          *models.Dimensions `json:"dimensions,omitempty"`
+	WarrantyInformation string             `json:"warranty_information"`
+	LowStockThreshold   *int               `json:"low_stock_threshold,omitempty" example:"10"`
+	Status              models.ProductStatus `json:"status,omitempty" example:"draft"` // draft or active; defaults to active
+}
+
+// UpdateProductRequest is the input for an admin partially updating a product.
+// Version is the version the client last read; a mismatch results in an edit conflict.
+type UpdateProductRequest struct {
+	Name                *string            `json:"name,omitempty"`
+	Description         *string            `json:"description,omitempty"`
+	Price               *float64           `json:"price,omitempty"`
+	StockQuantity       *int               `json:"stock_quantity,omitempty"`
+	CategoryID          *int64             `json:"category_id,omitempty"`
+	Brand               *string            `json:"brand,omitempty"`
+	SKU                 *string            `json:"sku,omitempty"`
+	Images              []string           `json:"images,omitempty"`
+	Thumbnail           *string            `json:"thumbnail,omitempty"`
+	Dimensions          *models.Dimensions `json:"dimensions,omitempty"`
+	WarrantyInformation *string            `json:"warranty_information,omitempty"`
+	LowStockThreshold   *int               `json:"low_stock_threshold,omitempty" example:"10"`
+	Version             *int               `json:"version,omitempty" example:"3"`
+}
+
+// SetProductStatusRequest moves a product to another lifecycle status.
+type SetProductStatusRequest struct {
+	Status models.ProductStatus `json:"status" example:"active"`
+}
+
+// SetProductAttributesRequest replaces a product's attribute values. Keys are attribute
+// codes of the product's category, e.g. {"ram": "16GB", "screen_size": "15.6"}.
+type SetProductAttributesRequest struct {
+	Attributes map[string]string `json:"attributes"`
+}
+
+// ProductOptionInput describes one option type a product varies by
+type ProductOptionInput struct {
+	Name   string   `json:"name" example:"Size"`
+	Values []string `json:"values" example:"S,M,L"`
+}
+
+// SetProductOptionsRequest replaces the full set of option types on a product.
+// Options are positioned in the order given.
+type SetProductOptionsRequest struct {
+	Options []ProductOptionInput `json:"options"`
+}
+
+// CreateVariantRequest is the input for adding a variant to a product.
+// Options maps every option type name to one of its values, e.g. {"Size": "M"}.
+type CreateVariantRequest struct {
+	SKU           string            `json:"sku" example:"TSHIRT-CLASSIC-001-M-RED"`
+	Price         float64           `json:"price" example:"549.00"`
+	StockQuantity int               `json:"stock_quantity" example:"25"`
+	Images        []string          `json:"images"`
+	Options       map[string]string `json:"options"`
+}
+
+// UpdateVariantRequest is the input for partially updating a variant.
+// Version is the version the client last read; a mismatch results in an edit conflict.
+type UpdateVariantRequest struct {
+	SKU           *string           `json:"sku,omitempty"`
+	Price         *float64          `json:"price,omitempty"`
+	StockQuantity *int              `json:"stock_quantity,omitempty"`
+	Images        []string          `json:"images,omitempty"`
+	Options       map[string]string `json:"options,omitempty"`
+	Version       *int              `json:"version,omitempty" example:"2"`
+}
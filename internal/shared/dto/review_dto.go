@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/purushothdl/ecommerce-api/internal/models"
+
+// CreateReviewRequest is the input for a customer reviewing a product
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" example:"5"`
+	Title  string `json:"title" example:"Fits perfectly"`
+	Body   string `json:"body" example:"Great fabric, true to size and survived many washes."`
+}
+
+// ModerateReviewRequest is the input for an admin publishing or hiding a review
+type ModerateReviewRequest struct {
+	Status         models.ReviewStatus `json:"status" example:"hidden"`
+	ModerationNote string              `json:"moderation_note,omitempty" example:"Contains personal information"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+// CreateWarehouseRequest is the input for adding a stock location
+type CreateWarehouseRequest struct {
+	Code       string `json:"code" example:"BLR-1"`
+	Name       string `json:"name" example:"Bengaluru fulfilment centre"`
+	Street1    string `json:"street1,omitempty" example:"12 Industrial Area"`
+	City       string `json:"city,omitempty" example:"Bengaluru"`
+	State      string `json:"state,omitempty" example:"Karnataka"`
+	PostalCode string `json:"postal_code,omitempty" example:"560100"`
+	Country    string `json:"country,omitempty" example:"IN"`
+	Priority   *int   `json:"priority,omitempty" example:"10"`
+	IsDefault  bool   `json:"is_default,omitempty"`
+}
+
+// UpdateWarehouseRequest is a partial update of a stock location
+type UpdateWarehouseRequest struct {
+	Code       *string `json:"code,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Street1    *string `json:"street1,omitempty"`
+	City       *string `json:"city,omitempty"`
+	State      *string `json:"state,omitempty"`
+	PostalCode *string `json:"postal_code,omitempty"`
+	Country    *string `json:"country,omitempty"`
+	Priority   *int    `json:"priority,omitempty"`
+	IsDefault  *bool   `json:"is_default,omitempty"`
+	IsActive   *bool   `json:"is_active,omitempty"`
+}
@@ -0,0 +1,24 @@
+// internal/shared/middleware/cache.go
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/purushothdl/ecommerce-api/pkg/cache"
+)
+
+// InvalidateCacheMiddleware flushes the cache after every request that may write, for
+// routes that change catalog data (stock, prices, ratings) without going through the
+// cached services. It flushes on failures too, since a failed request may have
+// committed part of its work. Register it after TimeoutMiddleware so it runs once
+// the handler has finished.
+func InvalidateCacheMiddleware(c *cache.Cache) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				c.Flush()
+			}
+		})
+	}
+}
@@ -2,17 +2,25 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
 	"github.com/purushothdl/ecommerce-api/pkg/response"
 	"github.com/purushothdl/ecommerce-api/pkg/web"
 )
 
+// CartIDParam is the query parameter a signed-in user picks one of their named carts with.
+const CartIDParam = "cart_id"
+
 // CartMiddleware manages cart creation for authenticated or anonymous users.
-func CartMiddleware(cartSvc domain.CartService, isProduction bool) func(http.Handler) http.Handler {
+// Anonymous carts are identified by a signed cookie; forged or unreadable cookies are
+// ignored and replaced with a new cart. Signed-in users get their default cart unless
+// they choose another with ?cart_id=.
+func CartMiddleware(cartSvc domain.CartService, cartTokens *web.AnonymousTokens, isProduction bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Get user ID if authenticated
@@ -21,11 +29,38 @@ func CartMiddleware(cartSvc domain.CartService, isProduction bool) func(http.Han
 				userID = &user.ID
 			}
 
+			// An explicitly chosen cart must belong to the signed-in user
+			if raw := r.URL.Query().Get(CartIDParam); raw != "" {
+				if userID == nil {
+					response.Error(w, http.StatusUnauthorized, "sign in to choose a cart")
+					return
+				}
+				cartID, err := strconv.ParseInt(raw, 10, 64)
+				if err != nil || cartID <= 0 {
+					response.Error(w, http.StatusBadRequest, "invalid cart ID")
+					return
+				}
+				cart, err := cartSvc.GetUserCart(r.Context(), *userID, cartID)
+				if err != nil {
+					if errors.Is(err, apperrors.ErrNotFound) {
+						response.Error(w, http.StatusNotFound, "cart not found")
+						return
+					}
+					response.Error(w, http.StatusInternalServerError, "failed to load cart")
+					return
+				}
+				ctx := context.SetCart(r.Context(), context.CartContext{ID: cart.ID})
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			// Check for existing anonymous cart cookie
 			var anonymousCartID *int64
+			var legacyCookie bool
 			if cookie, err := r.Cookie(web.CartIDCookieName); err == nil {
-				if id, err := strconv.ParseInt(cookie.Value, 10, 64); err == nil {
+				if id, legacy, err := cartTokens.Verify(cookie.Value); err == nil {
 					anonymousCartID = &id
+					legacyCookie = legacy
 				}
 			}
 
@@ -36,9 +71,9 @@ func CartMiddleware(cartSvc domain.CartService, isProduction bool) func(http.Han
 				return
 			}
 
-			// Set cookie for new anonymous carts
-			if userID == nil && anonymousCartID == nil {
-				web.SetCartCookie(w, strconv.FormatInt(cart.ID, 10), isProduction)
+			// Set cookie for new anonymous carts, and swap unsigned legacy cookies for signed ones
+			if userID == nil && (anonymousCartID == nil || *anonymousCartID != cart.ID || legacyCookie) {
+				web.SetCartCookie(w, cartTokens.Sign(cart.ID), isProduction)
 			}
 
 			// Inject cart into context
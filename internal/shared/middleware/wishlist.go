@@ -0,0 +1,40 @@
+// internal/shared/middleware/wishlist.go
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/web"
+)
+
+// WishlistMiddleware resolves the wishlist of an authenticated or anonymous user, the same way CartMiddleware does for carts.
+// Anonymous wishlists are identified by a signed cookie; forged cookies are ignored.
+func WishlistMiddleware(wishlistSvc domain.WishlistService, wishlistTokens *web.AnonymousTokens, isProduction bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var userID *int64
+			if user, err := context.GetUser(r.Context()); err == nil {
+				userID = &user.ID
+			}
+
+			anonymousWishlistID := wishlistTokens.IDFromRequest(r)
+
+			wishlist, err := wishlistSvc.GetOrCreateWishlist(r.Context(), userID, anonymousWishlistID)
+			if err != nil {
+				response.Error(w, http.StatusInternalServerError, "failed to load wishlist")
+				return
+			}
+
+			// Set cookie for new anonymous wishlists
+			if userID == nil && (anonymousWishlistID == nil || *anonymousWishlistID != wishlist.ID) {
+				web.SetWishlistCookie(w, wishlistTokens.Sign(wishlist.ID), isProduction)
+			}
+
+			ctx := context.SetWishlist(r.Context(), context.WishlistContext{ID: wishlist.ID})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// internal/storage/local.go
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+)
+
+type localBlobStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalBlobStore stores blobs under dir and builds URLs below baseURL,
+// which should point at the route that serves dir (see FileServer).
+func NewLocalBlobStore(dir, baseURL string) (domain.BlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("local blob store: failed to create upload directory: %w", err)
+	}
+	return &localBlobStore{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+func (s *localBlobStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("local blob store: failed to create directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a half-written blob.
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("local blob store: failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("local blob store: failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("local blob store: failed to close blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("local blob store: failed to move blob into place: %w", err)
+	}
+	return nil
+}
+
+func (s *localBlobStore) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("local blob store: failed to delete blob: %w", err)
+	}
+	return nil
+}
+
+func (s *localBlobStore) URL(key string) string {
+	return s.baseURL + "/" + strings.TrimLeft(key, "/")
+}
+
+// path resolves a key to a file inside the upload directory, refusing keys that escape it.
+func (s *localBlobStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" {
+		return "", fmt.Errorf("local blob store: invalid key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
+
+// FileServer serves the files of a local blob store. Directory listings are disabled.
+func FileServer(dir string) http.Handler {
+	files := http.FileServer(http.Dir(dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		files.ServeHTTP(w, r)
+	})
+}
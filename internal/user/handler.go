@@ -6,7 +6,6 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/purushothdl/ecommerce-api/internal/domain"
 	usercontext "github.com/purushothdl/ecommerce-api/internal/shared/context"
@@ -18,24 +17,28 @@ import (
 )
 
 type Handler struct {
-	userService  domain.UserService
-	authService  domain.AuthService
-	cartService  domain.CartService
-	store        domain.Store
-	jwtSecret    string
-	isProduction bool
-	logger       *slog.Logger
+	userService    domain.UserService
+	authService    domain.AuthService
+	cartService    domain.CartService
+	store          domain.Store
+	jwtSecret      string
+	cartTokens     *web.AnonymousTokens
+	wishlistTokens *web.AnonymousTokens
+	isProduction   bool
+	logger         *slog.Logger
 }
 
-func NewHandler(userService domain.UserService, authService domain.AuthService, cartService domain.CartService, store domain.Store, jwtSecret string, isProduction bool, logger *slog.Logger) *Handler {
+func NewHandler(userService domain.UserService, authService domain.AuthService, cartService domain.CartService, store domain.Store, jwtSecret string, cartTokens, wishlistTokens *web.AnonymousTokens, isProduction bool, logger *slog.Logger) *Handler {
     return &Handler{
-        userService:  userService,
-        authService:  authService,
-        cartService:  cartService,
-        store:        store,
-        jwtSecret:    jwtSecret,
-        isProduction: isProduction,
-        logger:       logger,
+        userService:    userService,
+        authService:    authService,
+        cartService:    cartService,
+        store:          store,
+        jwtSecret:      jwtSecret,
+        cartTokens:     cartTokens,
+        wishlistTokens: wishlistTokens,
+        isProduction:   isProduction,
+        logger:         logger,
     }
 }
 
@@ -56,16 +59,14 @@ func (h *Handler) HandleRegister(w http.ResponseWriter, r *http.Request) {
         return
     }
 
-    // Parse anonymous cart ID
-    var anonymousCartID *int64
-    if cookie, err := r.Cookie(web.CartIDCookieName); err == nil {
-        if id, parseErr := strconv.ParseInt(cookie.Value, 10, 64); parseErr == nil {
-            anonymousCartID = &id
-        }
-    }
+    // Anonymous cart ID from the signed cart cookie; forged cookies are ignored
+    anonymousCartID := h.cartTokens.IDFromRequest(r)
+
+    // Anonymous wishlist ID from the signed wishlist cookie; forged cookies are ignored
+    anonymousWishlistID := h.wishlistTokens.IDFromRequest(r)
 
     // Single transactional call
-    user, refreshToken, err := h.userService.RegisterWithCartMerge(r.Context(), h.store, input.Name, input.Email, input.Password, anonymousCartID)
+    user, refreshToken, err := h.userService.RegisterWithCartMerge(r.Context(), h.store, input.Name, input.Email, input.Password, anonymousCartID, anonymousWishlistID)
     if err != nil {
         if errors.Is(err, apperrors.ErrDuplicateEmail) {
             h.logger.Warn("duplicate email", "email", input.Email)
@@ -81,6 +82,9 @@ func (h *Handler) HandleRegister(w http.ResponseWriter, r *http.Request) {
     if anonymousCartID != nil && *anonymousCartID != 0 {
         web.ClearCookie(w, web.CartIDCookieName, h.isProduction)
     }
+    if anonymousWishlistID != nil && *anonymousWishlistID != 0 {
+        web.ClearCookie(w, web.WishlistIDCookieName, h.isProduction)
+    }
 
     // Generate access token (doesn't need transaction)
     accessToken, err := h.authService.GenerateAccessToken(r.Context(), user)
@@ -164,3 +164,42 @@ func (r *userRepository) GetAll(ctx context.Context) ([]*models.User, error) {
 
 	return users, nil
 }
+
+// GetByRole returns every user with the given role, e.g. the admins to alert.
+func (r *userRepository) GetByRole(ctx context.Context, role models.Role) ([]*models.User, error) {
+	query := `
+        SELECT id, created_at, name, email, password_hash, role, version
+        FROM users
+        WHERE role = $1
+        ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, role)
+	if err != nil {
+		return nil, fmt.Errorf("user repository: failed to get users by role: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(
+			&user.ID,
+			&user.CreatedAt,
+			&user.Name,
+			&user.Email,
+			&user.PasswordHash,
+			&user.Role,
+			&user.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("user repository: failed to scan user row: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("user repository: error iterating over rows: %w", err)
+	}
+
+	return users, nil
+}
@@ -18,15 +18,17 @@ type userService struct {
 	userRepo    domain.UserRepository
 	authService domain.AuthService
 	cartService domain.CartService
+	wishlistService domain.WishlistService
 	logger      *slog.Logger
 }
 
 // NewUserService returns a domain.UserService implementation
-func NewUserService(userRepo domain.UserRepository, authService domain.AuthService, cartService domain.CartService, logger *slog.Logger) domain.UserService {
+func NewUserService(userRepo domain.UserRepository, authService domain.AuthService, cartService domain.CartService, wishlistService domain.WishlistService, logger *slog.Logger) domain.UserService {
 	return &userService{
 		userRepo:    userRepo,
 		authService: authService,
 		cartService: cartService,
+		wishlistService: wishlistService,
 		logger:      logger,
 	}
 }
@@ -138,7 +140,7 @@ func (s *userService) DeleteAccount(ctx context.Context, userID int64, password
 	return nil
 }
 
-func (s *userService) RegisterWithCartMerge(ctx context.Context, store domain.Store, name, email, password string, anonymousCartID *int64) (*models.User, *models.RefreshToken, error) {
+func (s *userService) RegisterWithCartMerge(ctx context.Context, store domain.Store, name, email, password string, anonymousCartID, anonymousWishlistID *int64) (*models.User, *models.RefreshToken, error) {
 	var user *models.User
 	var refreshToken *models.RefreshToken
 
@@ -186,6 +188,14 @@ func (s *userService) RegisterWithCartMerge(ctx context.Context, store domain.St
 			s.logger.Info("cart merge successful", "user_id", user.ID, "anonymous_cart_id", *anonymousCartID)
 		}
 
+		// 4. Handle wishlist merge the same way
+		if anonymousWishlistID != nil && *anonymousWishlistID != 0 {
+			if err := s.wishlistService.HandleLoginWithTransaction(ctx, q, user.ID, *anonymousWishlistID); err != nil {
+				s.logger.Error("failed to merge wishlist", "user_id", user.ID, "anonymous_wishlist_id", *anonymousWishlistID, "error", err)
+				return fmt.Errorf("failed to merge wishlist: %w", err)
+			}
+		}
+
 		return nil
 	})
 
@@ -0,0 +1,90 @@
+// internal/warehouse/allocation.go
+package warehouse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+// Allocation strategies, set through configs.InventoryConfig.AllocationStrategy.
+const (
+	StrategyNearest  = "nearest"  // Closest to the shipping address, then by priority
+	StrategyPriority = "priority" // Lowest priority value first
+)
+
+// Allocate picks the one active warehouse that ships an order. Only warehouses that
+// can fill every line on their own qualify, so an order is never split; among those
+// the strategy decides. The caller must already hold the product and variant locks of
+// every line so the availability read here cannot go stale before reserving.
+func Allocate(ctx context.Context, q *domain.Queries, strategy string, shipTo *models.UserAddress, items []*models.OrderItem) (*models.Warehouse, error) {
+	warehouses, err := q.WarehouseRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[int64]bool, len(warehouses))
+	for _, w := range warehouses {
+		candidates[w.ID] = true
+	}
+	for _, item := range items {
+		stock, err := q.WarehouseStockRepo.ListAvailable(ctx, item.ProductID, item.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		canFill := make(map[int64]bool, len(stock))
+		for _, s := range stock {
+			if s.Available >= item.Quantity {
+				canFill[s.WarehouseID] = true
+			}
+		}
+		for id := range candidates {
+			if !canFill[id] {
+				delete(candidates, id)
+			}
+		}
+	}
+
+	eligible := make([]*models.Warehouse, 0, len(candidates))
+	for _, w := range warehouses {
+		if candidates[w.ID] {
+			eligible = append(eligible, w)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil, fmt.Errorf("no single warehouse can fulfil the whole order: %w", apperrors.ErrInsufficientStock)
+	}
+
+	// List already returns priority order, which is the tie-break for both strategies.
+	if strategy == StrategyNearest && shipTo != nil {
+		sort.SliceStable(eligible, func(i, j int) bool {
+			return proximity(eligible[i], shipTo) > proximity(eligible[j], shipTo)
+		})
+	}
+	return eligible[0], nil
+}
+
+// proximity scores how close a warehouse is to an address from what both record:
+// 3 for the same postal code, 2 for the same state, 1 for the same country, else 0.
+func proximity(w *models.Warehouse, addr *models.UserAddress) int {
+	if !sameField(w.Country, addr.Country) {
+		return 0
+	}
+	if !sameField(w.State, addr.State) {
+		return 1
+	}
+	if !sameField(w.PostalCode, addr.PostalCode) {
+		return 2
+	}
+	return 3
+}
+
+func sameField(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	return a != "" && strings.EqualFold(a, b)
+}
@@ -0,0 +1,121 @@
+// internal/warehouse/handler.go
+package warehouse
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	warehouseSvc domain.WarehouseService
+	logger       *slog.Logger
+}
+
+func NewHandler(warehouseSvc domain.WarehouseService, logger *slog.Logger) *Handler {
+	return &Handler{warehouseSvc: warehouseSvc, logger: logger}
+}
+
+func (h *Handler) HandleListWarehouses(w http.ResponseWriter, r *http.Request) {
+	warehouses, err := h.warehouseSvc.ListWarehouses(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "could not retrieve warehouses")
+		return
+	}
+	response.JSON(w, http.StatusOK, warehouses)
+}
+
+func (h *Handler) HandleCreateWarehouse(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWarehouseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateCreateWarehouseRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	warehouse, err := h.warehouseSvc.CreateWarehouse(r.Context(), &req)
+	if err != nil {
+		h.writeWarehouseWriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, warehouse)
+}
+
+func (h *Handler) HandleUpdateWarehouse(w http.ResponseWriter, r *http.Request) {
+	warehouseID, err := strconv.ParseInt(chi.URLParam(r, "warehouseId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid warehouse ID")
+		return
+	}
+
+	var req dto.UpdateWarehouseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	ValidateUpdateWarehouseRequest(req, v)
+	if !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	warehouse, err := h.warehouseSvc.UpdateWarehouse(r.Context(), warehouseID, &req)
+	if err != nil {
+		h.writeWarehouseWriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, warehouse)
+}
+
+// HandleListProductStock shows where a product's stock is held.
+func (h *Handler) HandleListProductStock(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	stock, err := h.warehouseSvc.ListProductStock(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "product not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "could not retrieve stock locations")
+		return
+	}
+	response.JSON(w, http.StatusOK, stock)
+}
+
+func (h *Handler) writeWarehouseWriteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "warehouse not found")
+	case errors.Is(err, apperrors.ErrDuplicateWarehouse):
+		response.Error(w, http.StatusConflict, "a warehouse with this code already exists")
+	case errors.Is(err, apperrors.ErrWarehouseInactive):
+		response.Error(w, http.StatusConflict, "only an active warehouse can be the default")
+	case errors.Is(err, apperrors.ErrDefaultWarehouseActive):
+		response.Error(w, http.StatusConflict, "the default warehouse cannot be deactivated, make another warehouse the default first")
+	default:
+		h.logger.Error("failed to save warehouse", "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not save warehouse")
+	}
+}
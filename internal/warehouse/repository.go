@@ -0,0 +1,135 @@
+// internal/warehouse/repository.go
+package warehouse
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type warehouseRepository struct {
+	db domain.DBTX
+}
+
+func NewWarehouseRepository(db domain.DBTX) domain.WarehouseRepository {
+	return &warehouseRepository{db: db}
+}
+
+const warehouseColumns = `id, code, name, street1, city, state, postal_code, country, priority, is_default, is_active, created_at, updated_at`
+
+func scanWarehouse(row interface{ Scan(dest ...any) error }, w *models.Warehouse) error {
+	return row.Scan(
+		&w.ID, &w.Code, &w.Name, &w.Street1, &w.City, &w.State, &w.PostalCode, &w.Country,
+		&w.Priority, &w.IsDefault, &w.IsActive, &w.CreatedAt, &w.UpdatedAt,
+	)
+}
+
+func (r *warehouseRepository) Create(ctx context.Context, w *models.Warehouse) error {
+	query := `
+        INSERT INTO warehouses (code, name, street1, city, state, postal_code, country, priority, is_default, is_active)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowContext(ctx, query,
+		w.Code, w.Name, w.Street1, w.City, w.State, w.PostalCode, w.Country, w.Priority, w.IsDefault, w.IsActive,
+	).Scan(&w.ID, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return apperrors.ErrDuplicateWarehouse
+		}
+		return fmt.Errorf("warehouse repository: failed to create warehouse: %w", err)
+	}
+	return nil
+}
+
+func (r *warehouseRepository) GetByID(ctx context.Context, id int64) (*models.Warehouse, error) {
+	var w models.Warehouse
+	err := scanWarehouse(r.db.QueryRowContext(ctx, `SELECT `+warehouseColumns+` FROM warehouses WHERE id = $1`, id), &w)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("warehouse repository: failed to get warehouse: %w", err)
+	}
+	return &w, nil
+}
+
+// GetDefault returns the warehouse that takes stock edits which name no location.
+func (r *warehouseRepository) GetDefault(ctx context.Context) (*models.Warehouse, error) {
+	var w models.Warehouse
+	err := scanWarehouse(r.db.QueryRowContext(ctx, `SELECT `+warehouseColumns+` FROM warehouses WHERE is_default`), &w)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("warehouse repository: failed to get default warehouse: %w", err)
+	}
+	return &w, nil
+}
+
+// List returns warehouses in allocation order: lowest priority first, then oldest.
+func (r *warehouseRepository) List(ctx context.Context, activeOnly bool) ([]*models.Warehouse, error) {
+	query := `SELECT ` + warehouseColumns + ` FROM warehouses`
+	if activeOnly {
+		query += ` WHERE is_active`
+	}
+	query += ` ORDER BY priority, id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("warehouse repository: failed to list warehouses: %w", err)
+	}
+	defer rows.Close()
+
+	warehouses := []*models.Warehouse{}
+	for rows.Next() {
+		var w models.Warehouse
+		if err := scanWarehouse(rows, &w); err != nil {
+			return nil, fmt.Errorf("warehouse repository: failed to scan warehouse: %w", err)
+		}
+		warehouses = append(warehouses, &w)
+	}
+	return warehouses, rows.Err()
+}
+
+func (r *warehouseRepository) Update(ctx context.Context, w *models.Warehouse) error {
+	query := `
+        UPDATE warehouses
+        SET code = $1, name = $2, street1 = $3, city = $4, state = $5, postal_code = $6, country = $7,
+            priority = $8, is_default = $9, is_active = $10, updated_at = NOW()
+        WHERE id = $11
+        RETURNING updated_at`
+	err := r.db.QueryRowContext(ctx, query,
+		w.Code, w.Name, w.Street1, w.City, w.State, w.PostalCode, w.Country, w.Priority, w.IsDefault, w.IsActive, w.ID,
+	).Scan(&w.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.ErrNotFound
+		}
+		if isUniqueViolation(err) {
+			return apperrors.ErrDuplicateWarehouse
+		}
+		return fmt.Errorf("warehouse repository: failed to update warehouse: %w", err)
+	}
+	return nil
+}
+
+// ClearDefault unsets the default flag on every warehouse except exceptID, so a new
+// default can be set without tripping the one-default index.
+func (r *warehouseRepository) ClearDefault(ctx context.Context, exceptID int64) error {
+	query := `UPDATE warehouses SET is_default = false, updated_at = NOW() WHERE is_default AND id <> $1`
+	if _, err := r.db.ExecContext(ctx, query, exceptID); err != nil {
+		return fmt.Errorf("warehouse repository: failed to clear default warehouse: %w", err)
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,48 @@
+// internal/warehouse/requests.go
+package warehouse
+
+import (
+	"regexp"
+
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+var codeRX = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateCreateWarehouseRequest validates a new stock location.
+func ValidateCreateWarehouseRequest(r dto.CreateWarehouseRequest, v *validator.Validator) {
+	validateCode(r.Code, v)
+	validateName(r.Name, v)
+	if r.Priority != nil {
+		v.Check(*r.Priority >= 0, "priority", "must not be negative")
+	}
+}
+
+// ValidateUpdateWarehouseRequest validates a partial update. The default cannot be
+// switched off directly; another warehouse is made the default instead.
+func ValidateUpdateWarehouseRequest(r dto.UpdateWarehouseRequest, v *validator.Validator) {
+	if r.Code != nil {
+		validateCode(*r.Code, v)
+	}
+	if r.Name != nil {
+		validateName(*r.Name, v)
+	}
+	if r.Priority != nil {
+		v.Check(*r.Priority >= 0, "priority", "must not be negative")
+	}
+	if r.IsDefault != nil {
+		v.Check(*r.IsDefault, "is_default", "can only be set to true; make another warehouse the default instead")
+	}
+}
+
+func validateCode(code string, v *validator.Validator) {
+	v.Check(validator.NotBlank(code), "code", "must be provided")
+	v.Check(len(code) <= 32, "code", "must not exceed 32 characters")
+	v.Check(validator.Matches(code, codeRX), "code", "may only contain letters, digits, '-' and '_'")
+}
+
+func validateName(name string, v *validator.Validator) {
+	v.Check(validator.NotBlank(name), "name", "must be provided")
+	v.Check(len(name) <= 100, "name", "must not exceed 100 characters")
+}
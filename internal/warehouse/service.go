@@ -0,0 +1,156 @@
+// internal/warehouse/service.go
+package warehouse
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	"github.com/purushothdl/ecommerce-api/internal/shared/dto"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/utils/ptr"
+)
+
+// defaultPriority matches the column default, so unranked warehouses sort after ranked ones.
+const defaultPriority = 100
+
+type warehouseService struct {
+	repo        domain.WarehouseRepository
+	stockRepo   domain.WarehouseStockRepository
+	productRepo domain.ProductRepository
+	store       domain.Store
+	logger      *slog.Logger
+}
+
+func NewWarehouseService(repo domain.WarehouseRepository, stockRepo domain.WarehouseStockRepository, productRepo domain.ProductRepository, store domain.Store, logger *slog.Logger) domain.WarehouseService {
+	return &warehouseService{
+		repo:        repo,
+		stockRepo:   stockRepo,
+		productRepo: productRepo,
+		store:       store,
+		logger:      logger,
+	}
+}
+
+// ListWarehouses returns every warehouse, inactive ones included, in allocation order.
+func (s *warehouseService) ListWarehouses(ctx context.Context) ([]*models.Warehouse, error) {
+	warehouses, err := s.repo.List(ctx, false)
+	if err != nil {
+		s.logger.Error("failed to list warehouses", "error", err)
+		return nil, fmt.Errorf("warehouse service: could not list warehouses: %w", err)
+	}
+	return warehouses, nil
+}
+
+// CreateWarehouse adds an active stock location. Making it the default takes the flag
+// from the current default in the same transaction.
+func (s *warehouseService) CreateWarehouse(ctx context.Context, req *dto.CreateWarehouseRequest) (*models.Warehouse, error) {
+	warehouse := &models.Warehouse{
+		Code:       strings.TrimSpace(req.Code),
+		Name:       strings.TrimSpace(req.Name),
+		Street1:    strings.TrimSpace(req.Street1),
+		City:       strings.TrimSpace(req.City),
+		State:      strings.TrimSpace(req.State),
+		PostalCode: strings.TrimSpace(req.PostalCode),
+		Country:    strings.TrimSpace(req.Country),
+		Priority:   defaultPriority,
+		IsDefault:  req.IsDefault,
+		IsActive:   true,
+	}
+	if req.Priority != nil {
+		warehouse.Priority = *req.Priority
+	}
+
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		if warehouse.IsDefault {
+			if err := q.WarehouseRepo.ClearDefault(ctx, 0); err != nil {
+				return err
+			}
+		}
+		return q.WarehouseRepo.Create(ctx, warehouse)
+	})
+	if err != nil {
+		s.logger.Warn("failed to create warehouse", "code", warehouse.Code, "error", err)
+		return nil, fmt.Errorf("warehouse service: could not create warehouse: %w", err)
+	}
+
+	s.logger.Info("warehouse created", "warehouse_id", warehouse.ID, "code", warehouse.Code, "is_default", warehouse.IsDefault)
+	return warehouse, nil
+}
+
+// UpdateWarehouse applies a partial update. The default warehouse must stay active,
+// and only an active warehouse can become the default.
+func (s *warehouseService) UpdateWarehouse(ctx context.Context, id int64, req *dto.UpdateWarehouseRequest) (*models.Warehouse, error) {
+	var warehouse *models.Warehouse
+	err := s.store.ExecTx(ctx, func(q *domain.Queries) error {
+		var err error
+		if warehouse, err = q.WarehouseRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		ptr.UpdateStringIfProvided(&warehouse.Code, req.Code)
+		ptr.UpdateStringIfProvided(&warehouse.Name, req.Name)
+		setAddressField(&warehouse.Street1, req.Street1)
+		setAddressField(&warehouse.City, req.City)
+		setAddressField(&warehouse.State, req.State)
+		setAddressField(&warehouse.PostalCode, req.PostalCode)
+		setAddressField(&warehouse.Country, req.Country)
+		if req.Priority != nil {
+			warehouse.Priority = *req.Priority
+		}
+		if req.IsActive != nil {
+			warehouse.IsActive = *req.IsActive
+		}
+
+		becomesDefault := req.IsDefault != nil && *req.IsDefault && !warehouse.IsDefault
+		if becomesDefault {
+			warehouse.IsDefault = true
+		}
+		if warehouse.IsDefault && !warehouse.IsActive {
+			if becomesDefault {
+				return apperrors.ErrWarehouseInactive
+			}
+			return apperrors.ErrDefaultWarehouseActive
+		}
+
+		if becomesDefault {
+			if err := q.WarehouseRepo.ClearDefault(ctx, id); err != nil {
+				return err
+			}
+		}
+		return q.WarehouseRepo.Update(ctx, warehouse)
+	})
+	if err != nil {
+		s.logger.Warn("failed to update warehouse", "warehouse_id", id, "error", err)
+		return nil, fmt.Errorf("warehouse service: could not update warehouse: %w", err)
+	}
+
+	s.logger.Info("warehouse updated", "warehouse_id", id, "is_default", warehouse.IsDefault, "is_active", warehouse.IsActive)
+	return warehouse, nil
+}
+
+// ListProductStock returns how much of a product, and of each of its variants, every
+// warehouse holds and can still sell.
+func (s *warehouseService) ListProductStock(ctx context.Context, productID int64) ([]models.WarehouseStock, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, fmt.Errorf("warehouse service: could not retrieve product: %w", err)
+	}
+
+	stock, err := s.stockRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to list warehouse stock", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("warehouse service: could not list stock: %w", err)
+	}
+	return stock, nil
+}
+
+// setAddressField applies a provided address field. Unlike names, address fields may
+// be cleared, so an empty string is applied too.
+func setAddressField(dest *string, src *string) {
+	if src != nil {
+		*dest = strings.TrimSpace(*src)
+	}
+}
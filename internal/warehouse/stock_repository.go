@@ -0,0 +1,117 @@
+// internal/warehouse/stock_repository.go
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type warehouseStockRepository struct {
+	db domain.DBTX
+}
+
+func NewWarehouseStockRepository(db domain.DBTX) domain.WarehouseStockRepository {
+	return &warehouseStockRepository{db: db}
+}
+
+// Adjust adds delta to the stock a warehouse holds of a product, or of one of its
+// variants, creating the row on first use. The database keeps the product and
+// variant totals in step. Taking more than the warehouse holds is ErrInsufficientStock.
+func (r *warehouseStockRepository) Adjust(ctx context.Context, warehouseID, productID int64, variantID *int64, delta int) error {
+	update := `
+        UPDATE warehouse_stock
+        SET quantity = quantity + $4, updated_at = NOW()
+        WHERE warehouse_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3`
+	result, err := r.db.ExecContext(ctx, update, warehouseID, productID, variantID, delta)
+	if err != nil {
+		return mapStockWriteError(err, "failed to adjust stock")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("warehouse stock repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	// Nothing is held here yet, so there is nothing to take.
+	if delta < 0 {
+		return apperrors.ErrInsufficientStock
+	}
+	// The conflict clause covers a concurrent first delivery to the same location.
+	insert := `
+        INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT ON CONSTRAINT warehouse_stock_location_key
+        DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity, updated_at = NOW()`
+	if _, err := r.db.ExecContext(ctx, insert, warehouseID, productID, variantID, delta); err != nil {
+		return mapStockWriteError(err, "failed to add stock")
+	}
+	return nil
+}
+
+// mapStockWriteError turns a negative quantity into ErrInsufficientStock and an
+// unknown warehouse, product or variant into ErrNotFound.
+func mapStockWriteError(err error, action string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23514":
+			return apperrors.ErrInsufficientStock
+		case "23503":
+			return apperrors.ErrNotFound
+		}
+	}
+	return fmt.Errorf("warehouse stock repository: %s: %w", action, err)
+}
+
+// ListByProduct returns every stock row of a product, its variants' included.
+func (r *warehouseStockRepository) ListByProduct(ctx context.Context, productID int64) ([]models.WarehouseStock, error) {
+	query := `
+        SELECT s.warehouse_id, w.code, s.product_id, s.variant_id, s.quantity,
+               s.quantity - reserved_stock_at(s.warehouse_id, s.product_id, s.variant_id), s.updated_at
+        FROM warehouse_stock s
+        JOIN warehouses w ON w.id = s.warehouse_id
+        WHERE s.product_id = $1
+        ORDER BY w.priority, w.id, s.variant_id NULLS FIRST`
+	return r.list(ctx, query, productID)
+}
+
+// ListAvailable returns what each active warehouse can still sell of a product line:
+// its stock less unexpired reservations held there. A nil variant means the product itself.
+func (r *warehouseStockRepository) ListAvailable(ctx context.Context, productID int64, variantID *int64) ([]models.WarehouseStock, error) {
+	query := `
+        SELECT s.warehouse_id, w.code, s.product_id, s.variant_id, s.quantity,
+               s.quantity - reserved_stock_at(s.warehouse_id, s.product_id, s.variant_id), s.updated_at
+        FROM warehouse_stock s
+        JOIN warehouses w ON w.id = s.warehouse_id
+        WHERE s.product_id = $1 AND s.variant_id IS NOT DISTINCT FROM $2 AND w.is_active
+        ORDER BY w.priority, w.id`
+	return r.list(ctx, query, productID, variantID)
+}
+
+func (r *warehouseStockRepository) list(ctx context.Context, query string, args ...any) ([]models.WarehouseStock, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("warehouse stock repository: failed to list stock: %w", err)
+	}
+	defer rows.Close()
+
+	stock := []models.WarehouseStock{}
+	for rows.Next() {
+		var s models.WarehouseStock
+		if err := rows.Scan(
+			&s.WarehouseID, &s.WarehouseCode, &s.ProductID, &s.VariantID, &s.Quantity, &s.Available, &s.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("warehouse stock repository: failed to scan stock: %w", err)
+		}
+		stock = append(stock, s)
+	}
+	return stock, rows.Err()
+}
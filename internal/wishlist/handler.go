@@ -0,0 +1,157 @@
+// internal/wishlist/handler.go
+package wishlist
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/ecommerce-api/internal/cart"
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/shared/context"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+	"github.com/purushothdl/ecommerce-api/pkg/response"
+	"github.com/purushothdl/ecommerce-api/pkg/validator"
+)
+
+type Handler struct {
+	wishlistSvc domain.WishlistService
+	logger      *slog.Logger
+}
+
+func NewHandler(wishlistSvc domain.WishlistService, logger *slog.Logger) *Handler {
+	return &Handler{wishlistSvc: wishlistSvc, logger: logger}
+}
+
+// HandleGetWishlist returns the current wishlist with live prices and stock.
+func (h *Handler) HandleGetWishlist(w http.ResponseWriter, r *http.Request) {
+	wishlistCtx, err := context.GetWishlist(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "wishlist unavailable")
+		return
+	}
+
+	wishlist, err := h.wishlistSvc.GetWishlistContents(r.Context(), wishlistCtx.ID)
+	if err != nil {
+		h.logger.Error("failed to get wishlist", "wishlist_id", wishlistCtx.ID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not retrieve wishlist")
+		return
+	}
+	response.JSON(w, http.StatusOK, NewWishlistResponse(wishlist))
+}
+
+// HandleAddItem saves a product to the wishlist. Saving it again is a no-op.
+func (h *Handler) HandleAddItem(w http.ResponseWriter, r *http.Request) {
+	wishlistCtx, err := context.GetWishlist(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "wishlist unavailable")
+		return
+	}
+
+	var input AddItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	if input.Validate(v); !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	wishlist, err := h.wishlistSvc.AddItem(r.Context(), wishlistCtx.ID, input.ProductID, input.VariantID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, apperrors.ErrVariantRequired):
+			response.Error(w, http.StatusUnprocessableEntity, "this product is sold in variants, please choose one")
+		default:
+			h.logger.Error("failed to add wishlist item", "wishlist_id", wishlistCtx.ID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not update wishlist")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, NewWishlistResponse(wishlist))
+}
+
+func (h *Handler) HandleRemoveItem(w http.ResponseWriter, r *http.Request) {
+	wishlistCtx, err := context.GetWishlist(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "wishlist unavailable")
+		return
+	}
+
+	itemID, err := strconv.ParseInt(chi.URLParam(r, "itemId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid item ID")
+		return
+	}
+
+	wishlist, err := h.wishlistSvc.RemoveItem(r.Context(), wishlistCtx.ID, itemID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "wishlist item not found")
+			return
+		}
+		h.logger.Error("failed to remove wishlist item", "wishlist_id", wishlistCtx.ID, "item_id", itemID, "error", err)
+		response.Error(w, http.StatusInternalServerError, "could not update wishlist")
+		return
+	}
+	response.JSON(w, http.StatusOK, NewWishlistResponse(wishlist))
+}
+
+// HandleMoveToCart moves a wishlist item into the cart and returns the updated cart.
+// The body is optional; without one a single unit is added.
+func (h *Handler) HandleMoveToCart(w http.ResponseWriter, r *http.Request) {
+	wishlistCtx, err := context.GetWishlist(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "wishlist unavailable")
+		return
+	}
+	cartCtx, err := context.GetCart(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "cart unavailable")
+		return
+	}
+
+	itemID, err := strconv.ParseInt(chi.URLParam(r, "itemId"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid item ID")
+		return
+	}
+
+	var input MoveToCartRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	v := validator.New()
+	if input.Validate(v); !v.Valid() {
+		response.JSON(w, http.StatusUnprocessableEntity, v.Errors)
+		return
+	}
+
+	updatedCart, err := h.wishlistSvc.MoveItemToCart(r.Context(), wishlistCtx.ID, itemID, cartCtx.ID, input.Quantity)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "wishlist item not found")
+		case errors.Is(err, apperrors.ErrVariantRequired):
+			response.Error(w, http.StatusUnprocessableEntity, "this product is sold in variants, please choose one")
+		case errors.Is(err, apperrors.ErrInsufficientStock):
+			response.Error(w, http.StatusConflict, "insufficient stock")
+		default:
+			h.logger.Error("failed to move wishlist item to cart", "wishlist_id", wishlistCtx.ID, "item_id", itemID, "error", err)
+			response.Error(w, http.StatusInternalServerError, "could not move item to cart")
+		}
+		return
+	}
+	response.JSON(w, http.StatusOK, cart.NewCartResponse(updatedCart, updatedCart.Items))
+}
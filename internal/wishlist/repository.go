@@ -0,0 +1,190 @@
+// internal/wishlist/repository.go
+package wishlist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type wishlistRepository struct {
+	db domain.DBTX
+}
+
+func NewWishlistRepository(db domain.DBTX) domain.WishlistRepository {
+	return &wishlistRepository{db: db}
+}
+
+func (r *wishlistRepository) GetByUserID(ctx context.Context, userID int64) (*models.Wishlist, error) {
+	query := `SELECT id, user_id, created_at, updated_at FROM wishlists WHERE user_id = $1`
+	var wl models.Wishlist
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&wl.ID, &wl.UserID, &wl.CreatedAt, &wl.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("wishlist repository: failed to get wishlist by user ID: %w", err)
+	}
+	return &wl, nil
+}
+
+func (r *wishlistRepository) GetByID(ctx context.Context, id int64) (*models.Wishlist, error) {
+	query := `SELECT id, user_id, created_at, updated_at FROM wishlists WHERE id = $1`
+	var wl models.Wishlist
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&wl.ID, &wl.UserID, &wl.CreatedAt, &wl.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("wishlist repository: failed to get wishlist: %w", err)
+	}
+	return &wl, nil
+}
+
+func (r *wishlistRepository) Create(ctx context.Context, userID *int64) (*models.Wishlist, error) {
+	query := `INSERT INTO wishlists (user_id) VALUES ($1) RETURNING id, user_id, created_at, updated_at`
+	var wl models.Wishlist
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&wl.ID, &wl.UserID, &wl.CreatedAt, &wl.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("wishlist repository: failed to create wishlist: %w", err)
+	}
+	return &wl, nil
+}
+
+func (r *wishlistRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM wishlists WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("wishlist repository: failed to delete wishlist: %w", err)
+	}
+	return nil
+}
+
+// AddItem saves a product, or one of its variants, to a wishlist. Products sold as
+// variants must be saved as a variant, as in the cart, so the item can be moved there
+// later. Saving an item twice is a no-op.
+func (r *wishlistRepository) AddItem(ctx context.Context, wishlistID, productID int64, variantID *int64) error {
+	var hasVariants, variantMatches bool
+	query := `
+        SELECT EXISTS (SELECT 1 FROM product_variants WHERE product_id = p.id),
+               EXISTS (SELECT 1 FROM product_variants WHERE product_id = p.id AND id = $2)
+        FROM products p
+        WHERE p.id = $1 AND p.status = 'active'`
+	if err := r.db.QueryRowContext(ctx, query, productID, variantID).Scan(&hasVariants, &variantMatches); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.ErrNotFound
+		}
+		return fmt.Errorf("wishlist repository: failed to look up product: %w", err)
+	}
+	if variantID != nil && !variantMatches {
+		return apperrors.ErrNotFound
+	}
+	if variantID == nil && hasVariants {
+		return apperrors.ErrVariantRequired
+	}
+
+	insertQuery := `
+        INSERT INTO wishlist_items (wishlist_id, product_id, variant_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT ON CONSTRAINT wishlist_items_wishlist_product_variant_key DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, insertQuery, wishlistID, productID, variantID); err != nil {
+		return fmt.Errorf("wishlist repository: failed to add item: %w", err)
+	}
+
+	_, err := r.db.ExecContext(ctx, `UPDATE wishlists SET updated_at = NOW() WHERE id = $1`, wishlistID)
+	return err
+}
+
+// RemoveItem deletes an item, provided it belongs to the given wishlist.
+func (r *wishlistRepository) RemoveItem(ctx context.Context, wishlistID, itemID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM wishlist_items WHERE id = $1 AND wishlist_id = $2`, itemID, wishlistID)
+	if err != nil {
+		return fmt.Errorf("wishlist repository: failed to remove item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("wishlist repository: failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	_, err = r.db.ExecContext(ctx, `UPDATE wishlists SET updated_at = NOW() WHERE id = $1`, wishlistID)
+	return err
+}
+
+// GetItemsByWishlistID returns a wishlist's items, newest first, with the current
+// price and stock of each. Products unpublished or archived since they were saved are left out.
+func (r *wishlistRepository) GetItemsByWishlistID(ctx context.Context, wishlistID int64) ([]models.WishlistItem, error) {
+	query := `
+        SELECT
+            wi.id, wi.wishlist_id, wi.created_at,
+            p.id, p.name, p.price, p.thumbnail, p.stock_quantity, p.stock_quantity - reserved_stock(p.id, NULL),
+            sale_price_at(p.id, NULL, NOW()), active_price_schedule(p.id, NULL, NOW()),
+            v.id, v.sku, v.price, v.stock_quantity, v.stock_quantity - reserved_stock(p.id, v.id), v.images, v.options,
+            sale_price_at(p.id, v.id, NOW()), active_price_schedule(p.id, v.id, NOW())
+        FROM wishlist_items wi
+        JOIN products p ON wi.product_id = p.id
+        LEFT JOIN product_variants v ON wi.variant_id = v.id
+        WHERE wi.wishlist_id = $1 AND p.status = 'active'
+        ORDER BY wi.created_at DESC, wi.id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, wishlistID)
+	if err != nil {
+		return nil, fmt.Errorf("wishlist repository: failed to get items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.WishlistItem{}
+	for rows.Next() {
+		var item models.WishlistItem
+		var product models.Product
+		var variant models.ProductVariant
+		var productPrice float64
+		var productSale, variantSale *float64
+		var productScheduleID, variantScheduleID *int64
+		var variantID, variantStock, variantAvailable sql.NullInt64
+		var variantSKU sql.NullString
+		var variantPrice sql.NullFloat64
+		var variantOptions []byte
+		if err := rows.Scan(
+			&item.ID, &item.WishlistID, &item.CreatedAt,
+			&product.ID, &product.Name, &productPrice, &product.Thumbnail, &product.StockQuantity, &product.AvailableStock,
+			&productSale, &productScheduleID,
+			&variantID, &variantSKU, &variantPrice, &variantStock, &variantAvailable, &variant.Images, &variantOptions,
+			&variantSale, &variantScheduleID,
+		); err != nil {
+			return nil, fmt.Errorf("wishlist repository: failed to scan item: %w", err)
+		}
+		product.SetPrice(productPrice, productSale, productScheduleID)
+		item.Product = &product
+		if variantID.Valid {
+			variant.ID = variantID.Int64
+			variant.ProductID = product.ID
+			variant.SKU = variantSKU.String
+			variant.SetPrice(variantPrice.Float64, variantSale, variantScheduleID)
+			variant.StockQuantity = int(variantStock.Int64)
+			variant.AvailableStock = int(variantAvailable.Int64)
+			variant.Options = variantOptions
+			item.Variant = &variant
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// MergeWishlists copies the items of one wishlist into another, skipping ones it already has.
+func (r *wishlistRepository) MergeWishlists(ctx context.Context, fromWishlistID, toWishlistID int64) error {
+	query := `
+        INSERT INTO wishlist_items (wishlist_id, product_id, variant_id, created_at)
+        SELECT $1, product_id, variant_id, created_at FROM wishlist_items WHERE wishlist_id = $2
+        ON CONFLICT ON CONSTRAINT wishlist_items_wishlist_product_variant_key DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, toWishlistID, fromWishlistID); err != nil {
+		return fmt.Errorf("wishlist repository: failed to merge wishlists: %w", err)
+	}
+	return nil
+}
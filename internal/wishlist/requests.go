@@ -0,0 +1,32 @@
+// internal/wishlist/requests.go
+package wishlist
+
+import "github.com/purushothdl/ecommerce-api/pkg/validator"
+
+// AddItemRequest defines the request body for saving a product to the wishlist.
+// VariantID is required for products that are sold as variants.
+type AddItemRequest struct {
+	ProductID int64  `json:"product_id"`
+	VariantID *int64 `json:"variant_id,omitempty"`
+}
+
+// Validate checks the AddItemRequest for correctness.
+func (r AddItemRequest) Validate(v *validator.Validator) {
+	v.Check(r.ProductID > 0, "product_id", "must be a positive integer")
+	if r.VariantID != nil {
+		v.Check(*r.VariantID > 0, "variant_id", "must be a positive integer")
+	}
+}
+
+// MoveToCartRequest defines the optional request body for moving an item to the cart.
+type MoveToCartRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+// Validate checks the MoveToCartRequest for correctness. A missing quantity means one.
+func (r *MoveToCartRequest) Validate(v *validator.Validator) {
+	if r.Quantity == 0 {
+		r.Quantity = 1
+	}
+	v.Check(r.Quantity > 0, "quantity", "must be a positive integer")
+}
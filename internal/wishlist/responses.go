@@ -0,0 +1,92 @@
+// internal/wishlist/responses.go
+package wishlist
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/purushothdl/ecommerce-api/internal/models"
+)
+
+// WishlistResponse represents a wishlist with its items
+type WishlistResponse struct {
+	ID        int64                  `json:"id"`
+	UserID    *int64                 `json:"user_id,omitempty"`
+	Items     []WishlistItemResponse `json:"items"`
+	ItemCount int                    `json:"item_count"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// WishlistItemResponse represents a saved product with its current price and stock
+type WishlistItemResponse struct {
+	ID             int64                    `json:"id"`
+	Product        WishlistProductResponse  `json:"product"`
+	Variant        *WishlistVariantResponse `json:"variant,omitempty"`
+	Price          float64                  `json:"price"`
+	CompareAtPrice *float64                 `json:"compare_at_price,omitempty"`
+	InStock        bool                     `json:"in_stock"`
+	AddedAt        time.Time                `json:"added_at"`
+}
+
+// WishlistProductResponse represents only the product fields needed in a wishlist
+type WishlistProductResponse struct {
+	ID             int64    `json:"id"`
+	Name           string   `json:"name"`
+	Price          float64  `json:"price"`
+	CompareAtPrice *float64 `json:"compare_at_price,omitempty"`
+	Thumbnail      string   `json:"thumbnail"`
+	AvailableStock int      `json:"available_stock"`
+}
+
+// WishlistVariantResponse represents the saved variant of a wishlist item
+type WishlistVariantResponse struct {
+	ID             int64           `json:"id"`
+	SKU            string          `json:"sku"`
+	Price          float64         `json:"price"`
+	CompareAtPrice *float64        `json:"compare_at_price,omitempty"`
+	AvailableStock int             `json:"available_stock"`
+	Options        json.RawMessage `json:"options"`
+}
+
+// NewWishlistResponse creates a WishlistResponse from models
+func NewWishlistResponse(wishlist *models.Wishlist) *WishlistResponse {
+	items := make([]WishlistItemResponse, len(wishlist.Items))
+	for i, item := range wishlist.Items {
+		resp := WishlistItemResponse{
+			ID: item.ID,
+			Product: WishlistProductResponse{
+				ID:             item.Product.ID,
+				Name:           item.Product.Name,
+				Price:          item.Product.Price,
+				CompareAtPrice: item.Product.CompareAtPrice,
+				Thumbnail:      item.Product.Thumbnail,
+				AvailableStock: item.Product.AvailableStock,
+			},
+			Price:          item.Product.Price,
+			CompareAtPrice: item.Product.CompareAtPrice,
+			InStock:        item.AvailableStock() > 0,
+			AddedAt:        item.CreatedAt,
+		}
+		if item.Variant != nil {
+			resp.Variant = &WishlistVariantResponse{
+				ID:             item.Variant.ID,
+				SKU:            item.Variant.SKU,
+				Price:          item.Variant.Price,
+				CompareAtPrice: item.Variant.CompareAtPrice,
+				AvailableStock: item.Variant.AvailableStock,
+				Options:        item.Variant.Options,
+			}
+			resp.Price = item.Variant.Price
+			resp.CompareAtPrice = item.Variant.CompareAtPrice
+		}
+		items[i] = resp
+	}
+
+	return &WishlistResponse{
+		ID:        wishlist.ID,
+		UserID:    wishlist.UserID,
+		Items:     items,
+		ItemCount: len(items),
+		UpdatedAt: wishlist.UpdatedAt,
+	}
+}
@@ -0,0 +1,157 @@
+// internal/wishlist/service.go
+package wishlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/purushothdl/ecommerce-api/internal/domain"
+	"github.com/purushothdl/ecommerce-api/internal/models"
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+type wishlistService struct {
+	repo        domain.WishlistRepository
+	cartService domain.CartService
+	store       domain.Store
+	logger      *slog.Logger
+}
+
+func NewWishlistService(repo domain.WishlistRepository, cartService domain.CartService, store domain.Store, logger *slog.Logger) domain.WishlistService {
+	return &wishlistService{
+		repo:        repo,
+		cartService: cartService,
+		store:       store,
+		logger:      logger,
+	}
+}
+
+// GetOrCreateWishlist returns the user's wishlist, or the anonymous one named by the
+// cookie, creating it when there is none yet.
+func (s *wishlistService) GetOrCreateWishlist(ctx context.Context, userID *int64, anonymousWishlistID *int64) (*models.Wishlist, error) {
+	if userID != nil {
+		wishlist, err := s.repo.GetByUserID(ctx, *userID)
+		if err == nil {
+			return wishlist, nil
+		}
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			s.logger.Error("failed to get wishlist by user id", "user_id", *userID, "error", err)
+			return nil, err
+		}
+		return s.repo.Create(ctx, userID)
+	}
+
+	if anonymousWishlistID != nil {
+		wishlist, err := s.repo.GetByID(ctx, *anonymousWishlistID)
+		if err == nil {
+			if wishlist.UserID == nil {
+				return wishlist, nil
+			}
+			s.logger.Warn("wishlist id from cookie belongs to a registered user", "wishlist_id", *anonymousWishlistID)
+		}
+	}
+
+	return s.repo.Create(ctx, nil)
+}
+
+func (s *wishlistService) GetWishlistContents(ctx context.Context, wishlistID int64) (*models.Wishlist, error) {
+	wishlist, err := s.repo.GetByID(ctx, wishlistID)
+	if err != nil {
+		return nil, fmt.Errorf("wishlist service: could not retrieve wishlist: %w", err)
+	}
+
+	if wishlist.Items, err = s.repo.GetItemsByWishlistID(ctx, wishlistID); err != nil {
+		s.logger.Error("failed to get wishlist items", "wishlist_id", wishlistID, "error", err)
+		return nil, fmt.Errorf("wishlist service: could not retrieve wishlist items: %w", err)
+	}
+	return wishlist, nil
+}
+
+func (s *wishlistService) AddItem(ctx context.Context, wishlistID, productID int64, variantID *int64) (*models.Wishlist, error) {
+	if err := s.repo.AddItem(ctx, wishlistID, productID, variantID); err != nil {
+		s.logger.Warn("failed to add item to wishlist", "wishlist_id", wishlistID, "product_id", productID, "variant_id", variantID, "error", err)
+		return nil, fmt.Errorf("wishlist service: could not add item: %w", err)
+	}
+	return s.GetWishlistContents(ctx, wishlistID)
+}
+
+func (s *wishlistService) RemoveItem(ctx context.Context, wishlistID, itemID int64) (*models.Wishlist, error) {
+	if err := s.repo.RemoveItem(ctx, wishlistID, itemID); err != nil {
+		s.logger.Warn("failed to remove item from wishlist", "wishlist_id", wishlistID, "item_id", itemID, "error", err)
+		return nil, fmt.Errorf("wishlist service: could not remove item: %w", err)
+	}
+	return s.GetWishlistContents(ctx, wishlistID)
+}
+
+// MoveItemToCart adds a wishlist item to the cart and then takes it off the wishlist.
+// If the cart refuses it, for example for lack of stock, the item stays saved.
+func (s *wishlistService) MoveItemToCart(ctx context.Context, wishlistID, itemID, cartID int64, quantity int) (*models.Cart, error) {
+	items, err := s.repo.GetItemsByWishlistID(ctx, wishlistID)
+	if err != nil {
+		return nil, fmt.Errorf("wishlist service: could not retrieve wishlist items: %w", err)
+	}
+
+	var item *models.WishlistItem
+	for i := range items {
+		if items[i].ID == itemID {
+			item = &items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, fmt.Errorf("wishlist service: could not find item: %w", apperrors.ErrNotFound)
+	}
+
+	var variantID *int64
+	if item.Variant != nil {
+		variantID = &item.Variant.ID
+	}
+	cart, err := s.cartService.AddProductToCart(ctx, cartID, item.Product.ID, variantID, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("wishlist service: could not add item to cart: %w", err)
+	}
+
+	if err := s.repo.RemoveItem(ctx, wishlistID, itemID); err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+		// The item is in the cart already; leaving it saved as well is harmless.
+		s.logger.Error("failed to remove moved item from wishlist", "wishlist_id", wishlistID, "item_id", itemID, "error", err)
+	}
+
+	s.logger.Info("wishlist item moved to cart", "wishlist_id", wishlistID, "item_id", itemID, "cart_id", cartID)
+	return cart, nil
+}
+
+// HandleLoginWithTransaction merges an anonymous wishlist into the user's, inside the login transaction.
+func (s *wishlistService) HandleLoginWithTransaction(ctx context.Context, q *domain.Queries, userID int64, anonymousWishlistID int64) error {
+	if anonymousWishlistID == 0 {
+		return nil
+	}
+
+	anonymous, err := q.WishlistRepo.GetByID(ctx, anonymousWishlistID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get anonymous wishlist: %w", err)
+	}
+	// Only anonymous wishlists are merged; a stale cookie may name someone else's.
+	if anonymous.UserID != nil {
+		return nil
+	}
+
+	userWishlist, err := q.WishlistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			return fmt.Errorf("failed to get user wishlist: %w", err)
+		}
+		if userWishlist, err = q.WishlistRepo.Create(ctx, &userID); err != nil {
+			return fmt.Errorf("failed to create user wishlist: %w", err)
+		}
+	}
+
+	if err := q.WishlistRepo.MergeWishlists(ctx, anonymousWishlistID, userWishlist.ID); err != nil {
+		return fmt.Errorf("failed to merge wishlists: %w", err)
+	}
+	return q.WishlistRepo.Delete(ctx, anonymousWishlistID)
+}
@@ -0,0 +1,5 @@
+-- migrations/000014_add_archived_at_to_products.down.sql
+DROP INDEX IF EXISTS idx_products_archived_at;
+
+ALTER TABLE products
+DROP COLUMN archived_at;
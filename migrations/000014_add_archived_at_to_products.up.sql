@@ -0,0 +1,6 @@
+-- migrations/000014_add_archived_at_to_products.up.sql
+-- Archived products are hidden from the public catalog but kept for order history.
+ALTER TABLE products
+ADD COLUMN archived_at timestamp(0) with time zone;
+
+CREATE INDEX IF NOT EXISTS idx_products_archived_at ON products(archived_at) WHERE archived_at IS NULL;
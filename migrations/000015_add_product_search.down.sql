@@ -0,0 +1,15 @@
+-- migrations/000015_add_product_search.down.sql
+DROP INDEX IF EXISTS idx_products_brand_trgm;
+DROP INDEX IF EXISTS idx_products_name_trgm;
+DROP INDEX IF EXISTS idx_products_search_vector;
+
+DROP TRIGGER IF EXISTS trg_categories_search_vector ON categories;
+DROP FUNCTION IF EXISTS categories_search_vector_refresh();
+
+DROP TRIGGER IF EXISTS trg_products_search_vector ON products;
+DROP FUNCTION IF EXISTS products_search_vector_update();
+
+ALTER TABLE products
+DROP COLUMN search_vector;
+
+-- The pg_trgm extension is left installed; other objects may depend on it.
@@ -0,0 +1,46 @@
+-- migrations/000015_add_product_search.up.sql
+-- Full-text search over products with a trigram fallback for misspellings.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE products
+ADD COLUMN search_vector tsvector;
+
+-- The vector includes the category name, which lives in another table, so it
+-- cannot be a generated column. A trigger keeps it in sync instead.
+-- Weights: name (A) > brand (B) > category (C) > description (D).
+CREATE OR REPLACE FUNCTION products_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.brand, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce((SELECT name FROM categories WHERE id = NEW.category_id), '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(NEW.description, '')), 'D');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_search_vector
+BEFORE INSERT OR UPDATE OF name, brand, description, category_id ON products
+FOR EACH ROW EXECUTE FUNCTION products_search_vector_update();
+
+-- Renaming a category must refresh the vectors of its products.
+-- Touching category_id is enough to fire the products trigger above.
+CREATE OR REPLACE FUNCTION categories_search_vector_refresh() RETURNS trigger AS $$
+BEGIN
+    UPDATE products SET category_id = category_id WHERE category_id = NEW.id;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_categories_search_vector
+AFTER UPDATE OF name ON categories
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+EXECUTE FUNCTION categories_search_vector_refresh();
+
+-- Backfill existing rows.
+UPDATE products SET category_id = category_id;
+
+CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_products_brand_trgm ON products USING GIN (brand gin_trgm_ops);
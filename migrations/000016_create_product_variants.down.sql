@@ -0,0 +1,22 @@
+-- migrations/000016_create_product_variants.down.sql
+ALTER TABLE order_items
+DROP COLUMN IF EXISTS variant_options,
+DROP COLUMN IF EXISTS variant_id;
+
+-- Collapse variant lines back to one row per product before restoring the old constraint.
+DELETE FROM cart_items WHERE variant_id IS NOT NULL;
+
+ALTER TABLE cart_items
+DROP CONSTRAINT IF EXISTS cart_items_cart_product_variant_key;
+
+ALTER TABLE cart_items
+DROP COLUMN IF EXISTS variant_id;
+
+ALTER TABLE cart_items
+ADD CONSTRAINT cart_items_cart_id_product_id_key UNIQUE (cart_id, product_id);
+
+DROP TRIGGER IF EXISTS trg_product_variants_sync_stock ON product_variants;
+DROP FUNCTION IF EXISTS product_variants_sync_stock();
+
+DROP TABLE IF EXISTS product_variants;
+DROP TABLE IF EXISTS product_options;
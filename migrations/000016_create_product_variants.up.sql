@@ -0,0 +1,73 @@
+-- migrations/000016_create_product_variants.up.sql
+-- Variants let one product be sold in several sizes/colours, each with its own SKU, price and stock.
+
+-- Option types a product varies by, e.g. Size: [S, M, L] and Colour: [Red, Blue].
+CREATE TABLE IF NOT EXISTS product_options (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    "values" text[] NOT NULL,
+    position integer NOT NULL DEFAULT 0,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS product_variants (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku text NOT NULL UNIQUE,
+    price decimal(10, 2) NOT NULL,
+    stock_quantity integer NOT NULL CHECK (stock_quantity >= 0),
+    images text[] NOT NULL DEFAULT '{}',
+    -- Chosen value per option type, e.g. {"Size": "M", "Colour": "Red"}
+    options jsonb NOT NULL DEFAULT '{}',
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE (product_id, options)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_options_product_id ON product_options(product_id);
+CREATE INDEX IF NOT EXISTS idx_product_variants_product_id ON product_variants(product_id);
+
+-- For products with variants, products.stock_quantity mirrors the sum of variant
+-- stock so listings and the in-stock filter keep working without extra joins.
+CREATE OR REPLACE FUNCTION product_variants_sync_stock() RETURNS trigger AS $$
+DECLARE
+    target_product_id bigint;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target_product_id := OLD.product_id;
+    ELSE
+        target_product_id := NEW.product_id;
+    END IF;
+
+    UPDATE products
+    SET stock_quantity = COALESCE((SELECT SUM(stock_quantity) FROM product_variants WHERE product_id = target_product_id), 0),
+        updated_at = NOW()
+    WHERE id = target_product_id;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_product_variants_sync_stock
+AFTER INSERT OR DELETE OR UPDATE OF stock_quantity ON product_variants
+FOR EACH ROW EXECUTE FUNCTION product_variants_sync_stock();
+
+-- Cart items can now point at a specific variant. The same product may appear
+-- once per variant, so the uniqueness moves to (cart, product, variant).
+ALTER TABLE cart_items
+ADD COLUMN variant_id bigint REFERENCES product_variants(id) ON DELETE CASCADE;
+
+ALTER TABLE cart_items
+DROP CONSTRAINT IF EXISTS cart_items_cart_id_product_id_key;
+
+ALTER TABLE cart_items
+ADD CONSTRAINT cart_items_cart_product_variant_key UNIQUE NULLS NOT DISTINCT (cart_id, product_id, variant_id);
+
+-- Order items snapshot the chosen variant.
+ALTER TABLE order_items
+ADD COLUMN variant_id bigint REFERENCES product_variants(id) ON DELETE SET NULL,
+ADD COLUMN variant_options jsonb;
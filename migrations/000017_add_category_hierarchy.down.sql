@@ -0,0 +1,13 @@
+-- migrations/000017_add_category_hierarchy.down.sql
+ALTER TABLE products
+DROP CONSTRAINT IF EXISTS products_category_id_fkey,
+ADD CONSTRAINT products_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_categories_parent_id;
+
+ALTER TABLE categories
+DROP CONSTRAINT IF EXISTS categories_parent_not_self,
+DROP CONSTRAINT IF EXISTS categories_slug_key,
+DROP COLUMN IF EXISTS position,
+DROP COLUMN IF EXISTS slug,
+DROP COLUMN IF EXISTS parent_id;
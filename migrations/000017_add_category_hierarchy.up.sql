@@ -0,0 +1,30 @@
+-- migrations/000017_add_category_hierarchy.up.sql
+-- Categories become a tree: each category may have a parent, a URL slug and a sort position.
+
+ALTER TABLE categories
+ADD COLUMN parent_id bigint REFERENCES categories(id) ON DELETE RESTRICT,
+ADD COLUMN slug text,
+ADD COLUMN position integer NOT NULL DEFAULT 0;
+
+-- Backfill slugs from names; ids break ties between names that slugify the same way.
+UPDATE categories
+SET slug = trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'));
+
+UPDATE categories c
+SET slug = c.slug || '-' || c.id
+WHERE c.slug = '' OR EXISTS (
+    SELECT 1 FROM categories other WHERE other.slug = c.slug AND other.id < c.id
+);
+
+ALTER TABLE categories
+ALTER COLUMN slug SET NOT NULL,
+ADD CONSTRAINT categories_slug_key UNIQUE (slug),
+ADD CONSTRAINT categories_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+
+-- Deleting a category must never take its products with it. The service refuses
+-- to delete categories that still have products; this is the safety net.
+ALTER TABLE products
+DROP CONSTRAINT IF EXISTS products_category_id_fkey,
+ADD CONSTRAINT products_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT;
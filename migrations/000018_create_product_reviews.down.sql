@@ -0,0 +1,11 @@
+-- migrations/000018_create_product_reviews.down.sql
+DROP TRIGGER IF EXISTS trg_product_reviews_refresh_rating ON product_reviews;
+DROP FUNCTION IF EXISTS product_reviews_refresh_rating();
+
+DROP INDEX IF EXISTS idx_products_rating;
+
+ALTER TABLE products
+DROP COLUMN IF EXISTS review_count,
+DROP COLUMN IF EXISTS rating_average;
+
+DROP TABLE IF EXISTS product_reviews;
@@ -0,0 +1,54 @@
+-- migrations/000018_create_product_reviews.up.sql
+CREATE TABLE IF NOT EXISTS product_reviews (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating smallint NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title text NOT NULL,
+    body text NOT NULL,
+    -- Reviews are visible once written; admins can hide them during moderation.
+    status text NOT NULL DEFAULT 'published' CHECK (status IN ('published', 'hidden')),
+    moderation_note text,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_reviews_product_status ON product_reviews(product_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_product_reviews_status ON product_reviews(status, created_at DESC);
+
+-- Aggregates are kept on the product row so listings can show and sort by rating
+-- without joining reviews. Only published reviews count.
+ALTER TABLE products
+ADD COLUMN rating_average numeric(3, 2) NOT NULL DEFAULT 0,
+ADD COLUMN review_count integer NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_products_rating ON products(rating_average DESC, review_count DESC);
+
+CREATE OR REPLACE FUNCTION product_reviews_refresh_rating() RETURNS trigger AS $$
+DECLARE
+    target_product_id bigint;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target_product_id := OLD.product_id;
+    ELSE
+        target_product_id := NEW.product_id;
+    END IF;
+
+    UPDATE products p
+    SET rating_average = COALESCE(stats.average, 0),
+        review_count = stats.total
+    FROM (
+        SELECT ROUND(AVG(rating)::numeric, 2) AS average, COUNT(*) AS total
+        FROM product_reviews
+        WHERE product_id = target_product_id AND status = 'published'
+    ) stats
+    WHERE p.id = target_product_id;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_product_reviews_refresh_rating
+AFTER INSERT OR DELETE OR UPDATE OF rating, status ON product_reviews
+FOR EACH ROW EXECUTE FUNCTION product_reviews_refresh_rating();
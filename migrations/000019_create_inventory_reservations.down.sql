@@ -0,0 +1,3 @@
+-- migrations/000019_create_inventory_reservations.down.sql
+DROP FUNCTION IF EXISTS reserved_stock(bigint, bigint);
+DROP TABLE IF EXISTS inventory_reservations;
@@ -0,0 +1,34 @@
+-- migrations/000019_create_inventory_reservations.up.sql
+-- Checkout reserves stock instead of decrementing it. A reservation is converted into a
+-- real decrement when payment succeeds (committed) or given back when it expires or the
+-- order is cancelled (released).
+CREATE TABLE IF NOT EXISTS inventory_reservations (
+    id bigserial PRIMARY KEY,
+    order_id bigint NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id bigint NOT NULL UNIQUE REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id bigint REFERENCES product_variants(id) ON DELETE CASCADE,
+    quantity integer NOT NULL CHECK (quantity > 0),
+    status text NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'committed', 'released')),
+    expires_at timestamp(0) with time zone NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_reservations_order ON inventory_reservations(order_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_reservations_active_product
+    ON inventory_reservations(product_id, variant_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_inventory_reservations_active_expiry
+    ON inventory_reservations(expires_at) WHERE status = 'active';
+
+-- reserved_stock returns the quantity held by unexpired active reservations. A NULL
+-- variant means the whole product, including reservations made on any of its variants.
+-- Expired reservations stop counting immediately, even before they are marked released.
+CREATE OR REPLACE FUNCTION reserved_stock(p_product_id bigint, p_variant_id bigint) RETURNS integer AS $$
+    SELECT COALESCE(SUM(quantity), 0)::integer
+    FROM inventory_reservations
+    WHERE product_id = p_product_id
+      AND (p_variant_id IS NULL OR variant_id = p_variant_id)
+      AND status = 'active'
+      AND expires_at > NOW();
+$$ LANGUAGE sql STABLE;
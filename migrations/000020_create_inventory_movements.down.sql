@@ -0,0 +1,2 @@
+-- migrations/000020_create_inventory_movements.down.sql
+DROP TABLE IF EXISTS inventory_movements;
@@ -0,0 +1,19 @@
+-- migrations/000020_create_inventory_movements.up.sql
+-- Every change to a product's or variant's stock_quantity is written here in the same
+-- transaction, so stock levels can always be explained. Variant movements also move the
+-- product's mirrored total, so a product's history includes its variants' rows.
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id bigint REFERENCES product_variants(id) ON DELETE SET NULL,
+    delta integer NOT NULL CHECK (delta <> 0),
+    stock_after integer NOT NULL,
+    reason text NOT NULL CHECK (reason IN ('order', 'cancellation', 'cleanup', 'manual_adjustment', 'return')),
+    reference_id bigint,
+    actor_id bigint REFERENCES users(id) ON DELETE SET NULL,
+    note text NOT NULL DEFAULT '',
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_product ON inventory_movements(product_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_variant ON inventory_movements(variant_id) WHERE variant_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- migrations/000021_create_stock_alerts.down.sql
+DROP TRIGGER IF EXISTS trg_products_stock_alerts ON products;
+DROP FUNCTION IF EXISTS products_stock_alerts();
+DROP TABLE IF EXISTS stock_alerts;
+DROP TABLE IF EXISTS stock_subscriptions;
+ALTER TABLE products DROP COLUMN IF EXISTS low_stock_threshold;
@@ -0,0 +1,55 @@
+-- migrations/000021_create_stock_alerts.up.sql
+-- Admins are told when a product's stock falls to its low-stock threshold, and
+-- customers subscribed to a sold-out product are told when it is back.
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS low_stock_threshold integer CHECK (low_stock_threshold >= 0);
+
+CREATE TABLE IF NOT EXISTS stock_subscriptions (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    notified_at timestamp(0) with time zone,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_subscriptions_pending
+    ON stock_subscriptions(product_id) WHERE notified_at IS NULL;
+
+-- stock_alerts is an outbox of stock crossings. Rows are written by the trigger below
+-- in the same transaction as the stock change, and sent out later by the mega-worker.
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    kind text NOT NULL CHECK (kind IN ('low_stock', 'back_in_stock')),
+    stock_quantity integer NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    processed_at timestamp(0) with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_pending ON stock_alerts(id) WHERE processed_at IS NULL;
+
+-- Every stock write path ends in an UPDATE of products.stock_quantity, including
+-- variant changes through trg_product_variants_sync_stock, so one trigger sees them all.
+CREATE OR REPLACE FUNCTION products_stock_alerts() RETURNS trigger AS $$
+BEGIN
+    IF NEW.low_stock_threshold IS NOT NULL
+       AND OLD.stock_quantity > NEW.low_stock_threshold
+       AND NEW.stock_quantity <= NEW.low_stock_threshold THEN
+        INSERT INTO stock_alerts (product_id, kind, stock_quantity)
+        VALUES (NEW.id, 'low_stock', NEW.stock_quantity);
+    END IF;
+
+    IF OLD.stock_quantity <= 0 AND NEW.stock_quantity > 0
+       AND EXISTS (SELECT 1 FROM stock_subscriptions WHERE product_id = NEW.id AND notified_at IS NULL) THEN
+        INSERT INTO stock_alerts (product_id, kind, stock_quantity)
+        VALUES (NEW.id, 'back_in_stock', NEW.stock_quantity);
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_stock_alerts
+AFTER UPDATE OF stock_quantity ON products
+FOR EACH ROW EXECUTE FUNCTION products_stock_alerts();
@@ -0,0 +1,12 @@
+-- migrations/000022_create_warehouses.down.sql
+DROP FUNCTION IF EXISTS reserved_stock_at(bigint, bigint, bigint);
+
+ALTER TABLE inventory_movements DROP COLUMN IF EXISTS warehouse_id;
+ALTER TABLE inventory_reservations DROP COLUMN IF EXISTS warehouse_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS warehouse_id;
+
+-- Totals stay as they were last synced; they become the stock of record again.
+DROP TRIGGER IF EXISTS trg_warehouse_stock_sync_totals ON warehouse_stock;
+DROP FUNCTION IF EXISTS warehouse_stock_sync_totals();
+DROP TABLE IF EXISTS warehouse_stock;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,110 @@
+-- migrations/000022_create_warehouses.up.sql
+-- Stock is held per warehouse. products.stock_quantity (for products without variants)
+-- and product_variants.stock_quantity become totals kept in sync with warehouse_stock
+-- by the trigger below, so every stock change must now go through a warehouse row.
+CREATE TABLE IF NOT EXISTS warehouses (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL UNIQUE,
+    name text NOT NULL,
+    street1 text NOT NULL DEFAULT '',
+    city text NOT NULL DEFAULT '',
+    state text NOT NULL DEFAULT '',
+    postal_code text NOT NULL DEFAULT '',
+    country text NOT NULL DEFAULT '',
+    priority integer NOT NULL DEFAULT 100,
+    is_default boolean NOT NULL DEFAULT false,
+    is_active boolean NOT NULL DEFAULT true,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+-- The default warehouse takes stock edits that do not name a location.
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_warehouses_default ON warehouses(is_default) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    id bigserial PRIMARY KEY,
+    warehouse_id bigint NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id bigint REFERENCES product_variants(id) ON DELETE CASCADE,
+    quantity integer NOT NULL CHECK (quantity >= 0),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT warehouse_stock_location_key UNIQUE NULLS NOT DISTINCT (warehouse_id, product_id, variant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_warehouse_stock_product ON warehouse_stock(product_id, variant_id);
+
+-- Existing stock moves into a default warehouse before the sync trigger exists.
+INSERT INTO warehouses (code, name, priority, is_default) VALUES ('MAIN', 'Main warehouse', 0, true);
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, p.id, NULL, GREATEST(p.stock_quantity, 0)
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.is_default
+  AND NOT EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id);
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, v.product_id, v.id, v.stock_quantity
+FROM product_variants v
+CROSS JOIN warehouses w
+WHERE w.is_default;
+
+CREATE OR REPLACE FUNCTION warehouse_stock_sync_totals() RETURNS trigger AS $$
+DECLARE
+    target_product_id bigint;
+    target_variant_id bigint;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target_product_id := OLD.product_id;
+        target_variant_id := OLD.variant_id;
+    ELSE
+        target_product_id := NEW.product_id;
+        target_variant_id := NEW.variant_id;
+    END IF;
+
+    IF target_variant_id IS NULL THEN
+        UPDATE products
+        SET stock_quantity = COALESCE((SELECT SUM(quantity) FROM warehouse_stock WHERE product_id = target_product_id AND variant_id IS NULL), 0),
+            updated_at = NOW()
+        WHERE id = target_product_id;
+    ELSE
+        -- trg_product_variants_sync_stock carries the change on to the product total.
+        UPDATE product_variants
+        SET stock_quantity = COALESCE((SELECT SUM(quantity) FROM warehouse_stock WHERE variant_id = target_variant_id), 0),
+            updated_at = NOW()
+        WHERE id = target_variant_id;
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_warehouse_stock_sync_totals
+AFTER INSERT OR DELETE OR UPDATE OF quantity ON warehouse_stock
+FOR EACH ROW EXECUTE FUNCTION warehouse_stock_sync_totals();
+
+-- Orders ship from one allocated warehouse; reservations and ledger entries say which.
+ALTER TABLE orders
+ADD COLUMN IF NOT EXISTS warehouse_id bigint REFERENCES warehouses(id) ON DELETE SET NULL;
+
+ALTER TABLE inventory_reservations
+ADD COLUMN IF NOT EXISTS warehouse_id bigint REFERENCES warehouses(id) ON DELETE SET NULL;
+
+ALTER TABLE inventory_movements
+ADD COLUMN IF NOT EXISTS warehouse_id bigint REFERENCES warehouses(id) ON DELETE SET NULL;
+
+-- Reservations still holding stock were all taken from the single stock pool.
+UPDATE inventory_reservations
+SET warehouse_id = (SELECT id FROM warehouses WHERE is_default)
+WHERE status = 'active';
+
+-- reserved_stock_at is reserved_stock limited to one warehouse.
+CREATE OR REPLACE FUNCTION reserved_stock_at(p_warehouse_id bigint, p_product_id bigint, p_variant_id bigint) RETURNS integer AS $$
+    SELECT COALESCE(SUM(quantity), 0)::integer
+    FROM inventory_reservations
+    WHERE warehouse_id = p_warehouse_id
+      AND product_id = p_product_id
+      AND (p_variant_id IS NULL OR variant_id = p_variant_id)
+      AND status = 'active'
+      AND expires_at > NOW();
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,10 @@
+-- migrations/000023_create_price_schedules.down.sql
+ALTER TABLE order_items
+DROP COLUMN IF EXISTS compare_at_price,
+DROP COLUMN IF EXISTS price_schedule_id,
+DROP COLUMN IF EXISTS price_rule;
+
+DROP FUNCTION IF EXISTS sale_price_at(bigint, bigint, timestamptz);
+DROP FUNCTION IF EXISTS active_price_schedule(bigint, bigint, timestamptz);
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS price_schedules;
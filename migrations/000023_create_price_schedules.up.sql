@@ -0,0 +1,61 @@
+-- migrations/000023_create_price_schedules.up.sql
+-- products.price and product_variants.price stay the regular (base) price. A scheduled
+-- sale overrides it between starts_at and ends_at; what a buyer pays is resolved at
+-- read time, so a sale starts and ends without anything having to run.
+CREATE TABLE IF NOT EXISTS price_schedules (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id bigint REFERENCES product_variants(id) ON DELETE CASCADE,
+    sale_price decimal(10, 2) NOT NULL CHECK (sale_price > 0),
+    starts_at timestamp(0) with time zone NOT NULL,
+    ends_at timestamp(0) with time zone NOT NULL,
+    label text NOT NULL DEFAULT '',
+    created_by bigint REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    cancelled_at timestamp(0) with time zone,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_schedules_target
+    ON price_schedules(product_id, variant_id, starts_at) WHERE cancelled_at IS NULL;
+
+-- Every change to a regular price, written in the same transaction as the change.
+-- old_price is NULL for the price a product or variant was created with.
+CREATE TABLE IF NOT EXISTS price_history (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id bigint REFERENCES product_variants(id) ON DELETE SET NULL,
+    old_price decimal(10, 2),
+    new_price decimal(10, 2) NOT NULL,
+    actor_id bigint REFERENCES users(id) ON DELETE SET NULL,
+    note text NOT NULL DEFAULT '',
+    changed_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_history_product ON price_history(product_id, changed_at DESC, id DESC);
+
+-- active_price_schedule returns the sale running for a product (p_variant_id NULL) or
+-- one of its variants at p_at. A product-level sale does not apply to variants, which
+-- carry their own prices.
+CREATE OR REPLACE FUNCTION active_price_schedule(p_product_id bigint, p_variant_id bigint, p_at timestamptz) RETURNS bigint AS $$
+    SELECT id
+    FROM price_schedules
+    WHERE product_id = p_product_id
+      AND variant_id IS NOT DISTINCT FROM p_variant_id
+      AND cancelled_at IS NULL
+      AND starts_at <= p_at
+      AND ends_at > p_at
+    ORDER BY starts_at DESC, id DESC
+    LIMIT 1;
+$$ LANGUAGE sql STABLE;
+
+-- sale_price_at is the price of the sale active_price_schedule finds, or NULL.
+CREATE OR REPLACE FUNCTION sale_price_at(p_product_id bigint, p_variant_id bigint, p_at timestamptz) RETURNS decimal AS $$
+    SELECT sale_price FROM price_schedules WHERE id = active_price_schedule(p_product_id, p_variant_id, p_at);
+$$ LANGUAGE sql STABLE;
+
+-- Order lines record which price they were sold at: the regular price or a sale.
+ALTER TABLE order_items
+ADD COLUMN IF NOT EXISTS price_rule text NOT NULL DEFAULT 'base' CHECK (price_rule IN ('base', 'scheduled_sale')),
+ADD COLUMN IF NOT EXISTS price_schedule_id bigint REFERENCES price_schedules(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS compare_at_price decimal(10, 2);
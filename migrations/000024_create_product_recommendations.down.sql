@@ -0,0 +1,2 @@
+-- migrations/000024_create_product_recommendations.down.sql
+DROP TABLE IF EXISTS product_recommendations;
@@ -0,0 +1,16 @@
+-- migrations/000024_create_product_recommendations.up.sql
+-- Related products, rebuilt in full by the mega-worker's scheduled maintenance.
+-- 'bought_together' rows come from paid orders that contained both products; products
+-- with too few of those are topped up with 'similar' ones from the same category or brand.
+CREATE TABLE IF NOT EXISTS product_recommendations (
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    recommended_product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    kind text NOT NULL CHECK (kind IN ('bought_together', 'similar')),
+    score double precision NOT NULL,
+    position integer NOT NULL,
+    refreshed_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, recommended_product_id),
+    CHECK (product_id <> recommended_product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_recommendations_position ON product_recommendations(product_id, position);
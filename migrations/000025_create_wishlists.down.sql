@@ -0,0 +1,3 @@
+-- migrations/000025_create_wishlists.down.sql
+DROP TABLE IF EXISTS wishlist_items;
+DROP TABLE IF EXISTS wishlists;
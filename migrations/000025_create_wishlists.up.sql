@@ -0,0 +1,20 @@
+-- migrations/000025_create_wishlists.up.sql
+-- Wishlists work like carts: one per user, or an anonymous one tracked by cookie that is
+-- merged into the user's wishlist on login.
+CREATE TABLE IF NOT EXISTS wishlists (
+    id bigserial PRIMARY KEY,
+    user_id bigint UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS wishlist_items (
+    id bigserial PRIMARY KEY,
+    wishlist_id bigint NOT NULL REFERENCES wishlists(id) ON DELETE CASCADE,
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id bigint REFERENCES product_variants(id) ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT wishlist_items_wishlist_product_variant_key UNIQUE NULLS NOT DISTINCT (wishlist_id, product_id, variant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wishlist_items_wishlist_id_created_at ON wishlist_items(wishlist_id, created_at DESC);
@@ -0,0 +1,10 @@
+-- migrations/000026_add_status_to_products.down.sql
+DROP INDEX IF EXISTS idx_products_status;
+CREATE INDEX IF NOT EXISTS idx_products_archived_at ON products(archived_at) WHERE archived_at IS NULL;
+
+-- Drafts have no place in the old schema; they become visible like active products.
+ALTER TABLE products
+DROP CONSTRAINT IF EXISTS products_archived_at_matches_status,
+DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS product_status;
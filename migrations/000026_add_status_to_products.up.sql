@@ -0,0 +1,19 @@
+-- migrations/000026_add_status_to_products.up.sql
+-- A product is drafted, then active (listed and sellable), then archived (kept for
+-- order history but no longer sold). archived_at records when it was archived.
+CREATE TYPE product_status AS ENUM (
+    'draft',
+    'active',
+    'archived'
+);
+
+ALTER TABLE products
+ADD COLUMN status product_status NOT NULL DEFAULT 'active';
+
+UPDATE products SET status = 'archived' WHERE archived_at IS NOT NULL;
+
+ALTER TABLE products
+ADD CONSTRAINT products_archived_at_matches_status CHECK ((status = 'archived') = (archived_at IS NOT NULL));
+
+DROP INDEX IF EXISTS idx_products_archived_at;
+CREATE INDEX IF NOT EXISTS idx_products_status ON products(status);
@@ -0,0 +1,3 @@
+-- migrations/000027_create_product_attributes.down.sql
+DROP TABLE IF EXISTS product_attribute_values;
+DROP TABLE IF EXISTS category_attributes;
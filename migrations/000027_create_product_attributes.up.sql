@@ -0,0 +1,28 @@
+-- migrations/000027_create_product_attributes.up.sql
+-- Typed specs defined per category (e.g. RAM and screen size for laptops) and the
+-- values products filed in that category carry for them.
+CREATE TABLE IF NOT EXISTS category_attributes (
+    id bigserial PRIMARY KEY,
+    category_id bigint NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    -- Stable key used in listing filters, e.g. ?attr.ram=16GB
+    code text NOT NULL,
+    name text NOT NULL,
+    data_type text NOT NULL CHECK (data_type IN ('text', 'number', 'boolean')),
+    unit text NOT NULL DEFAULT '',
+    -- Empty means any value of the type is accepted
+    allowed_values text[] NOT NULL DEFAULT '{}',
+    position integer NOT NULL DEFAULT 0,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE (category_id, code)
+);
+
+-- Values are stored in a canonical text form so filters can match them exactly.
+CREATE TABLE IF NOT EXISTS product_attribute_values (
+    product_id bigint NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    attribute_id bigint NOT NULL REFERENCES category_attributes(id) ON DELETE CASCADE,
+    value text NOT NULL,
+    PRIMARY KEY (product_id, attribute_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_attribute_values_attribute_value ON product_attribute_values(attribute_id, value);
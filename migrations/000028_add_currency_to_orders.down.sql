@@ -0,0 +1,4 @@
+-- migrations/000028_add_currency_to_orders.down.sql
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,7 @@
+-- migrations/000028_add_currency_to_orders.up.sql
+-- Orders are charged in the customer's currency. Amounts on the order and its items are
+-- in that currency; exchange_rate is what one unit of the base currency was converted at.
+-- Existing orders were all charged in rupees.
+ALTER TABLE orders
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'INR' CHECK (currency ~ '^[A-Z]{3}$'),
+    ADD COLUMN exchange_rate NUMERIC(18, 8) NOT NULL DEFAULT 1 CHECK (exchange_rate > 0);
@@ -0,0 +1,2 @@
+-- migrations/000029_add_price_at_add_to_cart_items.down.sql
+ALTER TABLE cart_items DROP COLUMN IF EXISTS price_at_add;
@@ -0,0 +1,4 @@
+-- migrations/000029_add_price_at_add_to_cart_items.up.sql
+-- The unit price a cart line was added at, so the cart can warn when the price has
+-- changed since. Lines added before this column existed have no recorded price.
+ALTER TABLE cart_items ADD COLUMN price_at_add DECIMAL(10,2);
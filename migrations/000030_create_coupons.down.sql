@@ -0,0 +1,5 @@
+-- migrations/000030_create_coupons.down.sql
+DROP FUNCTION IF EXISTS category_subtree(bigint[]);
+DROP TABLE IF EXISTS coupon_redemptions;
+DROP TABLE IF EXISTS cart_coupons;
+DROP TABLE IF EXISTS coupons;
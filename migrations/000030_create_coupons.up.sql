@@ -0,0 +1,63 @@
+-- migrations/000030_create_coupons.up.sql
+-- Coupons replace the single flat discount every order used to get. Codes are stored
+-- upper-cased. Fixed values and minimums are in the base currency. A coupon with neither
+-- product_ids nor category_ids applies to the whole cart; otherwise it applies to those
+-- products and to products anywhere below those categories.
+CREATE TABLE IF NOT EXISTS coupons (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL UNIQUE CHECK (code = UPPER(code) AND code <> ''),
+    description text NOT NULL DEFAULT '',
+    discount_type text NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    value decimal(10, 2) NOT NULL CHECK (value > 0),
+    max_discount decimal(10, 2) CHECK (max_discount > 0),
+    min_cart_value decimal(10, 2) NOT NULL DEFAULT 0 CHECK (min_cart_value >= 0),
+    product_ids bigint[] NOT NULL DEFAULT '{}',
+    category_ids bigint[] NOT NULL DEFAULT '{}',
+    usage_limit integer CHECK (usage_limit > 0),
+    per_user_limit integer CHECK (per_user_limit > 0),
+    stackable boolean NOT NULL DEFAULT FALSE,
+    starts_at timestamp(0) with time zone,
+    ends_at timestamp(0) with time zone,
+    active boolean NOT NULL DEFAULT TRUE,
+    created_by bigint REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    CHECK (discount_type <> 'percentage' OR value <= 100),
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+-- Coupons a customer has applied to their cart, checked again at checkout.
+CREATE TABLE IF NOT EXISTS cart_coupons (
+    cart_id bigint NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    coupon_id bigint NOT NULL REFERENCES coupons(id) ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (cart_id, coupon_id)
+);
+
+-- One row per coupon used on an order, written in the order's transaction. Cancelling
+-- the order releases the redemption, which then no longer counts against the limits.
+CREATE TABLE IF NOT EXISTS coupon_redemptions (
+    id bigserial PRIMARY KEY,
+    coupon_id bigint NOT NULL REFERENCES coupons(id) ON DELETE RESTRICT,
+    order_id bigint NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount decimal(10, 2) NOT NULL CHECK (amount >= 0),
+    currency char(3) NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    released_at timestamp(0) with time zone,
+    UNIQUE (coupon_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_coupon_user
+    ON coupon_redemptions(coupon_id, user_id) WHERE released_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_order_id ON coupon_redemptions(order_id);
+
+-- category_subtree returns the given categories and every category below them.
+CREATE OR REPLACE FUNCTION category_subtree(p_ids bigint[]) RETURNS bigint[] AS $$
+    WITH RECURSIVE subtree AS (
+        SELECT id FROM categories WHERE id = ANY(p_ids)
+        UNION
+        SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+    )
+    SELECT COALESCE(array_agg(id), '{}') FROM subtree;
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,9 @@
+-- migrations/000031_add_named_carts_and_saved_items.down.sql
+ALTER TABLE cart_items DROP COLUMN IF EXISTS saved_for_later;
+
+DROP INDEX IF EXISTS idx_carts_user_name;
+DROP INDEX IF EXISTS idx_carts_user_default;
+ALTER TABLE carts DROP CONSTRAINT IF EXISTS carts_default_owner_check;
+ALTER TABLE carts
+    DROP COLUMN IF EXISTS is_default,
+    DROP COLUMN IF EXISTS name;
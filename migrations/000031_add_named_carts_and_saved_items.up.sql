@@ -0,0 +1,22 @@
+-- migrations/000031_add_named_carts_and_saved_items.up.sql
+-- Users can keep several named carts next to their default one, which is the cart used
+-- when no other is chosen and the one anonymous carts are merged into on login.
+ALTER TABLE carts
+    ADD COLUMN name varchar(100),
+    ADD COLUMN is_default boolean NOT NULL DEFAULT false;
+
+-- Until now every user had a single cart, which becomes their default. Should a user
+-- have ended up with more than one, the oldest is kept as the default.
+UPDATE carts SET is_default = true
+WHERE id IN (
+    SELECT DISTINCT ON (user_id) id FROM carts
+    WHERE user_id IS NOT NULL
+    ORDER BY user_id, created_at, id
+);
+
+ALTER TABLE carts ADD CONSTRAINT carts_default_owner_check CHECK (NOT is_default OR user_id IS NOT NULL);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_user_default ON carts(user_id) WHERE is_default;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_user_name ON carts(user_id, lower(name)) WHERE name IS NOT NULL;
+
+-- Lines saved for later stay in the cart but are left out of its total and of checkout.
+ALTER TABLE cart_items ADD COLUMN saved_for_later boolean NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+-- migrations/000032_create_cart_reminders.down.sql
+DROP TABLE IF EXISTS cart_reminders;
+ALTER TABLE users DROP COLUMN IF EXISTS cart_reminders_enabled;
@@ -0,0 +1,20 @@
+-- migrations/000032_create_cart_reminders.up.sql
+-- Customers can turn off abandoned cart reminders from the link in the email.
+ALTER TABLE users ADD COLUMN cart_reminders_enabled boolean NOT NULL DEFAULT true;
+
+-- One row per reminder email. When the customer later places an order from the cart,
+-- the reminders sent since their previous order are marked converted with it; a cart
+-- gets a fresh allowance of reminders after each order.
+CREATE TABLE IF NOT EXISTS cart_reminders (
+    id bigserial PRIMARY KEY,
+    cart_id bigint NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_count integer NOT NULL,
+    cart_total DECIMAL(10,2) NOT NULL,
+    sent_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    order_id bigint REFERENCES orders(id) ON DELETE SET NULL,
+    converted_at timestamp(0) with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_cart_reminders_cart_id ON cart_reminders(cart_id, sent_at DESC);
+CREATE INDEX IF NOT EXISTS idx_cart_reminders_sent_at ON cart_reminders(sent_at);
@@ -0,0 +1,20 @@
+-- migrations/000033_add_on_hold_order_status.down.sql
+-- Postgres cannot drop an enum value, so the type is rebuilt without it. Orders still on
+-- hold are cancelled; their payments must be refunded by hand.
+UPDATE orders SET status = 'cancelled' WHERE status = 'on_hold';
+
+ALTER TYPE order_status RENAME TO order_status_old;
+CREATE TYPE order_status AS ENUM (
+    'pending_payment',
+    'confirmed',
+    'processing',
+    'shipped',
+    'out_for_delivery',
+    'delivered',
+    'cancelled'
+);
+ALTER TABLE orders
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE order_status USING status::text::order_status,
+    ALTER COLUMN status SET DEFAULT 'pending_payment';
+DROP TYPE order_status_old;
@@ -0,0 +1,5 @@
+-- migrations/000033_add_on_hold_order_status.up.sql
+-- An order is put on hold when its payment arrives but the stock it reserved has lapsed
+-- and been sold elsewhere. The payment is kept and the order waits for a refund or for
+-- staff to fill it by hand.
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'on_hold' AFTER 'pending_payment';
@@ -0,0 +1,88 @@
+// pkg/cache/cache.go
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// Cache is an in-process key/value cache whose entries expire after a fixed TTL.
+// It is safe for concurrent use. Values are shared between callers, so they must
+// not be modified after being loaded.
+type Cache struct {
+	mu         sync.RWMutex
+	entries    map[string]entry
+	generation uint64 // Bumped by Flush so loads that raced with it are not stored
+	ttl        time.Duration
+	maxEntries int
+}
+
+// New returns a cache keeping entries for ttl and holding at most maxEntries of them.
+// A ttl of zero or less disables caching, so every lookup calls the loader.
+func New(ttl time.Duration, maxEntries int) *Cache {
+	return &Cache{
+		entries:    make(map[string]entry),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+// GetOrLoad returns the value cached under key, or calls load and caches its result.
+// Errors are not cached. A result loaded while the cache was flushed is returned but
+// not stored, since it may predate the change that caused the flush.
+func (c *Cache) GetOrLoad(key string, load func() (any, error)) (any, error) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	generation := c.generation
+	c.mu.RUnlock()
+	if ok && time.Now().Before(e.expiresAt) {
+		return e.value, nil
+	}
+
+	value, err := load()
+	if err != nil || c.ttl <= 0 {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return value, nil
+	}
+	if _, exists := c.entries[key]; !exists && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = entry{value: value, expiresAt: time.Now().Add(c.ttl)}
+	return value, nil
+}
+
+// Flush drops every entry. Call it after the data behind the cached values changes.
+func (c *Cache) Flush() {
+	c.mu.Lock()
+	c.entries = make(map[string]entry)
+	c.generation++
+	c.mu.Unlock()
+}
+
+// evict makes room for one entry: expired entries go first and, if none had
+// expired, an arbitrary one. The caller must hold the write lock.
+func (c *Cache) evict() {
+	now := time.Now()
+	for key, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) < c.maxEntries {
+		return
+	}
+	for key := range c.entries {
+		delete(c.entries, key)
+		return
+	}
+}
@@ -7,6 +7,7 @@ import "errors"
 var (
 	ErrNotFound = errors.New("not found")
 	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrEmptyCart = errors.New("cart is empty")
 )
 
 // User-related errors
@@ -26,3 +27,86 @@ var (
 	ErrWeakPassword       = errors.New("password too weak")
 	ErrUnauthorized       = errors.New("unauthorized")
 )
+
+
+// Product-related errors
+var (
+	ErrDuplicateSKU     = errors.New("duplicate sku")
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrProductHasOrders = errors.New("product is referenced by existing orders")
+	ErrProductInStock   = errors.New("product is in stock")
+	ErrProductUnavailable = errors.New("product is not available for purchase")
+)
+
+// Category-related errors
+var (
+	ErrDuplicateCategory   = errors.New("category name or slug already exists")
+	ErrCategoryHasProducts = errors.New("category still has products")
+	ErrCategoryHasChildren = errors.New("category still has subcategories")
+	ErrCategoryCycle       = errors.New("category cannot be moved under itself or its descendants")
+)
+
+// Attribute-related errors
+var (
+	ErrDuplicateAttribute    = errors.New("an attribute with this code already exists in the category")
+	ErrInvalidAttributeValue = errors.New("invalid attribute value")
+	ErrAttributeValuesInUse  = errors.New("attribute values are still used by existing products")
+)
+
+// Variant-related errors
+var (
+	ErrVariantRequired       = errors.New("a variant must be selected for this product")
+	ErrDuplicateVariant      = errors.New("a variant with these options already exists")
+	ErrInvalidVariantOptions = errors.New("variant options do not match the product's option types")
+	ErrOptionsInUse          = errors.New("option types are still used by existing variants")
+)
+
+// Warehouse-related errors
+var (
+	ErrDuplicateWarehouse     = errors.New("a warehouse with this code already exists")
+	ErrWarehouseInactive      = errors.New("warehouse is not active")
+	ErrDefaultWarehouseActive = errors.New("the default warehouse must stay active")
+)
+
+// Review-related errors
+var (
+	ErrReviewNotAllowed = errors.New("only customers with a delivered order for this product can review it")
+	ErrDuplicateReview  = errors.New("user has already reviewed this product")
+)
+
+// Upload-related errors
+var (
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrFileTooLarge         = errors.New("file exceeds the maximum upload size")
+	ErrImageTooLarge        = errors.New("image dimensions exceed the allowed maximum")
+)
+
+// Pricing-related errors
+var (
+	ErrPriceScheduleOverlap = errors.New("another sale is already scheduled for this period")
+	ErrSalePriceTooHigh     = errors.New("sale price must be below the regular price")
+	ErrPriceScheduleEnded   = errors.New("price schedule has already ended")
+)
+
+// Cart-related errors
+var (
+	ErrDuplicateCartName = errors.New("a cart with this name already exists")
+	ErrDefaultCart       = errors.New("the default cart cannot be deleted")
+)
+
+// Currency-related errors
+var (
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+)
+
+// Coupon-related errors
+var (
+	ErrDuplicateCoupon     = errors.New("a coupon with this code already exists")
+	ErrInvalidCoupon       = errors.New("invalid coupon")
+	ErrCouponNotActive     = errors.New("coupon is not active")
+	ErrCouponUsageLimit    = errors.New("coupon has reached its usage limit")
+	ErrCouponNotStackable  = errors.New("coupon cannot be combined with the other coupons on the cart")
+	ErrCouponMinimumNotMet = errors.New("cart total is below the coupon's minimum")
+	ErrCouponNotApplicable = errors.New("coupon does not apply to any item in the cart")
+	ErrCouponRedeemed      = errors.New("coupon has been redeemed; deactivate it instead")
+)
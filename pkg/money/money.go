@@ -0,0 +1,77 @@
+// pkg/money/money.go
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// zeroDecimal lists currencies that have no minor unit, so amounts are whole numbers
+// and are sent to the payment provider as is. These follow Stripe's list.
+var zeroDecimal = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true,
+	"KRW": true, "MGA": true, "PYG": true, "RWF": true, "UGX": true, "VND": true,
+	"VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// threeDecimal lists currencies whose minor unit is a thousandth. Stripe still only
+// accepts amounts in multiples of ten of those units, so they are rounded to two decimals.
+var threeDecimal = map[string]bool{
+	"BHD": true, "JOD": true, "KWD": true, "OMR": true, "TND": true,
+}
+
+// symbols are the prefixes used when formatting amounts. Other currencies are
+// written with their ISO code.
+var symbols = map[string]string{
+	"INR": "₹",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"AUD": "A$",
+	"CAD": "C$",
+	"SGD": "S$",
+}
+
+// Normalize upper-cases and trims an ISO 4217 currency code.
+func Normalize(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// Decimals is the number of decimal places amounts in the currency are kept to.
+func Decimals(currency string) int {
+	if zeroDecimal[Normalize(currency)] {
+		return 0
+	}
+	return 2
+}
+
+// Round rounds amount to the precision the currency is charged in.
+func Round(amount float64, currency string) float64 {
+	scale := math.Pow10(Decimals(currency))
+	return math.Round(amount*scale) / scale
+}
+
+// ToMinorUnits converts amount to the integer the payment provider expects: cents
+// for USD, paise for INR, yen for JPY and fils for KWD.
+func ToMinorUnits(amount float64, currency string) int64 {
+	exponent := 2
+	switch code := Normalize(currency); {
+	case zeroDecimal[code]:
+		exponent = 0
+	case threeDecimal[code]:
+		exponent = 3
+	}
+	return int64(math.Round(Round(amount, currency) * math.Pow10(exponent)))
+}
+
+// Format writes amount for display, e.g. "₹1499.00", "¥2600" or "CHF 12.50".
+func Format(amount float64, currency string) string {
+	code := Normalize(currency)
+	number := fmt.Sprintf("%.*f", Decimals(code), Round(amount, code))
+	if symbol, ok := symbols[code]; ok {
+		return symbol + number
+	}
+	return code + " " + number
+}
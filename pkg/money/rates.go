@@ -0,0 +1,48 @@
+// pkg/money/rates.go
+package money
+
+import (
+	"fmt"
+
+	apperrors "github.com/purushothdl/ecommerce-api/pkg/errors"
+)
+
+// Rates converts catalog prices, which are kept in the base currency, into the other
+// currencies the store sells in, using a fixed table of exchange rates.
+type Rates struct {
+	base  string
+	rates map[string]float64 // Units of the currency per one unit of the base currency
+}
+
+// NewRates returns a rate table for base. The base currency always converts at 1.
+func NewRates(base string, rates map[string]float64) *Rates {
+	base = Normalize(base)
+	table := map[string]float64{base: 1}
+	for code, rate := range rates {
+		table[Normalize(code)] = rate
+	}
+	return &Rates{base: base, rates: table}
+}
+
+// Base returns the currency catalog prices are stored in.
+func (r *Rates) Base() string {
+	return r.base
+}
+
+// Rate returns how many units of currency one unit of the base currency buys.
+func (r *Rates) Rate(currency string) (float64, error) {
+	rate, ok := r.rates[Normalize(currency)]
+	if !ok {
+		return 0, fmt.Errorf("%q: %w", currency, apperrors.ErrUnsupportedCurrency)
+	}
+	return rate, nil
+}
+
+// Convert turns an amount in the base currency into currency, rounded to its precision.
+func (r *Rates) Convert(amount float64, currency string) (float64, error) {
+	rate, err := r.Rate(currency)
+	if err != nil {
+		return 0, err
+	}
+	return Round(amount*rate, currency), nil
+}
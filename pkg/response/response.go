@@ -2,9 +2,15 @@
 package response
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // Response represents a standard API response
@@ -42,6 +48,53 @@ func JSON(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// CachedJSON sends a 200 JSON response that clients and proxies may cache for maxAge.
+// The ETag is a digest of the body, so it changes with anything that shows up in the
+// response (stock, prices) rather than only with the product version. A request whose
+// If-None-Match names the current ETag gets 304 Not Modified with no body.
+// lastModified is sent as Last-Modified when set.
+func CachedJSON(w http.ResponseWriter, r *http.Request, data any, lastModified time.Time, maxAge time.Duration) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(Response{Success: true, Data: data}); err != nil {
+		slog.Error("failed to encode JSON response", "error", err)
+		Error(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	if !lastModified.IsZero() {
+		h.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body.Bytes()); err != nil {
+		slog.Error("failed to write JSON response", "error", err)
+	}
+}
+
+// etagMatches reports whether an If-None-Match header names etag, using the weak
+// comparison RFC 9110 prescribes for that header.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 // Error sends an error response
 func Error(w http.ResponseWriter, status int, message string) {
 	response := Response{
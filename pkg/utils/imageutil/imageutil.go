@@ -0,0 +1,111 @@
+// pkg/utils/imageutil/imageutil.go
+package imageutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+// ErrUnsupportedFormat is returned for content that is not one of the accepted image types.
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// ErrTooManyPixels is returned for images whose dimensions exceed the allowed pixel count.
+var ErrTooManyPixels = errors.New("image dimensions too large")
+
+// allowedTypes maps the sniffed content types we accept to the file extension we store them under.
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// DetectType sniffs the content type from the file's leading bytes rather than trusting
+// the client, and returns it with the extension to store the file under.
+func DetectType(data []byte) (contentType, ext string, err error) {
+	contentType = http.DetectContentType(data)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return "", "", ErrUnsupportedFormat
+	}
+	return contentType, ext, nil
+}
+
+// Thumbnail decodes an image and returns a JPEG no larger than maxSize on its longest side.
+// Smaller images are re-encoded without being upscaled. The header is checked before
+// decoding so a small file declaring huge dimensions is rejected with ErrTooManyPixels
+// instead of being allocated.
+func Thumbnail(data []byte, maxSize, maxPixels int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > int64(maxPixels) {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resize(src, width, height), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resize scales src to width x height by averaging the source pixels covered by each
+// destination pixel. Transparent areas are flattened onto white since JPEG has no alpha.
+func resize(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/width)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					// Composite over white: colour + (1 - alpha) * white.
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					b += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: 0xffff,
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,27 @@
+// pkg/utils/slug/slug.go
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Make turns a display name into a lowercase, hyphen-separated URL slug,
+// e.g. "Men's Shoes & Boots" becomes "men-s-shoes-boots".
+// It mirrors the backfill used by the category hierarchy migration.
+func Make(name string) string {
+	var b strings.Builder
+	pendingDash := false
+	for _, r := range strings.ToLower(name) {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			pendingDash = false
+			continue
+		}
+		pendingDash = true
+	}
+	return b.String()
+}
@@ -10,6 +10,15 @@ import (
 // This is a simple regex, a more comprehensive one exists but is very complex.
 var (
 	EmailRX = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
+
+	// SlugRX matches lowercase, hyphen-separated URL slugs such as "running-shoes".
+	SlugRX = regexp.MustCompile("^[a-z0-9]+(?:-[a-z0-9]+)*$")
+
+	// AttributeCodeRX matches attribute codes such as "screen_size", which appear in query keys.
+	AttributeCodeRX = regexp.MustCompile("^[a-z][a-z0-9_]*$")
+
+	// CurrencyRX matches ISO 4217 currency codes such as "USD", in either case.
+	CurrencyRX = regexp.MustCompile("^[A-Za-z]{3}$")
 )
 
 // Validator contains a map of validation errors.
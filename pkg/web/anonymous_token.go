@@ -0,0 +1,97 @@
+// pkg/web/anonymous_token.go
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidAnonymousToken is returned for cart or wishlist cookies that are malformed,
+// carry a bad signature, or are bare IDs past the legacy window.
+var ErrInvalidAnonymousToken = errors.New("invalid anonymous token")
+
+// AnonymousTokens signs and verifies the cookie that identifies an anonymous shopper's
+// cart or wishlist. A token is the ID followed by an HMAC-SHA256 of it, so the ID cannot
+// be swapped for someone else's.
+type AnonymousTokens struct {
+	secret      []byte
+	purpose     string    // Mixed into the signature so a cart token is never a valid wishlist token
+	cookieName  string    // Cookie the token is read from
+	legacyUntil time.Time // Bare integer cookies are accepted before this time; zero rejects them
+}
+
+// NewCartTokens returns the tokens for the anonymous cart cookie.
+func NewCartTokens(secret string, legacyUntil time.Time) *AnonymousTokens {
+	return &AnonymousTokens{secret: []byte(secret), purpose: "cart", cookieName: CartIDCookieName, legacyUntil: legacyUntil}
+}
+
+// NewWishlistTokens returns the tokens for the anonymous wishlist cookie. Wishlist
+// cookies were signed from the start, so bare IDs are never accepted.
+func NewWishlistTokens(secret string) *AnonymousTokens {
+	return &AnonymousTokens{secret: []byte(secret), purpose: "wishlist", cookieName: WishlistIDCookieName}
+}
+
+// Sign returns the cookie value for a cart or wishlist ID.
+func (t *AnonymousTokens) Sign(id int64) string {
+	value := strconv.FormatInt(id, 10)
+	return value + "." + sign(t.secret, t.purpose, value)
+}
+
+// Verify returns the ID held by a token. legacy is true when the token was a bare ID
+// from before cookies were signed; callers should replace it with a signed one.
+func (t *AnonymousTokens) Verify(token string) (id int64, legacy bool, err error) {
+	value, sig, signed := strings.Cut(token, ".")
+	if !signed {
+		if t.legacyUntil.IsZero() || !time.Now().Before(t.legacyUntil) {
+			return 0, false, ErrInvalidAnonymousToken
+		}
+		if id, err = parseAnonymousID(token); err != nil {
+			return 0, false, err
+		}
+		return id, true, nil
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(sign(t.secret, t.purpose, value))) {
+		return 0, false, ErrInvalidAnonymousToken
+	}
+	if id, err = parseAnonymousID(value); err != nil {
+		return 0, false, err
+	}
+	return id, false, nil
+}
+
+// IDFromRequest reads and verifies the token's cookie, returning nil when it is missing
+// or invalid.
+func (t *AnonymousTokens) IDFromRequest(r *http.Request) *int64 {
+	cookie, err := r.Cookie(t.cookieName)
+	if err != nil {
+		return nil
+	}
+	id, _, err := t.Verify(cookie.Value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// sign returns the HMAC-SHA256 of value under secret. The purpose is mixed in so a token
+// minted for one use is never valid for another.
+func sign(secret []byte, purpose, value string) string {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(purpose + ":" + value))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+func parseAnonymousID(value string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidAnonymousToken
+	}
+	return id, nil
+}
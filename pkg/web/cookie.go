@@ -70,4 +70,19 @@ func SetCartCookie(w http.ResponseWriter, cartID string, isProduction bool) {
 		IsSecure:   isProduction,
 		SameSite:   http.SameSiteLaxMode, // Lax is often better for carts
 	})
-}
\ No newline at end of file
+}
+
+// Anonymous wishlists are tracked the same way as anonymous carts
+const WishlistIDCookieName = "wishlist_id"
+
+func SetWishlistCookie(w http.ResponseWriter, wishlistID string, isProduction bool) {
+	SetCookie(w, CookieSettings{
+		Name:       WishlistIDCookieName,
+		Value:      wishlistID,
+		Path:       "/",
+		MaxAge:     30 * 24 * 60 * 60, // 30 days
+		IsHTTPOnly: true,
+		IsSecure:   isProduction,
+		SameSite:   http.SameSiteLaxMode,
+	})
+}
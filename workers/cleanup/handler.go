@@ -14,8 +14,13 @@ type CleanupHandler struct {
 	logger                       *slog.Logger
 	orderService                 domain.OrderService
 	cartService                  domain.CartService
+	inventoryService             domain.InventoryService
+	recommendationService        domain.RecommendationService
+	cartReminderService          domain.CartReminderService
 	pendingOrderCleanupThreshold time.Duration
 	anonymousCartCleanupThreshold time.Duration
+	abandonedCartThreshold       time.Duration
+	abandonedCartMaxReminders    int
 }
 
 // Update the constructor to accept the CartService
@@ -23,15 +28,25 @@ func NewCleanupHandler(
 	logger *slog.Logger,
 	orderService domain.OrderService,
 	cartService domain.CartService,
+	inventoryService domain.InventoryService,
+	recommendationService domain.RecommendationService,
+	cartReminderService domain.CartReminderService,
 	pendingOrderCleanupThreshold time.Duration,
 	anonymousCartCleanupThreshold time.Duration,
+	abandonedCartThreshold time.Duration,
+	abandonedCartMaxReminders int,
 ) *CleanupHandler {
 	return &CleanupHandler{
 		logger:                       logger,
 		orderService:                 orderService,
 		cartService:                  cartService,
+		inventoryService:             inventoryService,
+		recommendationService:        recommendationService,
+		cartReminderService:          cartReminderService,
 		pendingOrderCleanupThreshold: pendingOrderCleanupThreshold,
 		anonymousCartCleanupThreshold: anonymousCartCleanupThreshold,
+		abandonedCartThreshold:       abandonedCartThreshold,
+		abandonedCartMaxReminders:    abandonedCartMaxReminders,
 	}
 }
 
@@ -80,6 +95,14 @@ func (h *CleanupHandler) HandleScheduledMaintenance(w http.ResponseWriter, r *ht
 		h.logger.Info("Maintenance sub-task successful: CleanupPendingOrders", "cleaned_count", orderCleanedCount)
 	}
 
+	// --- Run Reservation Expiry ---
+	releasedCount, reservationErr := h.orderService.ReleaseExpiredReservations(r.Context())
+	if reservationErr != nil {
+		h.logger.Error("Maintenance sub-task failed: ReleaseExpiredReservations", "error", reservationErr)
+	} else {
+		h.logger.Info("Maintenance sub-task successful: ReleaseExpiredReservations", "released_count", releasedCount)
+	}
+
 	// --- Run Cart Cleanup ---
 	cartCleanedCount, cartErr := h.cartService.CleanupOldAnonymousCarts(r.Context(), h.anonymousCartCleanupThreshold)
 	if cartErr != nil {
@@ -88,6 +111,30 @@ func (h *CleanupHandler) HandleScheduledMaintenance(w http.ResponseWriter, r *ht
 		h.logger.Info("Maintenance sub-task successful: CleanupOldAnonymousCarts", "cleaned_cart_count", cartCleanedCount)
 	}
 	
+	// --- Send Abandoned Cart Reminders ---
+	reminderCount, reminderErr := h.cartReminderService.SendAbandonedCartReminders(r.Context(), h.abandonedCartThreshold, h.abandonedCartMaxReminders)
+	if reminderErr != nil {
+		h.logger.Error("Maintenance sub-task failed: SendAbandonedCartReminders", "error", reminderErr, "sent_count", reminderCount)
+	} else {
+		h.logger.Info("Maintenance sub-task successful: SendAbandonedCartReminders", "sent_count", reminderCount)
+	}
+
+	// --- Send Stock Alerts ---
+	alertCount, alertErr := h.inventoryService.ProcessStockAlerts(r.Context())
+	if alertErr != nil {
+		h.logger.Error("Maintenance sub-task failed: ProcessStockAlerts", "error", alertErr, "processed_count", alertCount)
+	} else {
+		h.logger.Info("Maintenance sub-task successful: ProcessStockAlerts", "processed_count", alertCount)
+	}
+
+	// --- Refresh Recommendations ---
+	recommendationCount, recommendationErr := h.recommendationService.RefreshRecommendations(r.Context())
+	if recommendationErr != nil {
+		h.logger.Error("Maintenance sub-task failed: RefreshRecommendations", "error", recommendationErr)
+	} else {
+		h.logger.Info("Maintenance sub-task successful: RefreshRecommendations", "recommendation_count", recommendationCount)
+	}
+
 	h.logger.Info("--- All scheduled maintenance tasks have been run ---")
 	
 	// Always return a 200 OK so Cloud Scheduler doesn't retry unless there's a total crash.
@@ -28,6 +28,12 @@ func NewEmailService(apiKey string, fromEmail string, fromName string, logger *s
 
 // SendEmail is a generic method to send an email.
 func (s *EmailService) SendEmail(to, subject, htmlBody string) (string, error) {
+	return s.SendEmailWithHeaders(to, subject, htmlBody, nil)
+}
+
+// SendEmailWithHeaders sends an email with extra message headers, such as the
+// List-Unsubscribe headers marketing mail needs.
+func (s *EmailService) SendEmailWithHeaders(to, subject, htmlBody string, headers map[string]string) (string, error) {
 	fromHeader := fmt.Sprintf("%s <%s>", s.fromName, s.fromEmail)
 
 	params := &resend.SendEmailRequest{
@@ -35,6 +41,7 @@ func (s *EmailService) SendEmail(to, subject, htmlBody string) (string, error) {
 		To:      []string{to},
 		Subject: subject,
 		Html:    htmlBody,
+		Headers: headers,
 	}
 
 	sent, err := s.client.Emails.Send(params)
@@ -37,6 +37,7 @@ func (h *NotificationHandler) HandleNotificationRequest(w http.ResponseWriter, r
 	h.logger.Info("Received notification request", "type", event.Type, "email", event.UserEmail)
 
 	var subject, body string
+	var headers map[string]string
 	var err error
 
 	// This acts as a router for different notification types
@@ -65,6 +66,29 @@ func (h *NotificationHandler) HandleNotificationRequest(w http.ResponseWriter, r
 			subject, body, err = h.templateService.GenerateOrderDeliveredEmail(payload)
 		}
 
+	case "LOW_STOCK":
+		var payload events.LowStockEvent
+		if err = json.Unmarshal(event.Payload, &payload); err == nil {
+			subject, body, err = h.templateService.GenerateLowStockEmail(payload)
+		}
+
+	case "BACK_IN_STOCK":
+		var payload events.BackInStockEvent
+		if err = json.Unmarshal(event.Payload, &payload); err == nil {
+			subject, body, err = h.templateService.GenerateBackInStockEmail(payload)
+		}
+
+	case "ABANDONED_CART":
+		var payload events.AbandonedCartEvent
+		if err = json.Unmarshal(event.Payload, &payload); err == nil {
+			subject, body, err = h.templateService.GenerateAbandonedCartEmail(payload)
+			// RFC 8058 one-click unsubscribe: mail clients POST to the link themselves.
+			headers = map[string]string{
+				"List-Unsubscribe":      "<" + payload.UnsubscribeURL + ">",
+				"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+			}
+		}
+
 	default:
 		err = fmt.Errorf("unhandled notification type: %s", event.Type)
 	}	
@@ -76,7 +100,7 @@ func (h *NotificationHandler) HandleNotificationRequest(w http.ResponseWriter, r
 	}
 
 	// Send the generated email
-	if _, err := h.emailService.SendEmail(event.UserEmail, subject, body); err != nil {
+	if _, err := h.emailService.SendEmailWithHeaders(event.UserEmail, subject, body, headers); err != nil {
 		http.Error(w, "failed to send email", http.StatusInternalServerError)
 		return
 	}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/purushothdl/ecommerce-api/events"
+	"github.com/purushothdl/ecommerce-api/pkg/money"
 )
 
 //go:embed all:templates/*.gohtml
@@ -21,8 +22,13 @@ type TemplateService struct {
 
 // Custom template functions to format data nicely inside the HTML.
 var templateFuncs = template.FuncMap{
-	"formatAsMoney": func(amount float64) string {
-		return fmt.Sprintf("₹%.2f", amount)
+	// formatAsMoney formats an amount in its currency. Events queued before orders had a
+	// currency carry none, and those were all in rupees.
+	"formatAsMoney": func(amount float64, currency string) string {
+		if currency == "" {
+			currency = "INR"
+		}
+		return money.Format(amount, currency)
 	},
 	"formatAsDate": func(t time.Time) string {
 		return t.Format("02 Jan 2006")
@@ -77,4 +83,22 @@ func (s *TemplateService) GenerateOrderDeliveredEmail(payload events.OrderDelive
 	subject = fmt.Sprintf("Your GoKart Order #%s Has Been Delivered!", payload.OrderNumber)
 	body, err = s.execute("order_delivered.gohtml", payload)
 	return
-}
\ No newline at end of file
+}
+
+func (s *TemplateService) GenerateLowStockEmail(payload events.LowStockEvent) (subject string, body string, err error) {
+	subject = fmt.Sprintf("Low stock: %s (%d left)", payload.ProductName, payload.StockQuantity)
+	body, err = s.execute("low_stock.gohtml", payload)
+	return
+}
+
+func (s *TemplateService) GenerateBackInStockEmail(payload events.BackInStockEvent) (subject string, body string, err error) {
+	subject = fmt.Sprintf("%s is Back in Stock at GoKart!", payload.ProductName)
+	body, err = s.execute("back_in_stock.gohtml", payload)
+	return
+}
+
+func (s *TemplateService) GenerateAbandonedCartEmail(payload events.AbandonedCartEvent) (subject string, body string, err error) {
+	subject = "You Left Something in Your GoKart Cart"
+	body, err = s.execute("abandoned_cart.gohtml", payload)
+	return
+}
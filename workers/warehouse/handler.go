@@ -41,7 +41,12 @@ func (h *WarehouseHandler) HandleOrderCreated(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	h.logger.Info("Received order created event, processing warehouse fulfillment...", "order_id", event.OrderID)
+	// Orders placed before warehouses existed carry no location; they ship from the default one.
+	if event.Warehouse == nil {
+		h.logger.Warn("order created event has no allocated warehouse, using the default", "order_id", event.OrderID)
+	} else {
+		h.logger.Info("Received order created event, processing warehouse fulfillment...", "order_id", event.OrderID, "warehouse_id", event.Warehouse.ID, "warehouse_code", event.Warehouse.Code)
+	}
 
 	// Simulate work
 	time.Sleep(h.processingTime)